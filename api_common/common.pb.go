@@ -80,6 +80,7 @@ type UpdateServerStateRequest struct {
 	ClusterNumber     int32                          `protobuf:"varint,3,opt,name=ClusterNumber,proto3" json:"ClusterNumber,omitempty"`
 	DataItemsPerShard int32                          `protobuf:"varint,4,opt,name=DataItemsPerShard,proto3" json:"DataItemsPerShard,omitempty"`
 	Clusters          map[int32]*ClusterDistribution `protobuf:"bytes,5,rep,name=Clusters,proto3" json:"Clusters,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Faults            *FaultConfig                   `protobuf:"bytes,6,opt,name=Faults,proto3" json:"Faults,omitempty"`
 }
 
 func (x *UpdateServerStateRequest) Reset() {
@@ -149,22 +150,93 @@ func (x *UpdateServerStateRequest) GetClusters() map[int32]*ClusterDistribution
 	return nil
 }
 
+func (x *UpdateServerStateRequest) GetFaults() *FaultConfig {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
+type FaultConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Behaviors []string `protobuf:"bytes,1,rep,name=Behaviors,proto3" json:"Behaviors,omitempty"`
+	DelayMs   int32    `protobuf:"varint,2,opt,name=DelayMs,proto3" json:"DelayMs,omitempty"`
+	DropRate  float32  `protobuf:"fixed32,3,opt,name=DropRate,proto3" json:"DropRate,omitempty"`
+}
+
+func (x *FaultConfig) Reset() {
+	*x = FaultConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultConfig) ProtoMessage() {}
+
+func (x *FaultConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultConfig.ProtoReflect.Descriptor instead.
+func (*FaultConfig) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FaultConfig) GetBehaviors() []string {
+	if x != nil {
+		return x.Behaviors
+	}
+	return nil
+}
+
+func (x *FaultConfig) GetDelayMs() int32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *FaultConfig) GetDropRate() float32 {
+	if x != nil {
+		return x.DropRate
+	}
+	return 0
+}
+
 type TxnSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SetNo            int32         `protobuf:"varint,1,opt,name=SetNo,proto3" json:"SetNo,omitempty"`
-	Txns             []*TxnRequest `protobuf:"bytes,2,rep,name=Txns,proto3" json:"Txns,omitempty"`
-	LiveServers      []string      `protobuf:"bytes,3,rep,name=LiveServers,proto3" json:"LiveServers,omitempty"`
-	ContactServers   []string      `protobuf:"bytes,4,rep,name=ContactServers,proto3" json:"ContactServers,omitempty"`
-	ByzantineServers []string      `protobuf:"bytes,5,rep,name=ByzantineServers,proto3" json:"ByzantineServers,omitempty"`
+	SetNo            int32                   `protobuf:"varint,1,opt,name=SetNo,proto3" json:"SetNo,omitempty"`
+	Txns             []*TxnRequest           `protobuf:"bytes,2,rep,name=Txns,proto3" json:"Txns,omitempty"`
+	LiveServers      []string                `protobuf:"bytes,3,rep,name=LiveServers,proto3" json:"LiveServers,omitempty"`
+	ContactServers   []string                `protobuf:"bytes,4,rep,name=ContactServers,proto3" json:"ContactServers,omitempty"`
+	ByzantineServers []string                `protobuf:"bytes,5,rep,name=ByzantineServers,proto3" json:"ByzantineServers,omitempty"`
+	Faults           map[string]*FaultConfig `protobuf:"bytes,6,rep,name=Faults,proto3" json:"Faults,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *TxnSet) Reset() {
 	*x = TxnSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_msgTypes[2]
+		mi := &file_common_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -177,7 +249,7 @@ func (x *TxnSet) String() string {
 func (*TxnSet) ProtoMessage() {}
 
 func (x *TxnSet) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_msgTypes[2]
+	mi := &file_common_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -190,7 +262,7 @@ func (x *TxnSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TxnSet.ProtoReflect.Descriptor instead.
 func (*TxnSet) Descriptor() ([]byte, []int) {
-	return file_common_proto_rawDescGZIP(), []int{2}
+	return file_common_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TxnSet) GetSetNo() int32 {
@@ -228,28 +300,40 @@ func (x *TxnSet) GetByzantineServers() []string {
 	return nil
 }
 
+func (x *TxnSet) GetFaults() map[string]*FaultConfig {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
 type TxnRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxnID     string                 `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
-	Sender    int32                  `protobuf:"varint,2,opt,name=Sender,proto3" json:"Sender,omitempty"`
-	Receiver  int32                  `protobuf:"varint,3,opt,name=Receiver,proto3" json:"Receiver,omitempty"`
-	Amount    float32                `protobuf:"fixed32,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	SeqNo     int32                  `protobuf:"varint,5,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
-	ViewNo    int32                  `protobuf:"varint,6,opt,name=ViewNo,proto3" json:"ViewNo,omitempty"`
-	Type      string                 `protobuf:"bytes,7,opt,name=Type,proto3" json:"Type,omitempty"`
-	Status    string                 `protobuf:"bytes,8,opt,name=Status,proto3" json:"Status,omitempty"`
-	Digest    string                 `protobuf:"bytes,9,opt,name=digest,proto3" json:"digest,omitempty"`
-	Error     string                 `protobuf:"bytes,10,opt,name=Error,proto3" json:"Error,omitempty"`
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	TxnID           string                 `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	Sender          int32                  `protobuf:"varint,2,opt,name=Sender,proto3" json:"Sender,omitempty"`
+	Receiver        int32                  `protobuf:"varint,3,opt,name=Receiver,proto3" json:"Receiver,omitempty"`
+	Amount          float32                `protobuf:"fixed32,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	SeqNo           int32                  `protobuf:"varint,5,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
+	ViewNo          int32                  `protobuf:"varint,6,opt,name=ViewNo,proto3" json:"ViewNo,omitempty"`
+	Type            string                 `protobuf:"bytes,7,opt,name=Type,proto3" json:"Type,omitempty"`
+	Status          string                 `protobuf:"bytes,8,opt,name=Status,proto3" json:"Status,omitempty"`
+	Digest          string                 `protobuf:"bytes,9,opt,name=digest,proto3" json:"digest,omitempty"`
+	Error           string                 `protobuf:"bytes,10,opt,name=Error,proto3" json:"Error,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	BatchID         string                 `protobuf:"bytes,12,opt,name=BatchID,proto3" json:"BatchID,omitempty"`
+	Batch           []*TxnRequest          `protobuf:"bytes,13,rep,name=Batch,proto3" json:"Batch,omitempty"`
+	Legs            []*TxnLeg              `protobuf:"bytes,14,rep,name=Legs,proto3" json:"Legs,omitempty"`
+	ClientID        string                 `protobuf:"bytes,15,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	ClientTimestamp int64                  `protobuf:"varint,16,opt,name=ClientTimestamp,proto3" json:"ClientTimestamp,omitempty"`
 }
 
 func (x *TxnRequest) Reset() {
 	*x = TxnRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_msgTypes[3]
+		mi := &file_common_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -262,7 +346,7 @@ func (x *TxnRequest) String() string {
 func (*TxnRequest) ProtoMessage() {}
 
 func (x *TxnRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_msgTypes[3]
+	mi := &file_common_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +359,7 @@ func (x *TxnRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TxnRequest.ProtoReflect.Descriptor instead.
 func (*TxnRequest) Descriptor() ([]byte, []int) {
-	return file_common_proto_rawDescGZIP(), []int{3}
+	return file_common_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TxnRequest) GetTxnID() string {
@@ -355,82 +439,52 @@ func (x *TxnRequest) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type ProcessTxnResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Txn    *TxnRequest `protobuf:"bytes,1,opt,name=Txn,proto3" json:"Txn,omitempty"`
-	Status string      `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
-	Error  string      `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
-}
-
-func (x *ProcessTxnResponse) Reset() {
-	*x = ProcessTxnResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TxnRequest) GetBatchID() string {
+	if x != nil {
+		return x.BatchID
 	}
+	return ""
 }
 
-func (x *ProcessTxnResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ProcessTxnResponse) ProtoMessage() {}
-
-func (x *ProcessTxnResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *TxnRequest) GetBatch() []*TxnRequest {
+	if x != nil {
+		return x.Batch
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ProcessTxnResponse.ProtoReflect.Descriptor instead.
-func (*ProcessTxnResponse) Descriptor() ([]byte, []int) {
-	return file_common_proto_rawDescGZIP(), []int{4}
+	return nil
 }
 
-func (x *ProcessTxnResponse) GetTxn() *TxnRequest {
+func (x *TxnRequest) GetLegs() []*TxnLeg {
 	if x != nil {
-		return x.Txn
+		return x.Legs
 	}
 	return nil
 }
 
-func (x *ProcessTxnResponse) GetStatus() string {
+func (x *TxnRequest) GetClientID() string {
 	if x != nil {
-		return x.Status
+		return x.ClientID
 	}
 	return ""
 }
 
-func (x *ProcessTxnResponse) GetError() string {
+func (x *TxnRequest) GetClientTimestamp() int64 {
 	if x != nil {
-		return x.Error
+		return x.ClientTimestamp
 	}
-	return ""
+	return 0
 }
 
-type SignedMessage struct {
+type TxnLeg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ViewNumber           int32  `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
-	SequenceNumber       int32  `protobuf:"varint,2,opt,name=SequenceNumber,proto3" json:"SequenceNumber,omitempty"`
-	Digest               string `protobuf:"bytes,3,opt,name=Digest,proto3" json:"Digest,omitempty"`
-	LastExecutedSequence int32  `protobuf:"varint,4,opt,name=LastExecutedSequence,proto3" json:"LastExecutedSequence,omitempty"`
+	Receiver int32   `protobuf:"varint,1,opt,name=Receiver,proto3" json:"Receiver,omitempty"`
+	Amount   float32 `protobuf:"fixed32,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
 }
 
-func (x *SignedMessage) Reset() {
-	*x = SignedMessage{}
+func (x *TxnLeg) Reset() {
+	*x = TxnLeg{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -438,13 +492,13 @@ func (x *SignedMessage) Reset() {
 	}
 }
 
-func (x *SignedMessage) String() string {
+func (x *TxnLeg) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignedMessage) ProtoMessage() {}
+func (*TxnLeg) ProtoMessage() {}
 
-func (x *SignedMessage) ProtoReflect() protoreflect.Message {
+func (x *TxnLeg) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,53 +510,41 @@ func (x *SignedMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignedMessage.ProtoReflect.Descriptor instead.
-func (*SignedMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxnLeg.ProtoReflect.Descriptor instead.
+func (*TxnLeg) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SignedMessage) GetViewNumber() int32 {
-	if x != nil {
-		return x.ViewNumber
-	}
-	return 0
-}
-
-func (x *SignedMessage) GetSequenceNumber() int32 {
+func (x *TxnLeg) GetReceiver() int32 {
 	if x != nil {
-		return x.SequenceNumber
+		return x.Receiver
 	}
 	return 0
 }
 
-func (x *SignedMessage) GetDigest() string {
-	if x != nil {
-		return x.Digest
-	}
-	return ""
-}
-
-func (x *SignedMessage) GetLastExecutedSequence() int32 {
+func (x *TxnLeg) GetAmount() float32 {
 	if x != nil {
-		return x.LastExecutedSequence
+		return x.Amount
 	}
 	return 0
 }
 
-type PBFTRequestResponse struct {
+type ProcessTxnResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SignedMessage []byte `protobuf:"bytes,1,opt,name=SignedMessage,proto3" json:"SignedMessage,omitempty"`
-	Sign          []byte `protobuf:"bytes,2,opt,name=Sign,proto3" json:"Sign,omitempty"`
-	TxnRequest    []byte `protobuf:"bytes,3,opt,name=TxnRequest,proto3" json:"TxnRequest,omitempty"`
-	ServerNo      int32  `protobuf:"varint,4,opt,name=ServerNo,proto3" json:"ServerNo,omitempty"`
-	Outcome       string `protobuf:"bytes,5,opt,name=Outcome,proto3" json:"Outcome,omitempty"`
+	Txn         *TxnRequest `protobuf:"bytes,1,opt,name=Txn,proto3" json:"Txn,omitempty"`
+	Status      string      `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	Error       string      `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
+	ServerNo    int32       `protobuf:"varint,4,opt,name=ServerNo,proto3" json:"ServerNo,omitempty"`
+	SignedReply []byte      `protobuf:"bytes,5,opt,name=SignedReply,proto3" json:"SignedReply,omitempty"`
+	Sign        []byte      `protobuf:"bytes,6,opt,name=Sign,proto3" json:"Sign,omitempty"`
+	Balance     float32     `protobuf:"fixed32,7,opt,name=Balance,proto3" json:"Balance,omitempty"`
 }
 
-func (x *PBFTRequestResponse) Reset() {
-	*x = PBFTRequestResponse{}
+func (x *ProcessTxnResponse) Reset() {
+	*x = ProcessTxnResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -510,13 +552,13 @@ func (x *PBFTRequestResponse) Reset() {
 	}
 }
 
-func (x *PBFTRequestResponse) String() string {
+func (x *ProcessTxnResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PBFTRequestResponse) ProtoMessage() {}
+func (*ProcessTxnResponse) ProtoMessage() {}
 
-func (x *PBFTRequestResponse) ProtoReflect() protoreflect.Message {
+func (x *ProcessTxnResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -528,61 +570,75 @@ func (x *PBFTRequestResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PBFTRequestResponse.ProtoReflect.Descriptor instead.
-func (*PBFTRequestResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProcessTxnResponse.ProtoReflect.Descriptor instead.
+func (*ProcessTxnResponse) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PBFTRequestResponse) GetSignedMessage() []byte {
+func (x *ProcessTxnResponse) GetTxn() *TxnRequest {
 	if x != nil {
-		return x.SignedMessage
+		return x.Txn
 	}
 	return nil
 }
 
-func (x *PBFTRequestResponse) GetSign() []byte {
+func (x *ProcessTxnResponse) GetStatus() string {
 	if x != nil {
-		return x.Sign
+		return x.Status
 	}
-	return nil
+	return ""
 }
 
-func (x *PBFTRequestResponse) GetTxnRequest() []byte {
+func (x *ProcessTxnResponse) GetError() string {
 	if x != nil {
-		return x.TxnRequest
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *PBFTRequestResponse) GetServerNo() int32 {
+func (x *ProcessTxnResponse) GetServerNo() int32 {
 	if x != nil {
 		return x.ServerNo
 	}
 	return 0
 }
 
-func (x *PBFTRequestResponse) GetOutcome() string {
+func (x *ProcessTxnResponse) GetSignedReply() []byte {
 	if x != nil {
-		return x.Outcome
+		return x.SignedReply
 	}
-	return ""
+	return nil
 }
 
-type PBFTMessage struct {
+func (x *ProcessTxnResponse) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *ProcessTxnResponse) GetBalance() float32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type SignedReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxnID       string                 `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
-	MessageType string                 `protobuf:"bytes,2,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
-	Sender      int32                  `protobuf:"varint,3,opt,name=Sender,proto3" json:"Sender,omitempty"`
-	Sign        string                 `protobuf:"bytes,4,opt,name=Sign,proto3" json:"Sign,omitempty"`
-	Payload     string                 `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
-	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	TxnID           string  `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	ClientID        string  `protobuf:"bytes,2,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	ClientTimestamp int64   `protobuf:"varint,3,opt,name=ClientTimestamp,proto3" json:"ClientTimestamp,omitempty"`
+	Status          string  `protobuf:"bytes,4,opt,name=Status,proto3" json:"Status,omitempty"`
+	Error           string  `protobuf:"bytes,5,opt,name=Error,proto3" json:"Error,omitempty"`
+	Balance         float32 `protobuf:"fixed32,6,opt,name=Balance,proto3" json:"Balance,omitempty"`
 }
 
-func (x *PBFTMessage) Reset() {
-	*x = PBFTMessage{}
+func (x *SignedReply) Reset() {
+	*x = SignedReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -590,13 +646,13 @@ func (x *PBFTMessage) Reset() {
 	}
 }
 
-func (x *PBFTMessage) String() string {
+func (x *SignedReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PBFTMessage) ProtoMessage() {}
+func (*SignedReply) ProtoMessage() {}
 
-func (x *PBFTMessage) ProtoReflect() protoreflect.Message {
+func (x *SignedReply) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,65 +664,69 @@ func (x *PBFTMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PBFTMessage.ProtoReflect.Descriptor instead.
-func (*PBFTMessage) Descriptor() ([]byte, []int) {
+// Deprecated: Use SignedReply.ProtoReflect.Descriptor instead.
+func (*SignedReply) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *PBFTMessage) GetTxnID() string {
+func (x *SignedReply) GetTxnID() string {
 	if x != nil {
 		return x.TxnID
 	}
 	return ""
 }
 
-func (x *PBFTMessage) GetMessageType() string {
+func (x *SignedReply) GetClientID() string {
 	if x != nil {
-		return x.MessageType
+		return x.ClientID
 	}
 	return ""
 }
 
-func (x *PBFTMessage) GetSender() int32 {
+func (x *SignedReply) GetClientTimestamp() int64 {
 	if x != nil {
-		return x.Sender
+		return x.ClientTimestamp
 	}
 	return 0
 }
 
-func (x *PBFTMessage) GetSign() string {
+func (x *SignedReply) GetStatus() string {
 	if x != nil {
-		return x.Sign
+		return x.Status
 	}
 	return ""
 }
 
-func (x *PBFTMessage) GetPayload() string {
+func (x *SignedReply) GetError() string {
 	if x != nil {
-		return x.Payload
+		return x.Error
 	}
 	return ""
 }
 
-func (x *PBFTMessage) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SignedReply) GetBalance() float32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Balance
 	}
-	return nil
+	return 0
 }
 
-type Certificate struct {
+type TxnDigest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ViewNumber     int32          `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
-	SequenceNumber int32          `protobuf:"varint,2,opt,name=SequenceNumber,proto3" json:"SequenceNumber,omitempty"`
-	Messages       []*PBFTMessage `protobuf:"bytes,3,rep,name=Messages,proto3" json:"Messages,omitempty"`
+	Sender          int32     `protobuf:"varint,1,opt,name=Sender,proto3" json:"Sender,omitempty"`
+	Receiver        int32     `protobuf:"varint,2,opt,name=Receiver,proto3" json:"Receiver,omitempty"`
+	Amount          float32   `protobuf:"fixed32,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Legs            []*TxnLeg `protobuf:"bytes,4,rep,name=Legs,proto3" json:"Legs,omitempty"`
+	ReadOnly        bool      `protobuf:"varint,5,opt,name=ReadOnly,proto3" json:"ReadOnly,omitempty"`
+	ClientID        string    `protobuf:"bytes,6,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	ClientTimestamp int64     `protobuf:"varint,7,opt,name=ClientTimestamp,proto3" json:"ClientTimestamp,omitempty"`
 }
 
-func (x *Certificate) Reset() {
-	*x = Certificate{}
+func (x *TxnDigest) Reset() {
+	*x = TxnDigest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -674,13 +734,13 @@ func (x *Certificate) Reset() {
 	}
 }
 
-func (x *Certificate) String() string {
+func (x *TxnDigest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Certificate) ProtoMessage() {}
+func (*TxnDigest) ProtoMessage() {}
 
-func (x *Certificate) ProtoReflect() protoreflect.Message {
+func (x *TxnDigest) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -692,58 +752,88 @@ func (x *Certificate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
-func (*Certificate) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxnDigest.ProtoReflect.Descriptor instead.
+func (*TxnDigest) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Certificate) GetViewNumber() int32 {
+func (x *TxnDigest) GetSender() int32 {
 	if x != nil {
-		return x.ViewNumber
+		return x.Sender
 	}
 	return 0
 }
 
-func (x *Certificate) GetSequenceNumber() int32 {
+func (x *TxnDigest) GetReceiver() int32 {
 	if x != nil {
-		return x.SequenceNumber
+		return x.Receiver
 	}
 	return 0
 }
 
-func (x *Certificate) GetMessages() []*PBFTMessage {
+func (x *TxnDigest) GetAmount() float32 {
 	if x != nil {
-		return x.Messages
+		return x.Amount
 	}
-	return nil
+	return 0
 }
 
-type PerformanceResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Latency    *durationpb.Duration `protobuf:"bytes,1,opt,name=Latency,proto3" json:"Latency,omitempty"`
-	Throughput float32              `protobuf:"fixed32,2,opt,name=Throughput,proto3" json:"Throughput,omitempty"`
-	TxnCount   int32                `protobuf:"varint,3,opt,name=TxnCount,proto3" json:"TxnCount,omitempty"`
+func (x *TxnDigest) GetLegs() []*TxnLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
 }
 
-func (x *PerformanceResponse) Reset() {
-	*x = PerformanceResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TxnDigest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
 	}
+	return false
 }
 
-func (x *PerformanceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TxnDigest) GetClientID() string {
+	if x != nil {
+		return x.ClientID
+	}
+	return ""
 }
 
-func (*PerformanceResponse) ProtoMessage() {}
+func (x *TxnDigest) GetClientTimestamp() int64 {
+	if x != nil {
+		return x.ClientTimestamp
+	}
+	return 0
+}
 
-func (x *PerformanceResponse) ProtoReflect() protoreflect.Message {
+type ThresholdVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cluster         int32  `protobuf:"varint,1,opt,name=Cluster,proto3" json:"Cluster,omitempty"`
+	TxnID           string `protobuf:"bytes,2,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	ClientID        string `protobuf:"bytes,3,opt,name=ClientID,proto3" json:"ClientID,omitempty"`
+	ClientTimestamp int64  `protobuf:"varint,4,opt,name=ClientTimestamp,proto3" json:"ClientTimestamp,omitempty"`
+	Digest          string `protobuf:"bytes,5,opt,name=Digest,proto3" json:"Digest,omitempty"`
+}
+
+func (x *ThresholdVote) Reset() {
+	*x = ThresholdVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ThresholdVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThresholdVote) ProtoMessage() {}
+
+func (x *ThresholdVote) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,43 +845,57 @@ func (x *PerformanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PerformanceResponse.ProtoReflect.Descriptor instead.
-func (*PerformanceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ThresholdVote.ProtoReflect.Descriptor instead.
+func (*ThresholdVote) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PerformanceResponse) GetLatency() *durationpb.Duration {
+func (x *ThresholdVote) GetCluster() int32 {
 	if x != nil {
-		return x.Latency
+		return x.Cluster
 	}
-	return nil
+	return 0
 }
 
-func (x *PerformanceResponse) GetThroughput() float32 {
+func (x *ThresholdVote) GetTxnID() string {
 	if x != nil {
-		return x.Throughput
+		return x.TxnID
 	}
-	return 0
+	return ""
 }
 
-func (x *PerformanceResponse) GetTxnCount() int32 {
+func (x *ThresholdVote) GetClientID() string {
 	if x != nil {
-		return x.TxnCount
+		return x.ClientID
+	}
+	return ""
+}
+
+func (x *ThresholdVote) GetClientTimestamp() int64 {
+	if x != nil {
+		return x.ClientTimestamp
 	}
 	return 0
 }
 
-type PrintBalanceRequest struct {
+func (x *ThresholdVote) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type BatchDigest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
-	User   int32 `protobuf:"varint,2,opt,name=User,proto3" json:"User,omitempty"`
+	TxnIDs  []string `protobuf:"bytes,1,rep,name=TxnIDs,proto3" json:"TxnIDs,omitempty"`
+	Digests []string `protobuf:"bytes,2,rep,name=Digests,proto3" json:"Digests,omitempty"`
 }
 
-func (x *PrintBalanceRequest) Reset() {
-	*x = PrintBalanceRequest{}
+func (x *BatchDigest) Reset() {
+	*x = BatchDigest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -799,13 +903,13 @@ func (x *PrintBalanceRequest) Reset() {
 	}
 }
 
-func (x *PrintBalanceRequest) String() string {
+func (x *BatchDigest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrintBalanceRequest) ProtoMessage() {}
+func (*BatchDigest) ProtoMessage() {}
 
-func (x *PrintBalanceRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchDigest) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -817,35 +921,35 @@ func (x *PrintBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrintBalanceRequest.ProtoReflect.Descriptor instead.
-func (*PrintBalanceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchDigest.ProtoReflect.Descriptor instead.
+func (*BatchDigest) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PrintBalanceRequest) GetServer() int32 {
+func (x *BatchDigest) GetTxnIDs() []string {
 	if x != nil {
-		return x.Server
+		return x.TxnIDs
 	}
-	return 0
+	return nil
 }
 
-func (x *PrintBalanceRequest) GetUser() int32 {
+func (x *BatchDigest) GetDigests() []string {
 	if x != nil {
-		return x.User
+		return x.Digests
 	}
-	return 0
+	return nil
 }
 
-type PrintBalanceResponse struct {
+type CheckpointState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Balance map[int32]float32 `protobuf:"bytes,1,rep,name=Balance,proto3" json:"Balance,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+	Balances []*UserBalance `protobuf:"bytes,1,rep,name=Balances,proto3" json:"Balances,omitempty"`
 }
 
-func (x *PrintBalanceResponse) Reset() {
-	*x = PrintBalanceResponse{}
+func (x *CheckpointState) Reset() {
+	*x = CheckpointState{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -853,13 +957,13 @@ func (x *PrintBalanceResponse) Reset() {
 	}
 }
 
-func (x *PrintBalanceResponse) String() string {
+func (x *CheckpointState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrintBalanceResponse) ProtoMessage() {}
+func (*CheckpointState) ProtoMessage() {}
 
-func (x *PrintBalanceResponse) ProtoReflect() protoreflect.Message {
+func (x *CheckpointState) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -871,28 +975,29 @@ func (x *PrintBalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrintBalanceResponse.ProtoReflect.Descriptor instead.
-func (*PrintBalanceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckpointState.ProtoReflect.Descriptor instead.
+func (*CheckpointState) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *PrintBalanceResponse) GetBalance() map[int32]float32 {
+func (x *CheckpointState) GetBalances() []*UserBalance {
 	if x != nil {
-		return x.Balance
+		return x.Balances
 	}
 	return nil
 }
 
-type PrintDBRequest struct {
+type UserBalance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	User    int32   `protobuf:"varint,1,opt,name=User,proto3" json:"User,omitempty"`
+	Balance float32 `protobuf:"fixed32,2,opt,name=Balance,proto3" json:"Balance,omitempty"`
 }
 
-func (x *PrintDBRequest) Reset() {
-	*x = PrintDBRequest{}
+func (x *UserBalance) Reset() {
+	*x = UserBalance{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -900,13 +1005,13 @@ func (x *PrintDBRequest) Reset() {
 	}
 }
 
-func (x *PrintDBRequest) String() string {
+func (x *UserBalance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrintDBRequest) ProtoMessage() {}
+func (*UserBalance) ProtoMessage() {}
 
-func (x *PrintDBRequest) ProtoReflect() protoreflect.Message {
+func (x *UserBalance) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -918,28 +1023,38 @@ func (x *PrintDBRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrintDBRequest.ProtoReflect.Descriptor instead.
-func (*PrintDBRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserBalance.ProtoReflect.Descriptor instead.
+func (*UserBalance) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *PrintDBRequest) GetServer() int32 {
+func (x *UserBalance) GetUser() int32 {
 	if x != nil {
-		return x.Server
+		return x.User
 	}
 	return 0
 }
 
-type PrintDBResponse struct {
+func (x *UserBalance) GetBalance() float32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type SignedMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Txns []*TxnRequest `protobuf:"bytes,1,rep,name=Txns,proto3" json:"Txns,omitempty"`
+	ViewNumber           int32  `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
+	SequenceNumber       int32  `protobuf:"varint,2,opt,name=SequenceNumber,proto3" json:"SequenceNumber,omitempty"`
+	Digest               string `protobuf:"bytes,3,opt,name=Digest,proto3" json:"Digest,omitempty"`
+	LastExecutedSequence int32  `protobuf:"varint,4,opt,name=LastExecutedSequence,proto3" json:"LastExecutedSequence,omitempty"`
 }
 
-func (x *PrintDBResponse) Reset() {
-	*x = PrintDBResponse{}
+func (x *SignedMessage) Reset() {
+	*x = SignedMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -947,13 +1062,13 @@ func (x *PrintDBResponse) Reset() {
 	}
 }
 
-func (x *PrintDBResponse) String() string {
+func (x *SignedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrintDBResponse) ProtoMessage() {}
+func (*SignedMessage) ProtoMessage() {}
 
-func (x *PrintDBResponse) ProtoReflect() protoreflect.Message {
+func (x *SignedMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -965,29 +1080,55 @@ func (x *PrintDBResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrintDBResponse.ProtoReflect.Descriptor instead.
-func (*PrintDBResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SignedMessage.ProtoReflect.Descriptor instead.
+func (*SignedMessage) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *PrintDBResponse) GetTxns() []*TxnRequest {
+func (x *SignedMessage) GetViewNumber() int32 {
 	if x != nil {
-		return x.Txns
+		return x.ViewNumber
 	}
-	return nil
+	return 0
 }
 
-type BenchmarkRequest struct {
+func (x *SignedMessage) GetSequenceNumber() int32 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *SignedMessage) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *SignedMessage) GetLastExecutedSequence() int32 {
+	if x != nil {
+		return x.LastExecutedSequence
+	}
+	return 0
+}
+
+type PBFTRequestResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxnNumber      int32    `protobuf:"varint,1,opt,name=TxnNumber,proto3" json:"TxnNumber,omitempty"`
-	ContactServers []string `protobuf:"bytes,2,rep,name=ContactServers,proto3" json:"ContactServers,omitempty"`
+	SignedMessage  []byte `protobuf:"bytes,1,opt,name=SignedMessage,proto3" json:"SignedMessage,omitempty"`
+	Sign           []byte `protobuf:"bytes,2,opt,name=Sign,proto3" json:"Sign,omitempty"`
+	TxnRequest     []byte `protobuf:"bytes,3,opt,name=TxnRequest,proto3" json:"TxnRequest,omitempty"`
+	ServerNo       int32  `protobuf:"varint,4,opt,name=ServerNo,proto3" json:"ServerNo,omitempty"`
+	Outcome        string `protobuf:"bytes,5,opt,name=Outcome,proto3" json:"Outcome,omitempty"`
+	Authenticator  []byte `protobuf:"bytes,6,opt,name=Authenticator,proto3" json:"Authenticator,omitempty"`
+	ThresholdShare []byte `protobuf:"bytes,7,opt,name=ThresholdShare,proto3" json:"ThresholdShare,omitempty"`
 }
 
-func (x *BenchmarkRequest) Reset() {
-	*x = BenchmarkRequest{}
+func (x *PBFTRequestResponse) Reset() {
+	*x = PBFTRequestResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_common_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -995,13 +1136,13 @@ func (x *BenchmarkRequest) Reset() {
 	}
 }
 
-func (x *BenchmarkRequest) String() string {
+func (x *PBFTRequestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BenchmarkRequest) ProtoMessage() {}
+func (*PBFTRequestResponse) ProtoMessage() {}
 
-func (x *BenchmarkRequest) ProtoReflect() protoreflect.Message {
+func (x *PBFTRequestResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_common_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1013,247 +1154,2849 @@ func (x *BenchmarkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BenchmarkRequest.ProtoReflect.Descriptor instead.
-func (*BenchmarkRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PBFTRequestResponse.ProtoReflect.Descriptor instead.
+func (*PBFTRequestResponse) Descriptor() ([]byte, []int) {
 	return file_common_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *BenchmarkRequest) GetTxnNumber() int32 {
+func (x *PBFTRequestResponse) GetSignedMessage() []byte {
 	if x != nil {
-		return x.TxnNumber
+		return x.SignedMessage
 	}
-	return 0
+	return nil
 }
 
-func (x *BenchmarkRequest) GetContactServers() []string {
+func (x *PBFTRequestResponse) GetSign() []byte {
 	if x != nil {
-		return x.ContactServers
+		return x.Sign
 	}
 	return nil
 }
 
-var File_common_proto protoreflect.FileDescriptor
+func (x *PBFTRequestResponse) GetTxnRequest() []byte {
+	if x != nil {
+		return x.TxnRequest
+	}
+	return nil
+}
 
-var file_common_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x44,
-	0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x22, 0xd0, 0x02, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x73,
-	0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0b, 0x49, 0x73, 0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x12, 0x24, 0x0a, 0x0d,
-	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x50,
-	0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x44,
-	0x61, 0x74, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x50, 0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x64,
-	0x12, 0x4a, 0x0a, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x58, 0x0a, 0x0d,
-	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x44,
-	0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbc, 0x01, 0x0a, 0x06, 0x54, 0x78, 0x6e, 0x53, 0x65,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54,
-	0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x12,
-	0x20, 0x0a, 0x0b, 0x4c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x42, 0x79, 0x7a,
-	0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x10, 0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0xb0, 0x02, 0x0a, 0x0a, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65,
-	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16,
-	0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06,
-	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06,
-	0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x69,
-	0x65, 0x77, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x38,
-	0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x68, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24,
-	0x0a, 0x03, 0x54, 0x78, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x03, 0x54, 0x78, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x53, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
-	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x65, 0x64, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64,
-	0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xa5, 0x01, 0x0a, 0x13, 0x50, 0x42, 0x46,
-	0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x24, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x78,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
-	0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
-	0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x50, 0x42, 0x46, 0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x53, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x38,
-	0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x43, 0x65, 0x72,
-	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69,
-	0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x65, 0x71, 0x75,
-	0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x73, 0x22, 0x86, 0x01, 0x0a, 0x13, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x4c, 0x61, 0x74,
-	0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1e,
-	0x0a, 0x0a, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x02, 0x52, 0x0a, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x54, 0x78, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x54, 0x78, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x41, 0x0a, 0x13, 0x50, 0x72,
-	0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x22, 0x97, 0x01,
-	0x0a, 0x14, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x42,
-	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6e, 0x74,
-	0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x22, 0x39, 0x0a, 0x0f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x44, 0x42, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x22, 0x58, 0x0a, 0x10,
-	0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1c, 0x0a, 0x09, 0x54, 0x78, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x78, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x32, 0x85, 0x09, 0x0a, 0x06, 0x42, 0x79, 0x7a, 0x32, 0x50,
-	0x43, 0x12, 0x4d, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x12, 0x3e, 0x0a, 0x08, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x78, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x12, 0x37, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x78, 0x6e, 0x53, 0x65,
-	0x74, 0x12, 0x0e, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x53, 0x65,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x54, 0x78, 0x6e, 0x12, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
-	0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x50,
-	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46,
-	0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3d, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
-	0x40, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42,
-	0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4a, 0x0a, 0x13, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4b, 0x0a,
-	0x14, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50,
-	0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a, 0x12, 0x54, 0x77,
-	0x6f, 0x50, 0x43, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x54, 0x77,
-	0x6f, 0x50, 0x43, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x41, 0x62,
-	0x6f, 0x72, 0x74, 0x12, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
-	0x42, 0x0a, 0x0b, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69,
-	0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42,
-	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
-	0x0a, 0x07, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x44, 0x42, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74,
-	0x44, 0x42, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x42, 0x65,
-	0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x03,
-	0x5a, 0x01, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *PBFTRequestResponse) GetServerNo() int32 {
+	if x != nil {
+		return x.ServerNo
+	}
+	return 0
+}
+
+func (x *PBFTRequestResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *PBFTRequestResponse) GetAuthenticator() []byte {
+	if x != nil {
+		return x.Authenticator
+	}
+	return nil
+}
+
+func (x *PBFTRequestResponse) GetThresholdShare() []byte {
+	if x != nil {
+		return x.ThresholdShare
+	}
+	return nil
+}
+
+type PBFTMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnID          string                 `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	MessageType    string                 `protobuf:"bytes,2,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
+	Sender         int32                  `protobuf:"varint,3,opt,name=Sender,proto3" json:"Sender,omitempty"`
+	Sign           []byte                 `protobuf:"bytes,4,opt,name=Sign,proto3" json:"Sign,omitempty"`
+	Payload        []byte                 `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Authenticator  []byte                 `protobuf:"bytes,7,opt,name=Authenticator,proto3" json:"Authenticator,omitempty"`
+	ThresholdShare []byte                 `protobuf:"bytes,8,opt,name=ThresholdShare,proto3" json:"ThresholdShare,omitempty"`
+}
+
+func (x *PBFTMessage) Reset() {
+	*x = PBFTMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PBFTMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PBFTMessage) ProtoMessage() {}
+
+func (x *PBFTMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PBFTMessage.ProtoReflect.Descriptor instead.
+func (*PBFTMessage) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PBFTMessage) GetTxnID() string {
+	if x != nil {
+		return x.TxnID
+	}
+	return ""
+}
+
+func (x *PBFTMessage) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *PBFTMessage) GetSender() int32 {
+	if x != nil {
+		return x.Sender
+	}
+	return 0
+}
+
+func (x *PBFTMessage) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *PBFTMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PBFTMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PBFTMessage) GetAuthenticator() []byte {
+	if x != nil {
+		return x.Authenticator
+	}
+	return nil
+}
+
+func (x *PBFTMessage) GetThresholdShare() []byte {
+	if x != nil {
+		return x.ThresholdShare
+	}
+	return nil
+}
+
+type MAC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Receiver int32  `protobuf:"varint,1,opt,name=Receiver,proto3" json:"Receiver,omitempty"`
+	Mac      []byte `protobuf:"bytes,2,opt,name=Mac,proto3" json:"Mac,omitempty"`
+}
+
+func (x *MAC) Reset() {
+	*x = MAC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MAC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MAC) ProtoMessage() {}
+
+func (x *MAC) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MAC.ProtoReflect.Descriptor instead.
+func (*MAC) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MAC) GetReceiver() int32 {
+	if x != nil {
+		return x.Receiver
+	}
+	return 0
+}
+
+func (x *MAC) GetMac() []byte {
+	if x != nil {
+		return x.Mac
+	}
+	return nil
+}
+
+type Authenticator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Macs []*MAC `protobuf:"bytes,1,rep,name=Macs,proto3" json:"Macs,omitempty"`
+}
+
+func (x *Authenticator) Reset() {
+	*x = Authenticator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Authenticator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authenticator) ProtoMessage() {}
+
+func (x *Authenticator) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authenticator.ProtoReflect.Descriptor instead.
+func (*Authenticator) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Authenticator) GetMacs() []*MAC {
+	if x != nil {
+		return x.Macs
+	}
+	return nil
+}
+
+type SessionKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerNo  int32  `protobuf:"varint,1,opt,name=ServerNo,proto3" json:"ServerNo,omitempty"`
+	PublicKey []byte `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Sign      []byte `protobuf:"bytes,3,opt,name=Sign,proto3" json:"Sign,omitempty"`
+}
+
+func (x *SessionKey) Reset() {
+	*x = SessionKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionKey) ProtoMessage() {}
+
+func (x *SessionKey) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionKey.ProtoReflect.Descriptor instead.
+func (*SessionKey) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SessionKey) GetServerNo() int32 {
+	if x != nil {
+		return x.ServerNo
+	}
+	return 0
+}
+
+func (x *SessionKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SessionKey) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type RotateKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server  int32  `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	KeyType string `protobuf:"bytes,2,opt,name=KeyType,proto3" json:"KeyType,omitempty"`
+}
+
+func (x *RotateKeyRequest) Reset() {
+	*x = RotateKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyRequest) ProtoMessage() {}
+
+func (x *RotateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RotateKeyRequest) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+func (x *RotateKeyRequest) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+type KeyAnnouncement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address   string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Version   int32  `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	KeyType   string `protobuf:"bytes,3,opt,name=KeyType,proto3" json:"KeyType,omitempty"`
+	PublicKey []byte `protobuf:"bytes,4,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Sign      []byte `protobuf:"bytes,5,opt,name=Sign,proto3" json:"Sign,omitempty"`
+}
+
+func (x *KeyAnnouncement) Reset() {
+	*x = KeyAnnouncement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyAnnouncement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyAnnouncement) ProtoMessage() {}
+
+func (x *KeyAnnouncement) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyAnnouncement.ProtoReflect.Descriptor instead.
+func (*KeyAnnouncement) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KeyAnnouncement) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *KeyAnnouncement) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyAnnouncement) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+func (x *KeyAnnouncement) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *KeyAnnouncement) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type KeyAnnouncementsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Version int32  `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+}
+
+func (x *KeyAnnouncementsRequest) Reset() {
+	*x = KeyAnnouncementsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyAnnouncementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyAnnouncementsRequest) ProtoMessage() {}
+
+func (x *KeyAnnouncementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyAnnouncementsRequest.ProtoReflect.Descriptor instead.
+func (*KeyAnnouncementsRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *KeyAnnouncementsRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *KeyAnnouncementsRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type KeyAnnouncementsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Announcements []*KeyAnnouncement `protobuf:"bytes,1,rep,name=Announcements,proto3" json:"Announcements,omitempty"`
+}
+
+func (x *KeyAnnouncementsResponse) Reset() {
+	*x = KeyAnnouncementsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyAnnouncementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyAnnouncementsResponse) ProtoMessage() {}
+
+func (x *KeyAnnouncementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyAnnouncementsResponse.ProtoReflect.Descriptor instead.
+func (*KeyAnnouncementsResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *KeyAnnouncementsResponse) GetAnnouncements() []*KeyAnnouncement {
+	if x != nil {
+		return x.Announcements
+	}
+	return nil
+}
+
+type Certificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewNumber         int32          `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
+	SequenceNumber     int32          `protobuf:"varint,2,opt,name=SequenceNumber,proto3" json:"SequenceNumber,omitempty"`
+	Messages           []*PBFTMessage `protobuf:"bytes,3,rep,name=Messages,proto3" json:"Messages,omitempty"`
+	ThresholdSignature []byte         `protobuf:"bytes,4,opt,name=ThresholdSignature,proto3" json:"ThresholdSignature,omitempty"`
+}
+
+func (x *Certificate) Reset() {
+	*x = Certificate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Certificate) ProtoMessage() {}
+
+func (x *Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Certificate) GetViewNumber() int32 {
+	if x != nil {
+		return x.ViewNumber
+	}
+	return 0
+}
+
+func (x *Certificate) GetSequenceNumber() int32 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *Certificate) GetMessages() []*PBFTMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *Certificate) GetThresholdSignature() []byte {
+	if x != nil {
+		return x.ThresholdSignature
+	}
+	return nil
+}
+
+type SignedStatement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender        int32  `protobuf:"varint,1,opt,name=Sender,proto3" json:"Sender,omitempty"`
+	StatementType string `protobuf:"bytes,2,opt,name=StatementType,proto3" json:"StatementType,omitempty"`
+	Payload       []byte `protobuf:"bytes,3,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	Sign          []byte `protobuf:"bytes,4,opt,name=Sign,proto3" json:"Sign,omitempty"`
+}
+
+func (x *SignedStatement) Reset() {
+	*x = SignedStatement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedStatement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedStatement) ProtoMessage() {}
+
+func (x *SignedStatement) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedStatement.ProtoReflect.Descriptor instead.
+func (*SignedStatement) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SignedStatement) GetSender() int32 {
+	if x != nil {
+		return x.Sender
+	}
+	return 0
+}
+
+func (x *SignedStatement) GetStatementType() string {
+	if x != nil {
+		return x.StatementType
+	}
+	return ""
+}
+
+func (x *SignedStatement) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SignedStatement) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type MisbehaviorEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accused        int32            `protobuf:"varint,1,opt,name=Accused,proto3" json:"Accused,omitempty"`
+	ViewNumber     int32            `protobuf:"varint,2,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
+	SequenceNumber int32            `protobuf:"varint,3,opt,name=SequenceNumber,proto3" json:"SequenceNumber,omitempty"`
+	First          *SignedStatement `protobuf:"bytes,4,opt,name=First,proto3" json:"First,omitempty"`
+	Second         *SignedStatement `protobuf:"bytes,5,opt,name=Second,proto3" json:"Second,omitempty"`
+	Reporter       int32            `protobuf:"varint,6,opt,name=Reporter,proto3" json:"Reporter,omitempty"`
+}
+
+func (x *MisbehaviorEvidence) Reset() {
+	*x = MisbehaviorEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MisbehaviorEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MisbehaviorEvidence) ProtoMessage() {}
+
+func (x *MisbehaviorEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MisbehaviorEvidence.ProtoReflect.Descriptor instead.
+func (*MisbehaviorEvidence) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MisbehaviorEvidence) GetAccused() int32 {
+	if x != nil {
+		return x.Accused
+	}
+	return 0
+}
+
+func (x *MisbehaviorEvidence) GetViewNumber() int32 {
+	if x != nil {
+		return x.ViewNumber
+	}
+	return 0
+}
+
+func (x *MisbehaviorEvidence) GetSequenceNumber() int32 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *MisbehaviorEvidence) GetFirst() *SignedStatement {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *MisbehaviorEvidence) GetSecond() *SignedStatement {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
+func (x *MisbehaviorEvidence) GetReporter() int32 {
+	if x != nil {
+		return x.Reporter
+	}
+	return 0
+}
+
+type PreparedCertificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txn         *TxnRequest  `protobuf:"bytes,1,opt,name=Txn,proto3" json:"Txn,omitempty"`
+	Certificate *Certificate `protobuf:"bytes,2,opt,name=Certificate,proto3" json:"Certificate,omitempty"`
+}
+
+func (x *PreparedCertificate) Reset() {
+	*x = PreparedCertificate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreparedCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreparedCertificate) ProtoMessage() {}
+
+func (x *PreparedCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreparedCertificate.ProtoReflect.Descriptor instead.
+func (*PreparedCertificate) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PreparedCertificate) GetTxn() *TxnRequest {
+	if x != nil {
+		return x.Txn
+	}
+	return nil
+}
+
+func (x *PreparedCertificate) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+type ViewChangeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewNumber           int32                  `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
+	LastExecutedSequence int32                  `protobuf:"varint,2,opt,name=LastExecutedSequence,proto3" json:"LastExecutedSequence,omitempty"`
+	PreparedCertificates []*PreparedCertificate `protobuf:"bytes,3,rep,name=PreparedCertificates,proto3" json:"PreparedCertificates,omitempty"`
+	StableCheckpoint     int32                  `protobuf:"varint,4,opt,name=StableCheckpoint,proto3" json:"StableCheckpoint,omitempty"`
+	CheckpointMessages   []*PBFTMessage         `protobuf:"bytes,5,rep,name=CheckpointMessages,proto3" json:"CheckpointMessages,omitempty"`
+}
+
+func (x *ViewChangeMessage) Reset() {
+	*x = ViewChangeMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewChangeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewChangeMessage) ProtoMessage() {}
+
+func (x *ViewChangeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewChangeMessage.ProtoReflect.Descriptor instead.
+func (*ViewChangeMessage) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ViewChangeMessage) GetViewNumber() int32 {
+	if x != nil {
+		return x.ViewNumber
+	}
+	return 0
+}
+
+func (x *ViewChangeMessage) GetLastExecutedSequence() int32 {
+	if x != nil {
+		return x.LastExecutedSequence
+	}
+	return 0
+}
+
+func (x *ViewChangeMessage) GetPreparedCertificates() []*PreparedCertificate {
+	if x != nil {
+		return x.PreparedCertificates
+	}
+	return nil
+}
+
+func (x *ViewChangeMessage) GetStableCheckpoint() int32 {
+	if x != nil {
+		return x.StableCheckpoint
+	}
+	return 0
+}
+
+func (x *ViewChangeMessage) GetCheckpointMessages() []*PBFTMessage {
+	if x != nil {
+		return x.CheckpointMessages
+	}
+	return nil
+}
+
+type CommitCertificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txn         *TxnRequest  `protobuf:"bytes,1,opt,name=Txn,proto3" json:"Txn,omitempty"`
+	Certificate *Certificate `protobuf:"bytes,2,opt,name=Certificate,proto3" json:"Certificate,omitempty"`
+}
+
+func (x *CommitCertificate) Reset() {
+	*x = CommitCertificate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitCertificate) ProtoMessage() {}
+
+func (x *CommitCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitCertificate.ProtoReflect.Descriptor instead.
+func (*CommitCertificate) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CommitCertificate) GetTxn() *TxnRequest {
+	if x != nil {
+		return x.Txn
+	}
+	return nil
+}
+
+func (x *CommitCertificate) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+type StateTransferMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StableCheckpoint   int32                `protobuf:"varint,1,opt,name=StableCheckpoint,proto3" json:"StableCheckpoint,omitempty"`
+	State              string               `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	CheckpointMessages []*PBFTMessage       `protobuf:"bytes,3,rep,name=CheckpointMessages,proto3" json:"CheckpointMessages,omitempty"`
+	CommittedTxns      []*CommitCertificate `protobuf:"bytes,4,rep,name=CommittedTxns,proto3" json:"CommittedTxns,omitempty"`
+}
+
+func (x *StateTransferMessage) Reset() {
+	*x = StateTransferMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateTransferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateTransferMessage) ProtoMessage() {}
+
+func (x *StateTransferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateTransferMessage.ProtoReflect.Descriptor instead.
+func (*StateTransferMessage) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StateTransferMessage) GetStableCheckpoint() int32 {
+	if x != nil {
+		return x.StableCheckpoint
+	}
+	return 0
+}
+
+func (x *StateTransferMessage) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StateTransferMessage) GetCheckpointMessages() []*PBFTMessage {
+	if x != nil {
+		return x.CheckpointMessages
+	}
+	return nil
+}
+
+func (x *StateTransferMessage) GetCommittedTxns() []*CommitCertificate {
+	if x != nil {
+		return x.CommittedTxns
+	}
+	return nil
+}
+
+type NewViewMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewNumber       int32          `protobuf:"varint,1,opt,name=ViewNumber,proto3" json:"ViewNumber,omitempty"`
+	ViewChanges      []*PBFTMessage `protobuf:"bytes,2,rep,name=ViewChanges,proto3" json:"ViewChanges,omitempty"`
+	PrePrepares      []*TxnRequest  `protobuf:"bytes,3,rep,name=PrePrepares,proto3" json:"PrePrepares,omitempty"`
+	StableCheckpoint int32          `protobuf:"varint,4,opt,name=StableCheckpoint,proto3" json:"StableCheckpoint,omitempty"`
+}
+
+func (x *NewViewMessage) Reset() {
+	*x = NewViewMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewViewMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewViewMessage) ProtoMessage() {}
+
+func (x *NewViewMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewViewMessage.ProtoReflect.Descriptor instead.
+func (*NewViewMessage) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NewViewMessage) GetViewNumber() int32 {
+	if x != nil {
+		return x.ViewNumber
+	}
+	return 0
+}
+
+func (x *NewViewMessage) GetViewChanges() []*PBFTMessage {
+	if x != nil {
+		return x.ViewChanges
+	}
+	return nil
+}
+
+func (x *NewViewMessage) GetPrePrepares() []*TxnRequest {
+	if x != nil {
+		return x.PrePrepares
+	}
+	return nil
+}
+
+func (x *NewViewMessage) GetStableCheckpoint() int32 {
+	if x != nil {
+		return x.StableCheckpoint
+	}
+	return 0
+}
+
+type PerformanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latency    *durationpb.Duration `protobuf:"bytes,1,opt,name=Latency,proto3" json:"Latency,omitempty"`
+	Throughput float32              `protobuf:"fixed32,2,opt,name=Throughput,proto3" json:"Throughput,omitempty"`
+	TxnCount   int32                `protobuf:"varint,3,opt,name=TxnCount,proto3" json:"TxnCount,omitempty"`
+}
+
+func (x *PerformanceResponse) Reset() {
+	*x = PerformanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PerformanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerformanceResponse) ProtoMessage() {}
+
+func (x *PerformanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerformanceResponse.ProtoReflect.Descriptor instead.
+func (*PerformanceResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PerformanceResponse) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *PerformanceResponse) GetThroughput() float32 {
+	if x != nil {
+		return x.Throughput
+	}
+	return 0
+}
+
+func (x *PerformanceResponse) GetTxnCount() int32 {
+	if x != nil {
+		return x.TxnCount
+	}
+	return 0
+}
+
+type PrintBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	User   int32 `protobuf:"varint,2,opt,name=User,proto3" json:"User,omitempty"`
+}
+
+func (x *PrintBalanceRequest) Reset() {
+	*x = PrintBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintBalanceRequest) ProtoMessage() {}
+
+func (x *PrintBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintBalanceRequest.ProtoReflect.Descriptor instead.
+func (*PrintBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PrintBalanceRequest) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+func (x *PrintBalanceRequest) GetUser() int32 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+type PrintBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Balance map[int32]float32 `protobuf:"bytes,1,rep,name=Balance,proto3" json:"Balance,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+}
+
+func (x *PrintBalanceResponse) Reset() {
+	*x = PrintBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintBalanceResponse) ProtoMessage() {}
+
+func (x *PrintBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintBalanceResponse.ProtoReflect.Descriptor instead.
+func (*PrintBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PrintBalanceResponse) GetBalance() map[int32]float32 {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+type ReadBalanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User   int32  `protobuf:"varint,1,opt,name=User,proto3" json:"User,omitempty"`
+	ReadID string `protobuf:"bytes,2,opt,name=ReadID,proto3" json:"ReadID,omitempty"`
+}
+
+func (x *ReadBalanceRequest) Reset() {
+	*x = ReadBalanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadBalanceRequest) ProtoMessage() {}
+
+func (x *ReadBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadBalanceRequest.ProtoReflect.Descriptor instead.
+func (*ReadBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReadBalanceRequest) GetUser() int32 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *ReadBalanceRequest) GetReadID() string {
+	if x != nil {
+		return x.ReadID
+	}
+	return ""
+}
+
+type ReadBalanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User       int32   `protobuf:"varint,1,opt,name=User,proto3" json:"User,omitempty"`
+	Balance    float32 `protobuf:"fixed32,2,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	SeqNo      int32   `protobuf:"varint,3,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
+	ServerNo   int32   `protobuf:"varint,4,opt,name=ServerNo,proto3" json:"ServerNo,omitempty"`
+	IsOrdered  bool    `protobuf:"varint,5,opt,name=IsOrdered,proto3" json:"IsOrdered,omitempty"`
+	SignedRead []byte  `protobuf:"bytes,6,opt,name=SignedRead,proto3" json:"SignedRead,omitempty"`
+	Sign       []byte  `protobuf:"bytes,7,opt,name=Sign,proto3" json:"Sign,omitempty"`
+}
+
+func (x *ReadBalanceResponse) Reset() {
+	*x = ReadBalanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadBalanceResponse) ProtoMessage() {}
+
+func (x *ReadBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadBalanceResponse.ProtoReflect.Descriptor instead.
+func (*ReadBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReadBalanceResponse) GetUser() int32 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *ReadBalanceResponse) GetBalance() float32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *ReadBalanceResponse) GetSeqNo() int32 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ReadBalanceResponse) GetServerNo() int32 {
+	if x != nil {
+		return x.ServerNo
+	}
+	return 0
+}
+
+func (x *ReadBalanceResponse) GetIsOrdered() bool {
+	if x != nil {
+		return x.IsOrdered
+	}
+	return false
+}
+
+func (x *ReadBalanceResponse) GetSignedRead() []byte {
+	if x != nil {
+		return x.SignedRead
+	}
+	return nil
+}
+
+func (x *ReadBalanceResponse) GetSign() []byte {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+type SignedRead struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReadID  string  `protobuf:"bytes,1,opt,name=ReadID,proto3" json:"ReadID,omitempty"`
+	User    int32   `protobuf:"varint,2,opt,name=User,proto3" json:"User,omitempty"`
+	Balance float32 `protobuf:"fixed32,3,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	SeqNo   int32   `protobuf:"varint,4,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
+}
+
+func (x *SignedRead) Reset() {
+	*x = SignedRead{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedRead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedRead) ProtoMessage() {}
+
+func (x *SignedRead) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedRead.ProtoReflect.Descriptor instead.
+func (*SignedRead) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SignedRead) GetReadID() string {
+	if x != nil {
+		return x.ReadID
+	}
+	return ""
+}
+
+func (x *SignedRead) GetUser() int32 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *SignedRead) GetBalance() float32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *SignedRead) GetSeqNo() int32 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+type PrintDBRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *PrintDBRequest) Reset() {
+	*x = PrintDBRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintDBRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintDBRequest) ProtoMessage() {}
+
+func (x *PrintDBRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintDBRequest.ProtoReflect.Descriptor instead.
+func (*PrintDBRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PrintDBRequest) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+type PrintDBResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txns []*TxnRequest `protobuf:"bytes,1,rep,name=Txns,proto3" json:"Txns,omitempty"`
+}
+
+func (x *PrintDBResponse) Reset() {
+	*x = PrintDBResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintDBResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintDBResponse) ProtoMessage() {}
+
+func (x *PrintDBResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintDBResponse.ProtoReflect.Descriptor instead.
+func (*PrintDBResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PrintDBResponse) GetTxns() []*TxnRequest {
+	if x != nil {
+		return x.Txns
+	}
+	return nil
+}
+
+type PrintLocksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *PrintLocksRequest) Reset() {
+	*x = PrintLocksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintLocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintLocksRequest) ProtoMessage() {}
+
+func (x *PrintLocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintLocksRequest.ProtoReflect.Descriptor instead.
+func (*PrintLocksRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PrintLocksRequest) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+type LockInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User    int32    `protobuf:"varint,1,opt,name=User,proto3" json:"User,omitempty"`
+	Holder  string   `protobuf:"bytes,2,opt,name=Holder,proto3" json:"Holder,omitempty"`
+	Waiters []string `protobuf:"bytes,3,rep,name=Waiters,proto3" json:"Waiters,omitempty"`
+}
+
+func (x *LockInfo) Reset() {
+	*x = LockInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LockInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockInfo) ProtoMessage() {}
+
+func (x *LockInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockInfo.ProtoReflect.Descriptor instead.
+func (*LockInfo) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LockInfo) GetUser() int32 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *LockInfo) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+func (x *LockInfo) GetWaiters() []string {
+	if x != nil {
+		return x.Waiters
+	}
+	return nil
+}
+
+type PrintLocksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locks []*LockInfo `protobuf:"bytes,1,rep,name=Locks,proto3" json:"Locks,omitempty"`
+}
+
+func (x *PrintLocksResponse) Reset() {
+	*x = PrintLocksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintLocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintLocksResponse) ProtoMessage() {}
+
+func (x *PrintLocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintLocksResponse.ProtoReflect.Descriptor instead.
+func (*PrintLocksResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PrintLocksResponse) GetLocks() []*LockInfo {
+	if x != nil {
+		return x.Locks
+	}
+	return nil
+}
+
+type EvidenceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server int32 `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *EvidenceRequest) Reset() {
+	*x = EvidenceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceRequest) ProtoMessage() {}
+
+func (x *EvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceRequest.ProtoReflect.Descriptor instead.
+func (*EvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *EvidenceRequest) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+type EvidenceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evidence    []*MisbehaviorEvidence `protobuf:"bytes,1,rep,name=Evidence,proto3" json:"Evidence,omitempty"`
+	Blacklisted []int32                `protobuf:"varint,2,rep,packed,name=Blacklisted,proto3" json:"Blacklisted,omitempty"`
+}
+
+func (x *EvidenceResponse) Reset() {
+	*x = EvidenceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceResponse) ProtoMessage() {}
+
+func (x *EvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceResponse.ProtoReflect.Descriptor instead.
+func (*EvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *EvidenceResponse) GetEvidence() []*MisbehaviorEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *EvidenceResponse) GetBlacklisted() []int32 {
+	if x != nil {
+		return x.Blacklisted
+	}
+	return nil
+}
+
+type TxnStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnID string `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+}
+
+func (x *TxnStatusRequest) Reset() {
+	*x = TxnStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnStatusRequest) ProtoMessage() {}
+
+func (x *TxnStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnStatusRequest.ProtoReflect.Descriptor instead.
+func (*TxnStatusRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TxnStatusRequest) GetTxnID() string {
+	if x != nil {
+		return x.TxnID
+	}
+	return ""
+}
+
+type ReplicaTxnStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server int32  `protobuf:"varint,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	SeqNo  int32  `protobuf:"varint,3,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
+	ViewNo int32  `protobuf:"varint,4,opt,name=ViewNo,proto3" json:"ViewNo,omitempty"`
+	Error  string `protobuf:"bytes,5,opt,name=Error,proto3" json:"Error,omitempty"`
+}
+
+func (x *ReplicaTxnStatus) Reset() {
+	*x = ReplicaTxnStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicaTxnStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaTxnStatus) ProtoMessage() {}
+
+func (x *ReplicaTxnStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaTxnStatus.ProtoReflect.Descriptor instead.
+func (*ReplicaTxnStatus) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReplicaTxnStatus) GetServer() int32 {
+	if x != nil {
+		return x.Server
+	}
+	return 0
+}
+
+func (x *ReplicaTxnStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReplicaTxnStatus) GetSeqNo() int32 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ReplicaTxnStatus) GetViewNo() int32 {
+	if x != nil {
+		return x.ViewNo
+	}
+	return 0
+}
+
+func (x *ReplicaTxnStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ClusterTxnStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cluster             int32               `protobuf:"varint,1,opt,name=Cluster,proto3" json:"Cluster,omitempty"`
+	Status              string              `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	SeqNo               int32               `protobuf:"varint,3,opt,name=SeqNo,proto3" json:"SeqNo,omitempty"`
+	ViewNo              int32               `protobuf:"varint,4,opt,name=ViewNo,proto3" json:"ViewNo,omitempty"`
+	HasQuorum           bool                `protobuf:"varint,5,opt,name=HasQuorum,proto3" json:"HasQuorum,omitempty"`
+	Replicas            []*ReplicaTxnStatus `protobuf:"bytes,6,rep,name=Replicas,proto3" json:"Replicas,omitempty"`
+	DisagreeingReplicas []int32             `protobuf:"varint,7,rep,packed,name=DisagreeingReplicas,proto3" json:"DisagreeingReplicas,omitempty"`
+}
+
+func (x *ClusterTxnStatus) Reset() {
+	*x = ClusterTxnStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterTxnStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterTxnStatus) ProtoMessage() {}
+
+func (x *ClusterTxnStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterTxnStatus.ProtoReflect.Descriptor instead.
+func (*ClusterTxnStatus) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ClusterTxnStatus) GetCluster() int32 {
+	if x != nil {
+		return x.Cluster
+	}
+	return 0
+}
+
+func (x *ClusterTxnStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ClusterTxnStatus) GetSeqNo() int32 {
+	if x != nil {
+		return x.SeqNo
+	}
+	return 0
+}
+
+func (x *ClusterTxnStatus) GetViewNo() int32 {
+	if x != nil {
+		return x.ViewNo
+	}
+	return 0
+}
+
+func (x *ClusterTxnStatus) GetHasQuorum() bool {
+	if x != nil {
+		return x.HasQuorum
+	}
+	return false
+}
+
+func (x *ClusterTxnStatus) GetReplicas() []*ReplicaTxnStatus {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *ClusterTxnStatus) GetDisagreeingReplicas() []int32 {
+	if x != nil {
+		return x.DisagreeingReplicas
+	}
+	return nil
+}
+
+type TxnStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnID    string              `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	Replicas []*ReplicaTxnStatus `protobuf:"bytes,2,rep,name=Replicas,proto3" json:"Replicas,omitempty"`
+	Clusters []*ClusterTxnStatus `protobuf:"bytes,3,rep,name=Clusters,proto3" json:"Clusters,omitempty"`
+}
+
+func (x *TxnStatusResponse) Reset() {
+	*x = TxnStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnStatusResponse) ProtoMessage() {}
+
+func (x *TxnStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnStatusResponse.ProtoReflect.Descriptor instead.
+func (*TxnStatusResponse) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TxnStatusResponse) GetTxnID() string {
+	if x != nil {
+		return x.TxnID
+	}
+	return ""
+}
+
+func (x *TxnStatusResponse) GetReplicas() []*ReplicaTxnStatus {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *TxnStatusResponse) GetClusters() []*ClusterTxnStatus {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type BenchmarkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnNumber      int32    `protobuf:"varint,1,opt,name=TxnNumber,proto3" json:"TxnNumber,omitempty"`
+	ContactServers []string `protobuf:"bytes,2,rep,name=ContactServers,proto3" json:"ContactServers,omitempty"`
+}
+
+func (x *BenchmarkRequest) Reset() {
+	*x = BenchmarkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BenchmarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BenchmarkRequest) ProtoMessage() {}
+
+func (x *BenchmarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BenchmarkRequest.ProtoReflect.Descriptor instead.
+func (*BenchmarkRequest) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *BenchmarkRequest) GetTxnNumber() int32 {
+	if x != nil {
+		return x.TxnNumber
+	}
+	return 0
+}
+
+func (x *BenchmarkRequest) GetContactServers() []string {
+	if x != nil {
+		return x.ContactServers
+	}
+	return nil
+}
+
+type TwoPCDecisionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnID   string `protobuf:"bytes,1,opt,name=TxnID,proto3" json:"TxnID,omitempty"`
+	Outcome string `protobuf:"bytes,2,opt,name=Outcome,proto3" json:"Outcome,omitempty"`
+}
+
+func (x *TwoPCDecisionMessage) Reset() {
+	*x = TwoPCDecisionMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_common_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TwoPCDecisionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoPCDecisionMessage) ProtoMessage() {}
+
+func (x *TwoPCDecisionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoPCDecisionMessage.ProtoReflect.Descriptor instead.
+func (*TwoPCDecisionMessage) Descriptor() ([]byte, []int) {
+	return file_common_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TwoPCDecisionMessage) GetTxnID() string {
+	if x != nil {
+		return x.TxnID
+	}
+	return ""
+}
+
+func (x *TwoPCDecisionMessage) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+var File_common_proto protoreflect.FileDescriptor
+
+var file_common_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x44,
+	0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x22, 0xfd, 0x02, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x73,
+	0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x49, 0x73, 0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x50,
+	0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x44,
+	0x61, 0x74, 0x61, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x50, 0x65, 0x72, 0x53, 0x68, 0x61, 0x72, 0x64,
+	0x12, 0x4a, 0x0a, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x06,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x58, 0x0a, 0x0d, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x61, 0x0a, 0x0b, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x72,
+	0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x44, 0x72,
+	0x6f, 0x70, 0x52, 0x61, 0x74, 0x65, 0x22, 0xc0, 0x02, 0x0a, 0x06, 0x54, 0x78, 0x6e, 0x53, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x53, 0x65, 0x74, 0x4e, 0x6f, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54,
+	0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x4c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x4c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x42, 0x79, 0x7a,
+	0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x42, 0x79, 0x7a, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54,
+	0x78, 0x6e, 0x53, 0x65, 0x74, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0b, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xde, 0x03, 0x0a, 0x0a, 0x54, 0x78,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65,
+	0x71, 0x4e, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f,
+	0x12, 0x16, 0x0a, 0x06, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54,
+	0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x22, 0x0a, 0x04, 0x4c, 0x65, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x4c, 0x65, 0x67, 0x52, 0x04,
+	0x4c, 0x65, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44,
+	0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x3c, 0x0a, 0x06, 0x54, 0x78,
+	0x6e, 0x4c, 0x65, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd4, 0x01, 0x0a, 0x12, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x24, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x12,
+	0x20, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0xb1, 0x01, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x54, 0x78, 0x6e, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a,
+	0x04, 0x4c, 0x65, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x4c, 0x65, 0x67, 0x52, 0x04, 0x4c, 0x65, 0x67,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x44, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x44,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x73, 0x22, 0x42, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69, 0x65,
+	0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x64, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xf3, 0x01, 0x0a, 0x13,
+	0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x1e, 0x0a,
+	0x0a, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x68, 0x61, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x22, 0x93, 0x02, 0x0a, 0x0b, 0x50, 0x42, 0x46, 0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x41, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x26, 0x0a, 0x0e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x53, 0x68, 0x61, 0x72, 0x65, 0x22, 0x33, 0x0a, 0x03, 0x4d, 0x41, 0x43, 0x12, 0x1a,
+	0x0a, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x61,
+	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x4d, 0x61, 0x63, 0x22, 0x30, 0x0a, 0x0d,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a,
+	0x04, 0x4d, 0x61, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x41, 0x43, 0x52, 0x04, 0x4d, 0x61, 0x63, 0x73, 0x22, 0x5a,
+	0x0a, 0x0a, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x44, 0x0a, 0x10, 0x52, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x91, 0x01, 0x0a, 0x0f, 0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x53, 0x69, 0x67, 0x6e, 0x22, 0x4d, 0x0a, 0x17, 0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x59, 0x0a, 0x18, 0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3d, 0x0a, 0x0d, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x0d, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xb6,
+	0x01, 0x0a, 0x0b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26,
+	0x0a, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x12, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x7d, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xf3, 0x01, 0x0a, 0x13, 0x4d, 0x69, 0x73, 0x62, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x41, 0x63, 0x63, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x41, 0x63, 0x63, 0x75, 0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69,
+	0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2d, 0x0a, 0x05, 0x46, 0x69, 0x72, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12,
+	0x2f, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x22, 0x72, 0x0a, 0x13,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x52, 0x0b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x22, 0xa9, 0x02, 0x0a, 0x11, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69, 0x65, 0x77,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x64, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x14, 0x50, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x14, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x64, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x53,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46,
+	0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x70, 0x0a, 0x11,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x12, 0x24, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x52, 0x0b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0xde,
+	0x01, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x12, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50,
+	0x42, 0x46, 0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x12, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x3f,
+	0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x54, 0x78, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x54, 0x78, 0x6e, 0x73, 0x22,
+	0xc9, 0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x77, 0x56, 0x69, 0x65, 0x77, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x50, 0x42, 0x46, 0x54, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x56, 0x69,
+	0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0b, 0x50, 0x72, 0x65,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x0b, 0x50, 0x72, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x53, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x13,
+	0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x68, 0x72, 0x6f,
+	0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x54, 0x68,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x78, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x54, 0x78, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x41, 0x0a, 0x13, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x43, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x40, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x22, 0xc7, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55,
+	0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x71,
+	0x4e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x12,
+	0x1a, 0x0a, 0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x49,
+	0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x49, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x67,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x22, 0x68, 0x0a,
+	0x0a, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x22, 0x28, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x22, 0x39, 0x0a, 0x0f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x44, 0x42, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x54, 0x78, 0x6e, 0x73, 0x22, 0x2b, 0x0a, 0x11,
+	0x50, 0x72, 0x69, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x50, 0x0a, 0x08, 0x4c, 0x6f, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x48, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x57, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x57, 0x61, 0x69, 0x74, 0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x12, 0x50,
+	0x72, 0x69, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x29, 0x0a, 0x0f, 0x45, 0x76, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x10, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x45, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x45,
+	0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x42, 0x6c, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x64, 0x22, 0x28, 0x0a, 0x10, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x22, 0x86, 0x01,
+	0x0a, 0x10, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x69, 0x65, 0x77,
+	0x4e, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f,
+	0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xf8, 0x01, 0x0a, 0x10, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x53, 0x65, 0x71, 0x4e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x65,
+	0x71, 0x4e, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x56, 0x69, 0x65, 0x77, 0x4e, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x48,
+	0x61, 0x73, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x48, 0x61, 0x73, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12, 0x34, 0x0a, 0x08, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x78, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12,
+	0x30, 0x0a, 0x13, 0x44, 0x69, 0x73, 0x61, 0x67, 0x72, 0x65, 0x65, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x05, 0x52, 0x13, 0x44, 0x69,
+	0x73, 0x61, 0x67, 0x72, 0x65, 0x65, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x73, 0x22, 0x95, 0x01, 0x0a, 0x11, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x6e, 0x49, 0x44, 0x12, 0x34, 0x0a,
+	0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x08, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x22, 0x58, 0x0a, 0x10, 0x42, 0x65, 0x6e,
+	0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x54, 0x78, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x54, 0x78, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x73, 0x22, 0x46, 0x0a, 0x14, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54,
+	0x78, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x6e, 0x49,
+	0x44, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x32, 0xa1, 0x11, 0x0a, 0x06,
+	0x42, 0x79, 0x7a, 0x32, 0x50, 0x43, 0x12, 0x4d, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x08, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x54, 0x78, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x54, 0x78, 0x6e, 0x53, 0x65, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38,
+	0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x78, 0x6e, 0x12, 0x12, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x50,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46,
+	0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x43, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x1b, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42,
+	0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a, 0x07, 0x4e, 0x65, 0x77,
+	0x56, 0x69, 0x65, 0x77, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42,
+	0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0e,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x48, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61,
+	0x76, 0x69, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x69,
+	0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x12, 0x45, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12,
+	0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x4b, 0x65, 0x79, 0x1a, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x3e, 0x0a, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x58, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4b, 0x65,
+	0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x41, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4a, 0x0a, 0x13, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4b, 0x0a,
+	0x14, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50,
+	0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4e, 0x0a, 0x12, 0x54, 0x77,
+	0x6f, 0x50, 0x43, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x54, 0x77,
+	0x6f, 0x50, 0x43, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x38, 0x0a, 0x0a, 0x54, 0x77, 0x6f, 0x50, 0x43, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x12, 0x12,
+	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x45, 0x0a, 0x0e, 0x41, 0x62,
+	0x6f, 0x72, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x42, 0x46, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x42, 0x0a, 0x0b, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50,
+	0x72, 0x69, 0x6e, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x46, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x44, 0x42, 0x12, 0x16, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69,
+	0x6e, 0x74, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x44, 0x42, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x4c, 0x6f, 0x63,
+	0x6b, 0x73, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e,
+	0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x4c, 0x6f, 0x63, 0x6b,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x54, 0x78, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x09, 0x42,
+	0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x42, 0x65, 0x6e, 0x63, 0x68, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x50, 0x65, 0x72, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x03, 0x5a, 0x01, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1268,79 +4011,171 @@ func file_common_proto_rawDescGZIP() []byte {
 	return file_common_proto_rawDescData
 }
 
-var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_common_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_common_proto_goTypes = []any{
 	(*ClusterDistribution)(nil),      // 0: common.ClusterDistribution
 	(*UpdateServerStateRequest)(nil), // 1: common.UpdateServerStateRequest
-	(*TxnSet)(nil),                   // 2: common.TxnSet
-	(*TxnRequest)(nil),               // 3: common.TxnRequest
-	(*ProcessTxnResponse)(nil),       // 4: common.ProcessTxnResponse
-	(*SignedMessage)(nil),            // 5: common.SignedMessage
-	(*PBFTRequestResponse)(nil),      // 6: common.PBFTRequestResponse
-	(*PBFTMessage)(nil),              // 7: common.PBFTMessage
-	(*Certificate)(nil),              // 8: common.Certificate
-	(*PerformanceResponse)(nil),      // 9: common.PerformanceResponse
-	(*PrintBalanceRequest)(nil),      // 10: common.PrintBalanceRequest
-	(*PrintBalanceResponse)(nil),     // 11: common.PrintBalanceResponse
-	(*PrintDBRequest)(nil),           // 12: common.PrintDBRequest
-	(*PrintDBResponse)(nil),          // 13: common.PrintDBResponse
-	(*BenchmarkRequest)(nil),         // 14: common.BenchmarkRequest
-	nil,                              // 15: common.UpdateServerStateRequest.ClustersEntry
-	nil,                              // 16: common.PrintBalanceResponse.BalanceEntry
-	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),      // 18: google.protobuf.Duration
-	(*emptypb.Empty)(nil),            // 19: google.protobuf.Empty
+	(*FaultConfig)(nil),              // 2: common.FaultConfig
+	(*TxnSet)(nil),                   // 3: common.TxnSet
+	(*TxnRequest)(nil),               // 4: common.TxnRequest
+	(*TxnLeg)(nil),                   // 5: common.TxnLeg
+	(*ProcessTxnResponse)(nil),       // 6: common.ProcessTxnResponse
+	(*SignedReply)(nil),              // 7: common.SignedReply
+	(*TxnDigest)(nil),                // 8: common.TxnDigest
+	(*ThresholdVote)(nil),            // 9: common.ThresholdVote
+	(*BatchDigest)(nil),              // 10: common.BatchDigest
+	(*CheckpointState)(nil),          // 11: common.CheckpointState
+	(*UserBalance)(nil),              // 12: common.UserBalance
+	(*SignedMessage)(nil),            // 13: common.SignedMessage
+	(*PBFTRequestResponse)(nil),      // 14: common.PBFTRequestResponse
+	(*PBFTMessage)(nil),              // 15: common.PBFTMessage
+	(*MAC)(nil),                      // 16: common.MAC
+	(*Authenticator)(nil),            // 17: common.Authenticator
+	(*SessionKey)(nil),               // 18: common.SessionKey
+	(*RotateKeyRequest)(nil),         // 19: common.RotateKeyRequest
+	(*KeyAnnouncement)(nil),          // 20: common.KeyAnnouncement
+	(*KeyAnnouncementsRequest)(nil),  // 21: common.KeyAnnouncementsRequest
+	(*KeyAnnouncementsResponse)(nil), // 22: common.KeyAnnouncementsResponse
+	(*Certificate)(nil),              // 23: common.Certificate
+	(*SignedStatement)(nil),          // 24: common.SignedStatement
+	(*MisbehaviorEvidence)(nil),      // 25: common.MisbehaviorEvidence
+	(*PreparedCertificate)(nil),      // 26: common.PreparedCertificate
+	(*ViewChangeMessage)(nil),        // 27: common.ViewChangeMessage
+	(*CommitCertificate)(nil),        // 28: common.CommitCertificate
+	(*StateTransferMessage)(nil),     // 29: common.StateTransferMessage
+	(*NewViewMessage)(nil),           // 30: common.NewViewMessage
+	(*PerformanceResponse)(nil),      // 31: common.PerformanceResponse
+	(*PrintBalanceRequest)(nil),      // 32: common.PrintBalanceRequest
+	(*PrintBalanceResponse)(nil),     // 33: common.PrintBalanceResponse
+	(*ReadBalanceRequest)(nil),       // 34: common.ReadBalanceRequest
+	(*ReadBalanceResponse)(nil),      // 35: common.ReadBalanceResponse
+	(*SignedRead)(nil),               // 36: common.SignedRead
+	(*PrintDBRequest)(nil),           // 37: common.PrintDBRequest
+	(*PrintDBResponse)(nil),          // 38: common.PrintDBResponse
+	(*PrintLocksRequest)(nil),        // 39: common.PrintLocksRequest
+	(*LockInfo)(nil),                 // 40: common.LockInfo
+	(*PrintLocksResponse)(nil),       // 41: common.PrintLocksResponse
+	(*EvidenceRequest)(nil),          // 42: common.EvidenceRequest
+	(*EvidenceResponse)(nil),         // 43: common.EvidenceResponse
+	(*TxnStatusRequest)(nil),         // 44: common.TxnStatusRequest
+	(*ReplicaTxnStatus)(nil),         // 45: common.ReplicaTxnStatus
+	(*ClusterTxnStatus)(nil),         // 46: common.ClusterTxnStatus
+	(*TxnStatusResponse)(nil),        // 47: common.TxnStatusResponse
+	(*BenchmarkRequest)(nil),         // 48: common.BenchmarkRequest
+	(*TwoPCDecisionMessage)(nil),     // 49: common.TwoPCDecisionMessage
+	nil,                              // 50: common.UpdateServerStateRequest.ClustersEntry
+	nil,                              // 51: common.TxnSet.FaultsEntry
+	nil,                              // 52: common.PrintBalanceResponse.BalanceEntry
+	(*timestamppb.Timestamp)(nil),    // 53: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 54: google.protobuf.Duration
+	(*emptypb.Empty)(nil),            // 55: google.protobuf.Empty
 }
 var file_common_proto_depIdxs = []int32{
-	15, // 0: common.UpdateServerStateRequest.Clusters:type_name -> common.UpdateServerStateRequest.ClustersEntry
-	3,  // 1: common.TxnSet.Txns:type_name -> common.TxnRequest
-	17, // 2: common.TxnRequest.CreatedAt:type_name -> google.protobuf.Timestamp
-	3,  // 3: common.ProcessTxnResponse.Txn:type_name -> common.TxnRequest
-	17, // 4: common.PBFTMessage.CreatedAt:type_name -> google.protobuf.Timestamp
-	7,  // 5: common.Certificate.Messages:type_name -> common.PBFTMessage
-	18, // 6: common.PerformanceResponse.Latency:type_name -> google.protobuf.Duration
-	16, // 7: common.PrintBalanceResponse.Balance:type_name -> common.PrintBalanceResponse.BalanceEntry
-	3,  // 8: common.PrintDBResponse.Txns:type_name -> common.TxnRequest
-	0,  // 9: common.UpdateServerStateRequest.ClustersEntry.value:type_name -> common.ClusterDistribution
-	1,  // 10: common.Byz2PC.UpdateServerState:input_type -> common.UpdateServerStateRequest
-	4,  // 11: common.Byz2PC.Callback:input_type -> common.ProcessTxnResponse
-	2,  // 12: common.Byz2PC.ProcessTxnSet:input_type -> common.TxnSet
-	3,  // 13: common.Byz2PC.ProcessTxn:input_type -> common.TxnRequest
-	6,  // 14: common.Byz2PC.PrePrepare:input_type -> common.PBFTRequestResponse
-	6,  // 15: common.Byz2PC.Prepare:input_type -> common.PBFTRequestResponse
-	6,  // 16: common.Byz2PC.Commit:input_type -> common.PBFTRequestResponse
-	6,  // 17: common.Byz2PC.Sync:input_type -> common.PBFTRequestResponse
-	6,  // 18: common.Byz2PC.TwoPCPrepareRequest:input_type -> common.PBFTRequestResponse
-	6,  // 19: common.Byz2PC.TwoPCPrepareResponse:input_type -> common.PBFTRequestResponse
-	6,  // 20: common.Byz2PC.TwoPCCommitRequest:input_type -> common.PBFTRequestResponse
-	3,  // 21: common.Byz2PC.TwoPCCommit:input_type -> common.TxnRequest
-	3,  // 22: common.Byz2PC.TwoPCAbort:input_type -> common.TxnRequest
-	19, // 23: common.Byz2PC.Performance:input_type -> google.protobuf.Empty
-	10, // 24: common.Byz2PC.PrintBalance:input_type -> common.PrintBalanceRequest
-	12, // 25: common.Byz2PC.PrintDB:input_type -> common.PrintDBRequest
-	14, // 26: common.Byz2PC.Benchmark:input_type -> common.BenchmarkRequest
-	19, // 27: common.Byz2PC.UpdateServerState:output_type -> google.protobuf.Empty
-	19, // 28: common.Byz2PC.Callback:output_type -> google.protobuf.Empty
-	19, // 29: common.Byz2PC.ProcessTxnSet:output_type -> google.protobuf.Empty
-	19, // 30: common.Byz2PC.ProcessTxn:output_type -> google.protobuf.Empty
-	6,  // 31: common.Byz2PC.PrePrepare:output_type -> common.PBFTRequestResponse
-	6,  // 32: common.Byz2PC.Prepare:output_type -> common.PBFTRequestResponse
-	19, // 33: common.Byz2PC.Commit:output_type -> google.protobuf.Empty
-	6,  // 34: common.Byz2PC.Sync:output_type -> common.PBFTRequestResponse
-	19, // 35: common.Byz2PC.TwoPCPrepareRequest:output_type -> google.protobuf.Empty
-	19, // 36: common.Byz2PC.TwoPCPrepareResponse:output_type -> google.protobuf.Empty
-	6,  // 37: common.Byz2PC.TwoPCCommitRequest:output_type -> common.PBFTRequestResponse
-	19, // 38: common.Byz2PC.TwoPCCommit:output_type -> google.protobuf.Empty
-	19, // 39: common.Byz2PC.TwoPCAbort:output_type -> google.protobuf.Empty
-	9,  // 40: common.Byz2PC.Performance:output_type -> common.PerformanceResponse
-	11, // 41: common.Byz2PC.PrintBalance:output_type -> common.PrintBalanceResponse
-	13, // 42: common.Byz2PC.PrintDB:output_type -> common.PrintDBResponse
-	9,  // 43: common.Byz2PC.Benchmark:output_type -> common.PerformanceResponse
-	27, // [27:44] is the sub-list for method output_type
-	10, // [10:27] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	50, // 0: common.UpdateServerStateRequest.Clusters:type_name -> common.UpdateServerStateRequest.ClustersEntry
+	2,  // 1: common.UpdateServerStateRequest.Faults:type_name -> common.FaultConfig
+	4,  // 2: common.TxnSet.Txns:type_name -> common.TxnRequest
+	51, // 3: common.TxnSet.Faults:type_name -> common.TxnSet.FaultsEntry
+	53, // 4: common.TxnRequest.CreatedAt:type_name -> google.protobuf.Timestamp
+	4,  // 5: common.TxnRequest.Batch:type_name -> common.TxnRequest
+	5,  // 6: common.TxnRequest.Legs:type_name -> common.TxnLeg
+	4,  // 7: common.ProcessTxnResponse.Txn:type_name -> common.TxnRequest
+	5,  // 8: common.TxnDigest.Legs:type_name -> common.TxnLeg
+	12, // 9: common.CheckpointState.Balances:type_name -> common.UserBalance
+	53, // 10: common.PBFTMessage.CreatedAt:type_name -> google.protobuf.Timestamp
+	16, // 11: common.Authenticator.Macs:type_name -> common.MAC
+	20, // 12: common.KeyAnnouncementsResponse.Announcements:type_name -> common.KeyAnnouncement
+	15, // 13: common.Certificate.Messages:type_name -> common.PBFTMessage
+	24, // 14: common.MisbehaviorEvidence.First:type_name -> common.SignedStatement
+	24, // 15: common.MisbehaviorEvidence.Second:type_name -> common.SignedStatement
+	4,  // 16: common.PreparedCertificate.Txn:type_name -> common.TxnRequest
+	23, // 17: common.PreparedCertificate.Certificate:type_name -> common.Certificate
+	26, // 18: common.ViewChangeMessage.PreparedCertificates:type_name -> common.PreparedCertificate
+	15, // 19: common.ViewChangeMessage.CheckpointMessages:type_name -> common.PBFTMessage
+	4,  // 20: common.CommitCertificate.Txn:type_name -> common.TxnRequest
+	23, // 21: common.CommitCertificate.Certificate:type_name -> common.Certificate
+	15, // 22: common.StateTransferMessage.CheckpointMessages:type_name -> common.PBFTMessage
+	28, // 23: common.StateTransferMessage.CommittedTxns:type_name -> common.CommitCertificate
+	15, // 24: common.NewViewMessage.ViewChanges:type_name -> common.PBFTMessage
+	4,  // 25: common.NewViewMessage.PrePrepares:type_name -> common.TxnRequest
+	54, // 26: common.PerformanceResponse.Latency:type_name -> google.protobuf.Duration
+	52, // 27: common.PrintBalanceResponse.Balance:type_name -> common.PrintBalanceResponse.BalanceEntry
+	4,  // 28: common.PrintDBResponse.Txns:type_name -> common.TxnRequest
+	40, // 29: common.PrintLocksResponse.Locks:type_name -> common.LockInfo
+	25, // 30: common.EvidenceResponse.Evidence:type_name -> common.MisbehaviorEvidence
+	45, // 31: common.ClusterTxnStatus.Replicas:type_name -> common.ReplicaTxnStatus
+	45, // 32: common.TxnStatusResponse.Replicas:type_name -> common.ReplicaTxnStatus
+	46, // 33: common.TxnStatusResponse.Clusters:type_name -> common.ClusterTxnStatus
+	0,  // 34: common.UpdateServerStateRequest.ClustersEntry.value:type_name -> common.ClusterDistribution
+	2,  // 35: common.TxnSet.FaultsEntry.value:type_name -> common.FaultConfig
+	1,  // 36: common.Byz2PC.UpdateServerState:input_type -> common.UpdateServerStateRequest
+	6,  // 37: common.Byz2PC.Callback:input_type -> common.ProcessTxnResponse
+	3,  // 38: common.Byz2PC.ProcessTxnSet:input_type -> common.TxnSet
+	4,  // 39: common.Byz2PC.ProcessTxn:input_type -> common.TxnRequest
+	14, // 40: common.Byz2PC.PrePrepare:input_type -> common.PBFTRequestResponse
+	14, // 41: common.Byz2PC.Prepare:input_type -> common.PBFTRequestResponse
+	14, // 42: common.Byz2PC.Commit:input_type -> common.PBFTRequestResponse
+	14, // 43: common.Byz2PC.Sync:input_type -> common.PBFTRequestResponse
+	14, // 44: common.Byz2PC.ViewChange:input_type -> common.PBFTRequestResponse
+	14, // 45: common.Byz2PC.NewView:input_type -> common.PBFTRequestResponse
+	14, // 46: common.Byz2PC.Checkpoint:input_type -> common.PBFTRequestResponse
+	24, // 47: common.Byz2PC.ShareStatement:input_type -> common.SignedStatement
+	25, // 48: common.Byz2PC.ReportMisbehavior:input_type -> common.MisbehaviorEvidence
+	18, // 49: common.Byz2PC.ExchangeSessionKey:input_type -> common.SessionKey
+	20, // 50: common.Byz2PC.AnnounceKey:input_type -> common.KeyAnnouncement
+	21, // 51: common.Byz2PC.GetKeyAnnouncements:input_type -> common.KeyAnnouncementsRequest
+	14, // 52: common.Byz2PC.TwoPCPrepareRequest:input_type -> common.PBFTRequestResponse
+	14, // 53: common.Byz2PC.TwoPCPrepareResponse:input_type -> common.PBFTRequestResponse
+	14, // 54: common.Byz2PC.TwoPCCommitRequest:input_type -> common.PBFTRequestResponse
+	14, // 55: common.Byz2PC.TwoPCDecision:input_type -> common.PBFTRequestResponse
+	4,  // 56: common.Byz2PC.TwoPCCommit:input_type -> common.TxnRequest
+	4,  // 57: common.Byz2PC.TwoPCAbort:input_type -> common.TxnRequest
+	14, // 58: common.Byz2PC.AbortVoteShare:input_type -> common.PBFTRequestResponse
+	55, // 59: common.Byz2PC.Performance:input_type -> google.protobuf.Empty
+	32, // 60: common.Byz2PC.PrintBalance:input_type -> common.PrintBalanceRequest
+	34, // 61: common.Byz2PC.ReadBalance:input_type -> common.ReadBalanceRequest
+	37, // 62: common.Byz2PC.PrintDB:input_type -> common.PrintDBRequest
+	39, // 63: common.Byz2PC.PrintLocks:input_type -> common.PrintLocksRequest
+	44, // 64: common.Byz2PC.GetTxnStatus:input_type -> common.TxnStatusRequest
+	42, // 65: common.Byz2PC.GetMisbehaviorEvidence:input_type -> common.EvidenceRequest
+	19, // 66: common.Byz2PC.RotateKey:input_type -> common.RotateKeyRequest
+	48, // 67: common.Byz2PC.Benchmark:input_type -> common.BenchmarkRequest
+	55, // 68: common.Byz2PC.UpdateServerState:output_type -> google.protobuf.Empty
+	55, // 69: common.Byz2PC.Callback:output_type -> google.protobuf.Empty
+	55, // 70: common.Byz2PC.ProcessTxnSet:output_type -> google.protobuf.Empty
+	55, // 71: common.Byz2PC.ProcessTxn:output_type -> google.protobuf.Empty
+	14, // 72: common.Byz2PC.PrePrepare:output_type -> common.PBFTRequestResponse
+	14, // 73: common.Byz2PC.Prepare:output_type -> common.PBFTRequestResponse
+	55, // 74: common.Byz2PC.Commit:output_type -> google.protobuf.Empty
+	14, // 75: common.Byz2PC.Sync:output_type -> common.PBFTRequestResponse
+	55, // 76: common.Byz2PC.ViewChange:output_type -> google.protobuf.Empty
+	55, // 77: common.Byz2PC.NewView:output_type -> google.protobuf.Empty
+	55, // 78: common.Byz2PC.Checkpoint:output_type -> google.protobuf.Empty
+	55, // 79: common.Byz2PC.ShareStatement:output_type -> google.protobuf.Empty
+	55, // 80: common.Byz2PC.ReportMisbehavior:output_type -> google.protobuf.Empty
+	18, // 81: common.Byz2PC.ExchangeSessionKey:output_type -> common.SessionKey
+	55, // 82: common.Byz2PC.AnnounceKey:output_type -> google.protobuf.Empty
+	22, // 83: common.Byz2PC.GetKeyAnnouncements:output_type -> common.KeyAnnouncementsResponse
+	55, // 84: common.Byz2PC.TwoPCPrepareRequest:output_type -> google.protobuf.Empty
+	55, // 85: common.Byz2PC.TwoPCPrepareResponse:output_type -> google.protobuf.Empty
+	14, // 86: common.Byz2PC.TwoPCCommitRequest:output_type -> common.PBFTRequestResponse
+	14, // 87: common.Byz2PC.TwoPCDecision:output_type -> common.PBFTRequestResponse
+	55, // 88: common.Byz2PC.TwoPCCommit:output_type -> google.protobuf.Empty
+	55, // 89: common.Byz2PC.TwoPCAbort:output_type -> google.protobuf.Empty
+	55, // 90: common.Byz2PC.AbortVoteShare:output_type -> google.protobuf.Empty
+	31, // 91: common.Byz2PC.Performance:output_type -> common.PerformanceResponse
+	33, // 92: common.Byz2PC.PrintBalance:output_type -> common.PrintBalanceResponse
+	35, // 93: common.Byz2PC.ReadBalance:output_type -> common.ReadBalanceResponse
+	38, // 94: common.Byz2PC.PrintDB:output_type -> common.PrintDBResponse
+	41, // 95: common.Byz2PC.PrintLocks:output_type -> common.PrintLocksResponse
+	47, // 96: common.Byz2PC.GetTxnStatus:output_type -> common.TxnStatusResponse
+	43, // 97: common.Byz2PC.GetMisbehaviorEvidence:output_type -> common.EvidenceResponse
+	20, // 98: common.Byz2PC.RotateKey:output_type -> common.KeyAnnouncement
+	31, // 99: common.Byz2PC.Benchmark:output_type -> common.PerformanceResponse
+	68, // [68:100] is the sub-list for method output_type
+	36, // [36:68] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_common_proto_init() }
@@ -1361,8 +4196,152 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateServerStateRequest); i {
+		file_common_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateServerStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*FaultConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnLeg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ProcessTxnResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*SignedReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ThresholdVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*CheckpointState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*UserBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*SignedMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1373,8 +4352,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*TxnSet); i {
+		file_common_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*PBFTRequestResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1385,8 +4364,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*TxnRequest); i {
+		file_common_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*PBFTMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1397,8 +4376,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*ProcessTxnResponse); i {
+		file_common_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*MAC); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1409,8 +4388,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*SignedMessage); i {
+		file_common_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*Authenticator); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1421,8 +4400,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*PBFTRequestResponse); i {
+		file_common_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SessionKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1433,8 +4412,8 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*PBFTMessage); i {
+		file_common_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*RotateKeyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1445,7 +4424,43 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[8].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*KeyAnnouncement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*KeyAnnouncementsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*KeyAnnouncementsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[23].Exporter = func(v any, i int) any {
 			switch v := v.(*Certificate); i {
 			case 0:
 				return &v.state
@@ -1457,7 +4472,91 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[9].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*SignedStatement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*MisbehaviorEvidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*PreparedCertificate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*ViewChangeMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*CommitCertificate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*StateTransferMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*NewViewMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[31].Exporter = func(v any, i int) any {
 			switch v := v.(*PerformanceResponse); i {
 			case 0:
 				return &v.state
@@ -1469,7 +4568,7 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[10].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[32].Exporter = func(v any, i int) any {
 			switch v := v.(*PrintBalanceRequest); i {
 			case 0:
 				return &v.state
@@ -1481,7 +4580,7 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[11].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[33].Exporter = func(v any, i int) any {
 			switch v := v.(*PrintBalanceResponse); i {
 			case 0:
 				return &v.state
@@ -1493,7 +4592,43 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[12].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*ReadBalanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*ReadBalanceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*SignedRead); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[37].Exporter = func(v any, i int) any {
 			switch v := v.(*PrintDBRequest); i {
 			case 0:
 				return &v.state
@@ -1505,7 +4640,7 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[13].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[38].Exporter = func(v any, i int) any {
 			switch v := v.(*PrintDBResponse); i {
 			case 0:
 				return &v.state
@@ -1517,7 +4652,115 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_msgTypes[14].Exporter = func(v any, i int) any {
+		file_common_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*PrintLocksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*LockInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*PrintLocksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*EvidenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*EvidenceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*ReplicaTxnStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*ClusterTxnStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*TxnStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_common_proto_msgTypes[48].Exporter = func(v any, i int) any {
 			switch v := v.(*BenchmarkRequest); i {
 			case 0:
 				return &v.state
@@ -1529,6 +4772,18 @@ func file_common_proto_init() {
 				return nil
 			}
 		}
+		file_common_proto_msgTypes[49].Exporter = func(v any, i int) any {
+			switch v := v.(*TwoPCDecisionMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1536,7 +4791,7 @@ func file_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_common_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
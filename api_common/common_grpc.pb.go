@@ -20,23 +20,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Byz2PC_UpdateServerState_FullMethodName    = "/common.Byz2PC/UpdateServerState"
-	Byz2PC_Callback_FullMethodName             = "/common.Byz2PC/Callback"
-	Byz2PC_ProcessTxnSet_FullMethodName        = "/common.Byz2PC/ProcessTxnSet"
-	Byz2PC_ProcessTxn_FullMethodName           = "/common.Byz2PC/ProcessTxn"
-	Byz2PC_PrePrepare_FullMethodName           = "/common.Byz2PC/PrePrepare"
-	Byz2PC_Prepare_FullMethodName              = "/common.Byz2PC/Prepare"
-	Byz2PC_Commit_FullMethodName               = "/common.Byz2PC/Commit"
-	Byz2PC_Sync_FullMethodName                 = "/common.Byz2PC/Sync"
-	Byz2PC_TwoPCPrepareRequest_FullMethodName  = "/common.Byz2PC/TwoPCPrepareRequest"
-	Byz2PC_TwoPCPrepareResponse_FullMethodName = "/common.Byz2PC/TwoPCPrepareResponse"
-	Byz2PC_TwoPCCommitRequest_FullMethodName   = "/common.Byz2PC/TwoPCCommitRequest"
-	Byz2PC_TwoPCCommit_FullMethodName          = "/common.Byz2PC/TwoPCCommit"
-	Byz2PC_TwoPCAbort_FullMethodName           = "/common.Byz2PC/TwoPCAbort"
-	Byz2PC_Performance_FullMethodName          = "/common.Byz2PC/Performance"
-	Byz2PC_PrintBalance_FullMethodName         = "/common.Byz2PC/PrintBalance"
-	Byz2PC_PrintDB_FullMethodName              = "/common.Byz2PC/PrintDB"
-	Byz2PC_Benchmark_FullMethodName            = "/common.Byz2PC/Benchmark"
+	Byz2PC_UpdateServerState_FullMethodName      = "/common.Byz2PC/UpdateServerState"
+	Byz2PC_Callback_FullMethodName               = "/common.Byz2PC/Callback"
+	Byz2PC_ProcessTxnSet_FullMethodName          = "/common.Byz2PC/ProcessTxnSet"
+	Byz2PC_ProcessTxn_FullMethodName             = "/common.Byz2PC/ProcessTxn"
+	Byz2PC_PrePrepare_FullMethodName             = "/common.Byz2PC/PrePrepare"
+	Byz2PC_Prepare_FullMethodName                = "/common.Byz2PC/Prepare"
+	Byz2PC_Commit_FullMethodName                 = "/common.Byz2PC/Commit"
+	Byz2PC_Sync_FullMethodName                   = "/common.Byz2PC/Sync"
+	Byz2PC_ViewChange_FullMethodName             = "/common.Byz2PC/ViewChange"
+	Byz2PC_NewView_FullMethodName                = "/common.Byz2PC/NewView"
+	Byz2PC_Checkpoint_FullMethodName             = "/common.Byz2PC/Checkpoint"
+	Byz2PC_ShareStatement_FullMethodName         = "/common.Byz2PC/ShareStatement"
+	Byz2PC_ReportMisbehavior_FullMethodName      = "/common.Byz2PC/ReportMisbehavior"
+	Byz2PC_ExchangeSessionKey_FullMethodName     = "/common.Byz2PC/ExchangeSessionKey"
+	Byz2PC_AnnounceKey_FullMethodName            = "/common.Byz2PC/AnnounceKey"
+	Byz2PC_GetKeyAnnouncements_FullMethodName    = "/common.Byz2PC/GetKeyAnnouncements"
+	Byz2PC_TwoPCPrepareRequest_FullMethodName    = "/common.Byz2PC/TwoPCPrepareRequest"
+	Byz2PC_TwoPCPrepareResponse_FullMethodName   = "/common.Byz2PC/TwoPCPrepareResponse"
+	Byz2PC_TwoPCCommitRequest_FullMethodName     = "/common.Byz2PC/TwoPCCommitRequest"
+	Byz2PC_TwoPCDecision_FullMethodName          = "/common.Byz2PC/TwoPCDecision"
+	Byz2PC_TwoPCCommit_FullMethodName            = "/common.Byz2PC/TwoPCCommit"
+	Byz2PC_TwoPCAbort_FullMethodName             = "/common.Byz2PC/TwoPCAbort"
+	Byz2PC_AbortVoteShare_FullMethodName         = "/common.Byz2PC/AbortVoteShare"
+	Byz2PC_Performance_FullMethodName            = "/common.Byz2PC/Performance"
+	Byz2PC_PrintBalance_FullMethodName           = "/common.Byz2PC/PrintBalance"
+	Byz2PC_ReadBalance_FullMethodName            = "/common.Byz2PC/ReadBalance"
+	Byz2PC_PrintDB_FullMethodName                = "/common.Byz2PC/PrintDB"
+	Byz2PC_PrintLocks_FullMethodName             = "/common.Byz2PC/PrintLocks"
+	Byz2PC_GetTxnStatus_FullMethodName           = "/common.Byz2PC/GetTxnStatus"
+	Byz2PC_GetMisbehaviorEvidence_FullMethodName = "/common.Byz2PC/GetMisbehaviorEvidence"
+	Byz2PC_RotateKey_FullMethodName              = "/common.Byz2PC/RotateKey"
+	Byz2PC_Benchmark_FullMethodName              = "/common.Byz2PC/Benchmark"
 )
 
 // Byz2PCClient is the client API for Byz2PC service.
@@ -51,14 +66,29 @@ type Byz2PCClient interface {
 	Prepare(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*PBFTRequestResponse, error)
 	Commit(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Sync(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*PBFTRequestResponse, error)
+	ViewChange(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	NewView(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Checkpoint(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ShareStatement(ctx context.Context, in *SignedStatement, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReportMisbehavior(ctx context.Context, in *MisbehaviorEvidence, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExchangeSessionKey(ctx context.Context, in *SessionKey, opts ...grpc.CallOption) (*SessionKey, error)
+	AnnounceKey(ctx context.Context, in *KeyAnnouncement, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetKeyAnnouncements(ctx context.Context, in *KeyAnnouncementsRequest, opts ...grpc.CallOption) (*KeyAnnouncementsResponse, error)
 	TwoPCPrepareRequest(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	TwoPCPrepareResponse(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	TwoPCCommitRequest(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*PBFTRequestResponse, error)
+	TwoPCDecision(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*PBFTRequestResponse, error)
 	TwoPCCommit(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	TwoPCAbort(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AbortVoteShare(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Performance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PerformanceResponse, error)
 	PrintBalance(ctx context.Context, in *PrintBalanceRequest, opts ...grpc.CallOption) (*PrintBalanceResponse, error)
+	ReadBalance(ctx context.Context, in *ReadBalanceRequest, opts ...grpc.CallOption) (*ReadBalanceResponse, error)
 	PrintDB(ctx context.Context, in *PrintDBRequest, opts ...grpc.CallOption) (*PrintDBResponse, error)
+	PrintLocks(ctx context.Context, in *PrintLocksRequest, opts ...grpc.CallOption) (*PrintLocksResponse, error)
+	GetTxnStatus(ctx context.Context, in *TxnStatusRequest, opts ...grpc.CallOption) (*TxnStatusResponse, error)
+	GetMisbehaviorEvidence(ctx context.Context, in *EvidenceRequest, opts ...grpc.CallOption) (*EvidenceResponse, error)
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*KeyAnnouncement, error)
 	Benchmark(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*PerformanceResponse, error)
 }
 
@@ -150,6 +180,86 @@ func (c *byz2PCClient) Sync(ctx context.Context, in *PBFTRequestResponse, opts .
 	return out, nil
 }
 
+func (c *byz2PCClient) ViewChange(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_ViewChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) NewView(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_NewView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) Checkpoint(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_Checkpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) ShareStatement(ctx context.Context, in *SignedStatement, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_ShareStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) ReportMisbehavior(ctx context.Context, in *MisbehaviorEvidence, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_ReportMisbehavior_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) ExchangeSessionKey(ctx context.Context, in *SessionKey, opts ...grpc.CallOption) (*SessionKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionKey)
+	err := c.cc.Invoke(ctx, Byz2PC_ExchangeSessionKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) AnnounceKey(ctx context.Context, in *KeyAnnouncement, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_AnnounceKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) GetKeyAnnouncements(ctx context.Context, in *KeyAnnouncementsRequest, opts ...grpc.CallOption) (*KeyAnnouncementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyAnnouncementsResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_GetKeyAnnouncements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *byz2PCClient) TwoPCPrepareRequest(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -180,6 +290,16 @@ func (c *byz2PCClient) TwoPCCommitRequest(ctx context.Context, in *PBFTRequestRe
 	return out, nil
 }
 
+func (c *byz2PCClient) TwoPCDecision(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*PBFTRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PBFTRequestResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_TwoPCDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *byz2PCClient) TwoPCCommit(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -200,6 +320,16 @@ func (c *byz2PCClient) TwoPCAbort(ctx context.Context, in *TxnRequest, opts ...g
 	return out, nil
 }
 
+func (c *byz2PCClient) AbortVoteShare(ctx context.Context, in *PBFTRequestResponse, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Byz2PC_AbortVoteShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *byz2PCClient) Performance(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PerformanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PerformanceResponse)
@@ -220,6 +350,16 @@ func (c *byz2PCClient) PrintBalance(ctx context.Context, in *PrintBalanceRequest
 	return out, nil
 }
 
+func (c *byz2PCClient) ReadBalance(ctx context.Context, in *ReadBalanceRequest, opts ...grpc.CallOption) (*ReadBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadBalanceResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_ReadBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *byz2PCClient) PrintDB(ctx context.Context, in *PrintDBRequest, opts ...grpc.CallOption) (*PrintDBResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PrintDBResponse)
@@ -230,6 +370,46 @@ func (c *byz2PCClient) PrintDB(ctx context.Context, in *PrintDBRequest, opts ...
 	return out, nil
 }
 
+func (c *byz2PCClient) PrintLocks(ctx context.Context, in *PrintLocksRequest, opts ...grpc.CallOption) (*PrintLocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PrintLocksResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_PrintLocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) GetTxnStatus(ctx context.Context, in *TxnStatusRequest, opts ...grpc.CallOption) (*TxnStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxnStatusResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_GetTxnStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) GetMisbehaviorEvidence(ctx context.Context, in *EvidenceRequest, opts ...grpc.CallOption) (*EvidenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvidenceResponse)
+	err := c.cc.Invoke(ctx, Byz2PC_GetMisbehaviorEvidence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *byz2PCClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*KeyAnnouncement, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyAnnouncement)
+	err := c.cc.Invoke(ctx, Byz2PC_RotateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *byz2PCClient) Benchmark(ctx context.Context, in *BenchmarkRequest, opts ...grpc.CallOption) (*PerformanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PerformanceResponse)
@@ -252,14 +432,29 @@ type Byz2PCServer interface {
 	Prepare(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error)
 	Commit(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
 	Sync(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error)
+	ViewChange(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
+	NewView(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
+	Checkpoint(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
+	ShareStatement(context.Context, *SignedStatement) (*emptypb.Empty, error)
+	ReportMisbehavior(context.Context, *MisbehaviorEvidence) (*emptypb.Empty, error)
+	ExchangeSessionKey(context.Context, *SessionKey) (*SessionKey, error)
+	AnnounceKey(context.Context, *KeyAnnouncement) (*emptypb.Empty, error)
+	GetKeyAnnouncements(context.Context, *KeyAnnouncementsRequest) (*KeyAnnouncementsResponse, error)
 	TwoPCPrepareRequest(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
 	TwoPCPrepareResponse(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
 	TwoPCCommitRequest(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error)
+	TwoPCDecision(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error)
 	TwoPCCommit(context.Context, *TxnRequest) (*emptypb.Empty, error)
 	TwoPCAbort(context.Context, *TxnRequest) (*emptypb.Empty, error)
+	AbortVoteShare(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error)
 	Performance(context.Context, *emptypb.Empty) (*PerformanceResponse, error)
 	PrintBalance(context.Context, *PrintBalanceRequest) (*PrintBalanceResponse, error)
+	ReadBalance(context.Context, *ReadBalanceRequest) (*ReadBalanceResponse, error)
 	PrintDB(context.Context, *PrintDBRequest) (*PrintDBResponse, error)
+	PrintLocks(context.Context, *PrintLocksRequest) (*PrintLocksResponse, error)
+	GetTxnStatus(context.Context, *TxnStatusRequest) (*TxnStatusResponse, error)
+	GetMisbehaviorEvidence(context.Context, *EvidenceRequest) (*EvidenceResponse, error)
+	RotateKey(context.Context, *RotateKeyRequest) (*KeyAnnouncement, error)
 	Benchmark(context.Context, *BenchmarkRequest) (*PerformanceResponse, error)
 	mustEmbedUnimplementedByz2PCServer()
 }
@@ -295,6 +490,30 @@ func (UnimplementedByz2PCServer) Commit(context.Context, *PBFTRequestResponse) (
 func (UnimplementedByz2PCServer) Sync(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedByz2PCServer) ViewChange(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewChange not implemented")
+}
+func (UnimplementedByz2PCServer) NewView(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewView not implemented")
+}
+func (UnimplementedByz2PCServer) Checkpoint(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
+func (UnimplementedByz2PCServer) ShareStatement(context.Context, *SignedStatement) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareStatement not implemented")
+}
+func (UnimplementedByz2PCServer) ReportMisbehavior(context.Context, *MisbehaviorEvidence) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportMisbehavior not implemented")
+}
+func (UnimplementedByz2PCServer) ExchangeSessionKey(context.Context, *SessionKey) (*SessionKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeSessionKey not implemented")
+}
+func (UnimplementedByz2PCServer) AnnounceKey(context.Context, *KeyAnnouncement) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceKey not implemented")
+}
+func (UnimplementedByz2PCServer) GetKeyAnnouncements(context.Context, *KeyAnnouncementsRequest) (*KeyAnnouncementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyAnnouncements not implemented")
+}
 func (UnimplementedByz2PCServer) TwoPCPrepareRequest(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TwoPCPrepareRequest not implemented")
 }
@@ -304,21 +523,42 @@ func (UnimplementedByz2PCServer) TwoPCPrepareResponse(context.Context, *PBFTRequ
 func (UnimplementedByz2PCServer) TwoPCCommitRequest(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TwoPCCommitRequest not implemented")
 }
+func (UnimplementedByz2PCServer) TwoPCDecision(context.Context, *PBFTRequestResponse) (*PBFTRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TwoPCDecision not implemented")
+}
 func (UnimplementedByz2PCServer) TwoPCCommit(context.Context, *TxnRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TwoPCCommit not implemented")
 }
 func (UnimplementedByz2PCServer) TwoPCAbort(context.Context, *TxnRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TwoPCAbort not implemented")
 }
+func (UnimplementedByz2PCServer) AbortVoteShare(context.Context, *PBFTRequestResponse) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortVoteShare not implemented")
+}
 func (UnimplementedByz2PCServer) Performance(context.Context, *emptypb.Empty) (*PerformanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Performance not implemented")
 }
 func (UnimplementedByz2PCServer) PrintBalance(context.Context, *PrintBalanceRequest) (*PrintBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrintBalance not implemented")
 }
+func (UnimplementedByz2PCServer) ReadBalance(context.Context, *ReadBalanceRequest) (*ReadBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadBalance not implemented")
+}
 func (UnimplementedByz2PCServer) PrintDB(context.Context, *PrintDBRequest) (*PrintDBResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrintDB not implemented")
 }
+func (UnimplementedByz2PCServer) PrintLocks(context.Context, *PrintLocksRequest) (*PrintLocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrintLocks not implemented")
+}
+func (UnimplementedByz2PCServer) GetTxnStatus(context.Context, *TxnStatusRequest) (*TxnStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxnStatus not implemented")
+}
+func (UnimplementedByz2PCServer) GetMisbehaviorEvidence(context.Context, *EvidenceRequest) (*EvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMisbehaviorEvidence not implemented")
+}
+func (UnimplementedByz2PCServer) RotateKey(context.Context, *RotateKeyRequest) (*KeyAnnouncement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
 func (UnimplementedByz2PCServer) Benchmark(context.Context, *BenchmarkRequest) (*PerformanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Benchmark not implemented")
 }
@@ -487,6 +727,150 @@ func _Byz2PC_Sync_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Byz2PC_ViewChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PBFTRequestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).ViewChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_ViewChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).ViewChange(ctx, req.(*PBFTRequestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_NewView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PBFTRequestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).NewView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_NewView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).NewView(ctx, req.(*PBFTRequestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PBFTRequestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_Checkpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).Checkpoint(ctx, req.(*PBFTRequestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_ShareStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedStatement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).ShareStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_ShareStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).ShareStatement(ctx, req.(*SignedStatement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_ReportMisbehavior_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MisbehaviorEvidence)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).ReportMisbehavior(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_ReportMisbehavior_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).ReportMisbehavior(ctx, req.(*MisbehaviorEvidence))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_ExchangeSessionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).ExchangeSessionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_ExchangeSessionKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).ExchangeSessionKey(ctx, req.(*SessionKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_AnnounceKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyAnnouncement)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).AnnounceKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_AnnounceKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).AnnounceKey(ctx, req.(*KeyAnnouncement))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_GetKeyAnnouncements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyAnnouncementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).GetKeyAnnouncements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_GetKeyAnnouncements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).GetKeyAnnouncements(ctx, req.(*KeyAnnouncementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Byz2PC_TwoPCPrepareRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PBFTRequestResponse)
 	if err := dec(in); err != nil {
@@ -541,6 +925,24 @@ func _Byz2PC_TwoPCCommitRequest_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Byz2PC_TwoPCDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PBFTRequestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).TwoPCDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_TwoPCDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).TwoPCDecision(ctx, req.(*PBFTRequestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Byz2PC_TwoPCCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TxnRequest)
 	if err := dec(in); err != nil {
@@ -577,6 +979,24 @@ func _Byz2PC_TwoPCAbort_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Byz2PC_AbortVoteShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PBFTRequestResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).AbortVoteShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_AbortVoteShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).AbortVoteShare(ctx, req.(*PBFTRequestResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Byz2PC_Performance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -613,6 +1033,24 @@ func _Byz2PC_PrintBalance_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Byz2PC_ReadBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).ReadBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_ReadBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).ReadBalance(ctx, req.(*ReadBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Byz2PC_PrintDB_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PrintDBRequest)
 	if err := dec(in); err != nil {
@@ -631,6 +1069,78 @@ func _Byz2PC_PrintDB_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Byz2PC_PrintLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrintLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).PrintLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_PrintLocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).PrintLocks(ctx, req.(*PrintLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_GetTxnStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).GetTxnStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_GetTxnStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).GetTxnStatus(ctx, req.(*TxnStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_GetMisbehaviorEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).GetMisbehaviorEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_GetMisbehaviorEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).GetMisbehaviorEvidence(ctx, req.(*EvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Byz2PC_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Byz2PCServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Byz2PC_RotateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Byz2PCServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Byz2PC_Benchmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BenchmarkRequest)
 	if err := dec(in); err != nil {
@@ -688,6 +1198,38 @@ var Byz2PC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sync",
 			Handler:    _Byz2PC_Sync_Handler,
 		},
+		{
+			MethodName: "ViewChange",
+			Handler:    _Byz2PC_ViewChange_Handler,
+		},
+		{
+			MethodName: "NewView",
+			Handler:    _Byz2PC_NewView_Handler,
+		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Byz2PC_Checkpoint_Handler,
+		},
+		{
+			MethodName: "ShareStatement",
+			Handler:    _Byz2PC_ShareStatement_Handler,
+		},
+		{
+			MethodName: "ReportMisbehavior",
+			Handler:    _Byz2PC_ReportMisbehavior_Handler,
+		},
+		{
+			MethodName: "ExchangeSessionKey",
+			Handler:    _Byz2PC_ExchangeSessionKey_Handler,
+		},
+		{
+			MethodName: "AnnounceKey",
+			Handler:    _Byz2PC_AnnounceKey_Handler,
+		},
+		{
+			MethodName: "GetKeyAnnouncements",
+			Handler:    _Byz2PC_GetKeyAnnouncements_Handler,
+		},
 		{
 			MethodName: "TwoPCPrepareRequest",
 			Handler:    _Byz2PC_TwoPCPrepareRequest_Handler,
@@ -700,6 +1242,10 @@ var Byz2PC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TwoPCCommitRequest",
 			Handler:    _Byz2PC_TwoPCCommitRequest_Handler,
 		},
+		{
+			MethodName: "TwoPCDecision",
+			Handler:    _Byz2PC_TwoPCDecision_Handler,
+		},
 		{
 			MethodName: "TwoPCCommit",
 			Handler:    _Byz2PC_TwoPCCommit_Handler,
@@ -708,6 +1254,10 @@ var Byz2PC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TwoPCAbort",
 			Handler:    _Byz2PC_TwoPCAbort_Handler,
 		},
+		{
+			MethodName: "AbortVoteShare",
+			Handler:    _Byz2PC_AbortVoteShare_Handler,
+		},
 		{
 			MethodName: "Performance",
 			Handler:    _Byz2PC_Performance_Handler,
@@ -716,10 +1266,30 @@ var Byz2PC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PrintBalance",
 			Handler:    _Byz2PC_PrintBalance_Handler,
 		},
+		{
+			MethodName: "ReadBalance",
+			Handler:    _Byz2PC_ReadBalance_Handler,
+		},
 		{
 			MethodName: "PrintDB",
 			Handler:    _Byz2PC_PrintDB_Handler,
 		},
+		{
+			MethodName: "PrintLocks",
+			Handler:    _Byz2PC_PrintLocks_Handler,
+		},
+		{
+			MethodName: "GetTxnStatus",
+			Handler:    _Byz2PC_GetTxnStatus_Handler,
+		},
+		{
+			MethodName: "GetMisbehaviorEvidence",
+			Handler:    _Byz2PC_GetMisbehaviorEvidence_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _Byz2PC_RotateKey_Handler,
+		},
 		{
 			MethodName: "Benchmark",
 			Handler:    _Byz2PC_Benchmark_Handler,
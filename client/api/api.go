@@ -47,6 +47,60 @@ func (c *Client) PrintDB(ctx context.Context, req *common.PrintDBRequest) (*comm
 	return resp, nil
 }
 
+func (c *Client) PrintLocks(ctx context.Context, req *common.PrintLocksRequest) (*common.PrintLocksResponse, error) {
+	resp, err := logic.PrintLocks(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error printing locks: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetMisbehaviorEvidence(ctx context.Context, req *common.EvidenceRequest) (*common.EvidenceResponse, error) {
+	resp, err := logic.GetMisbehaviorEvidence(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error getting misbehavior evidence: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) RotateKey(ctx context.Context, req *common.RotateKeyRequest) (*common.KeyAnnouncement, error) {
+	resp, err := logic.RotateKey(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error rotating key: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) AnnounceKey(ctx context.Context, req *common.KeyAnnouncement) (*emptypb.Empty, error) {
+	err := logic.AnnounceKey(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error accepting key announcement: %v", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (c *Client) ReadBalance(ctx context.Context, req *common.ReadBalanceRequest) (*common.ReadBalanceResponse, error) {
+	resp, err := logic.ReadBalance(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error reading balance: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) GetTxnStatus(ctx context.Context, req *common.TxnStatusRequest) (*common.TxnStatusResponse, error) {
+	resp, err := logic.GetTxnStatus(ctx, req, c.Config)
+	if err != nil {
+		fmt.Printf("Error getting txn status: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *Client) Performance(ctx context.Context, _ *emptypb.Empty) (*common.PerformanceResponse, error) {
 	resp, err := logic.Performance(ctx, c.Config)
 	if err != nil {
@@ -3,12 +3,14 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"log"
 	"os"
 	"sync"
 	"time"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
 	serverPool "GolandProjects/2pcbyz-gautamsardana/server_pool"
 )
 
@@ -22,17 +24,26 @@ type Config struct {
 	TotalUsers          int32    `json:"total_users"`
 	DataItemsPerShard   int32
 	Pool                *serverPool.ServerPool
+	PublicKeys          *KeyPool.KeyPool
 	DBDSN               string `json:"db_dsn"`
 	MapClusterToServers map[int32][]int32
-	ViewNumber          int32 `json:"view_number"`
+	ViewNumber          int32  `json:"view_number"`
+	ClientTimeout       int32  `json:"client_timeout_ms"`
+	MaxRetries          int32  `json:"max_retries"`
+	KeyDir              string `json:"key_dir"`
+	ClientID            string
 
-	Lock         sync.Mutex
-	TxnResponses map[string][]*common.ProcessTxnResponse
+	Lock          sync.Mutex
+	TxnResponses  map[string][]*common.ProcessTxnResponse
+	CompletedTxns map[string]string
+	PendingReads  map[string]chan float32
 
 	TxnQueueLock sync.Mutex
 	TxnStartTime map[string]time.Time
+	TxnTimers    map[string]*time.Timer
 	LatencyQueue []time.Duration
 	TxnCount     int32
+	Timestamp    int64
 }
 
 func GetConfig() *Config {
@@ -60,11 +71,23 @@ func InitiateServerPool(conf *Config) {
 }
 
 func InitiateConfig(conf *Config) {
+	conf.ClientID = uuid.NewString()
 	conf.TxnResponses = make(map[string][]*common.ProcessTxnResponse)
+	conf.CompletedTxns = make(map[string]string)
+	conf.PendingReads = make(map[string]chan float32)
 	conf.TxnStartTime = make(map[string]time.Time)
+	conf.TxnTimers = make(map[string]*time.Timer)
 	conf.LatencyQueue = make([]time.Duration, 0)
 }
 
+func InitiatePublicKeys(conf *Config) {
+	pool, err := KeyPool.NewPublicKeyPool(conf.KeyDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.PublicKeys = pool
+}
+
 func InitiateClusters(conf *Config) {
 	conf.DataItemsPerShard = conf.TotalUsers / conf.Clusters
 	conf.MapClusterToServers = make(map[int32][]int32)
@@ -21,6 +21,9 @@ func PopulateDB(dsn string, server, userStart, userEnd int32) {
 		"DELETE FROM transaction",
 		"DELETE FROM user",
 		"DELETE FROM pbft_messages",
+		"DELETE FROM checkpoint",
+		"DELETE FROM two_pc_state",
+		"DELETE FROM client_reply",
 	}
 	for _, query := range queries {
 		if _, err := db.Exec(query); err != nil {
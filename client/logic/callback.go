@@ -2,19 +2,155 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/client/config"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
+// Callback collects the signed replies of the replicas for a txn, the txn is complete once f+1 of them
+// report the same outcome since at least one of those comes from a correct replica
+
 func Callback(ctx context.Context, conf *config.Config, resp *common.ProcessTxnResponse) {
 	fmt.Printf("received response for txn: %v\n", resp)
 
+	signedReply, err := VerifyReply(conf, resp)
+	if err != nil {
+		fmt.Printf("dropping reply from server %d: %v\n", resp.ServerNo, err)
+		return
+	}
+
+	conf.Lock.Lock()
+	defer conf.Lock.Unlock()
+
+	if _, ok := conf.CompletedTxns[signedReply.TxnID]; ok {
+		return
+	}
+
+	for _, received := range conf.TxnResponses[signedReply.TxnID] {
+		if received.ServerNo == resp.ServerNo {
+			return
+		}
+	}
+	conf.TxnResponses[signedReply.TxnID] = append(conf.TxnResponses[signedReply.TxnID], resp)
+
+	matching := 0
+	for _, received := range conf.TxnResponses[signedReply.TxnID] {
+		if received.Status == signedReply.Status && received.Balance == signedReply.Balance {
+			matching++
+		} else {
+			fmt.Printf("conflicting replies for txn %s: server %d says %s (%v), server %d says %s (%v)\n",
+				signedReply.TxnID, received.ServerNo, received.Status, received.Balance, resp.ServerNo,
+				signedReply.Status, signedReply.Balance)
+		}
+	}
+	if int32(matching) < GetMaxFaulty(conf)+1 {
+		return
+	}
+
+	fmt.Printf("txn %s completed with status %s\n", signedReply.TxnID, signedReply.Status)
+	StopTxnTimer(conf, signedReply.TxnID)
+	conf.CompletedTxns[signedReply.TxnID] = signedReply.Status
+	delete(conf.TxnResponses, signedReply.TxnID)
+
+	if pendingRead, ok := conf.PendingReads[signedReply.TxnID]; ok {
+		pendingRead <- signedReply.Balance
+		delete(conf.PendingReads, signedReply.TxnID)
+		return
+	}
+
 	conf.TxnQueueLock.Lock()
-	conf.LatencyQueue = append(conf.LatencyQueue, time.Since(conf.TxnStartTime[resp.Txn.TxnID]))
+	conf.LatencyQueue = append(conf.LatencyQueue, time.Since(conf.TxnStartTime[signedReply.TxnID]))
 	conf.TxnQueueLock.Unlock()
+}
+
+// VerifyReply checks resp is signed by the server it claims to come from and returns the reply it signed,
+// the status and error in resp are replaced with the signed ones
+
+func VerifyReply(conf *config.Config, resp *common.ProcessTxnResponse) (*common.SignedReply, error) {
+	serverAddr, ok := mapServerNoToServerAddr[resp.ServerNo]
+	if !ok {
+		return nil, errors.New("unknown server")
+	}
+	err := VerifyServerSignature(conf, serverAddr, resp.SignedReply, resp.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	signedReply := &common.SignedReply{}
+	err = signedPayload.Unmarshal(resp.SignedReply, signedReply)
+	if err != nil {
+		return nil, err
+	}
+	if signedReply.ClientID != conf.ClientID {
+		return nil, errors.New("reply for another client")
+	}
+	resp.Status = signedReply.Status
+	resp.Error = signedReply.Error
+	resp.Balance = signedReply.Balance
+	return signedReply, nil
+}
+
+func VerifySignature(publicKey KeyPool.Verifier, message, signature []byte) error {
+	return publicKey.Verify(message, signature)
+}
+
+// VerifyServerSignature checks signature of the server at serverAddr over message. if it does not verify, the
+// server may have rotated its key while the client missed the announcement, so the client fetches the
+// announcements it missed from the server and checks again
+
+func VerifyServerSignature(conf *config.Config, serverAddr string, message, signature []byte) error {
+	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
+	if err != nil {
+		return err
+	}
+	err = VerifySignature(publicKey, message, signature)
+	if err == nil {
+		return nil
+	}
+
+	fetchErr := FetchKeyAnnouncements(conf, serverAddr)
+	if fetchErr != nil {
+		return err
+	}
+	publicKey, err = conf.PublicKeys.GetPublicKey(serverAddr)
+	if err != nil {
+		return err
+	}
+	return VerifySignature(publicKey, message, signature)
+}
+
+func FetchKeyAnnouncements(conf *config.Config, serverAddr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.ClientTimeout)*time.Millisecond)
+	defer cancel()
+
+	server, err := conf.Pool.GetServer(serverAddr)
+	if err != nil {
+		return err
+	}
+	resp, err := server.GetKeyAnnouncements(ctx, &common.KeyAnnouncementsRequest{
+		Address: serverAddr,
+		Version: conf.PublicKeys.GetKeyVersion(serverAddr),
+	})
+	if err != nil {
+		return err
+	}
+	for _, announcement := range resp.Announcements {
+		err = AnnounceKey(ctx, announcement, conf)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("caught up on key version %d of %s\n", announcement.Version, serverAddr)
+	}
+	return nil
+}
+
+// GetMaxFaulty returns f, the number of faulty replicas a cluster tolerates
 
-	return
+func GetMaxFaulty(conf *config.Config) int32 {
+	return (conf.ClusterSize - 1) / 3
 }
@@ -11,6 +11,7 @@ import (
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/client/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func PrintBalance(ctx context.Context, req *common.PrintBalanceRequest, conf *config.Config) (*common.PrintBalanceResponse, error) {
@@ -49,6 +50,60 @@ func PrintDB(ctx context.Context, req *common.PrintDBRequest, conf *config.Confi
 	return resp, nil
 }
 
+func PrintLocks(ctx context.Context, req *common.PrintLocksRequest, conf *config.Config) (*common.PrintLocksResponse, error) {
+	serverAddr := mapServerNoToServerAddr[req.Server]
+	server, err := conf.Pool.GetServer(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := server.PrintLocks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func GetMisbehaviorEvidence(ctx context.Context, req *common.EvidenceRequest, conf *config.Config) (*common.EvidenceResponse, error) {
+	serverAddr := mapServerNoToServerAddr[req.Server]
+	server, err := conf.Pool.GetServer(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := server.GetMisbehaviorEvidence(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func RotateKey(ctx context.Context, req *common.RotateKeyRequest, conf *config.Config) (*common.KeyAnnouncement, error) {
+	serverAddr := mapServerNoToServerAddr[req.Server]
+	server, err := conf.Pool.GetServer(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := server.RotateKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AnnounceKey switches to the rotated key of a server once its old key signed the announcement
+
+func AnnounceKey(_ context.Context, req *common.KeyAnnouncement, conf *config.Config) error {
+	payload, err := signedPayload.Marshal(&common.KeyAnnouncement{
+		Address:   req.Address,
+		Version:   req.Version,
+		KeyType:   req.KeyType,
+		PublicKey: req.PublicKey,
+	})
+	if err != nil {
+		return err
+	}
+	return conf.PublicKeys.AcceptRotatedKey(req.Address, req.Version, req.PublicKey, payload, req.Sign)
+}
+
 func Performance(_ context.Context, conf *config.Config) (*common.PerformanceResponse, error) {
 	var totalLatency time.Duration
 
@@ -4,9 +4,12 @@ const (
 	EmptyString            = ""
 	StatusSuccess          = "Success"
 	StatusFailed           = "Failed"
+	StatusNotFound         = "Not-Found"
+	StatusUnreachable      = "Unreachable"
 	TypeIntraShard         = "IntraShard"
 	TypeCrossShardSender   = "CrossShard-Sender"
 	TypeCrossShardReceiver = "CrossShard-Receiver"
+	TypeReadOnly           = "Read-Only"
 )
 
 var mapServerToServerNo = map[string]int32{
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"log"
 	"math"
+	"sync"
 	"time"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
@@ -24,6 +25,12 @@ func ProcessTxnSet(ctx context.Context, req *common.TxnSet, conf *config.Config)
 		isServerByzantine[mapServerToServerNo[byzantineServer]] = true
 	}
 
+	faults := make(map[int32]*common.FaultConfig)
+	for byzantineServer, fault := range req.Faults {
+		faults[mapServerToServerNo[byzantineServer]] = fault
+		isServerByzantine[mapServerToServerNo[byzantineServer]] = true
+	}
+
 	updateServerStateReq := &common.UpdateServerStateRequest{
 		Clusters: make(map[int32]*common.ClusterDistribution),
 	}
@@ -44,6 +51,7 @@ func ProcessTxnSet(ctx context.Context, req *common.TxnSet, conf *config.Config)
 				ClusterNumber:     cluster,
 				IsAlive:           isServerAlive[serverNo],
 				IsByzantine:       isServerByzantine[serverNo],
+				Faults:            faults[serverNo],
 				DataItemsPerShard: conf.DataItemsPerShard,
 			}
 			server.UpdateServerState(ctx, serverReq)
@@ -57,7 +65,7 @@ func ProcessTxnSet(ctx context.Context, req *common.TxnSet, conf *config.Config)
 		}
 		txn.TxnID = txnID.String()
 
-		fmt.Println("processing", txn, "\n")
+		fmt.Println("processing", txn)
 		senderCluster := math.Ceil(float64(txn.Sender) / float64(conf.DataItemsPerShard))
 
 		conf.TxnCount++
@@ -70,11 +78,18 @@ func ProcessTxnSet(ctx context.Context, req *common.TxnSet, conf *config.Config)
 func ProcessTxn(conf *config.Config, txn *common.TxnRequest, cluster int32, contactServers []string) {
 	conf.TxnQueueLock.Lock()
 	conf.TxnStartTime[txn.TxnID] = time.Now()
+	if txn.ClientID == EmptyString {
+		txn.ClientID = conf.ClientID
+		txn.ClientTimestamp = NextTimestamp(conf)
+	}
 	conf.TxnQueueLock.Unlock()
 
+	StartTxnTimer(conf, txn, cluster, 0)
+
 	server, err := conf.Pool.GetServer(GetContactServerForCluster(conf, cluster, contactServers))
 	if err != nil {
 		fmt.Println(err)
+		return
 	}
 
 	_, err = server.ProcessTxn(context.Background(), txn)
@@ -83,6 +98,75 @@ func ProcessTxn(conf *config.Config, txn *common.TxnRequest, cluster int32, cont
 	}
 }
 
+// StartTxnTimer waits for f+1 matching replies to txn, if they do not arrive in time the txn is sent to
+// every server of the cluster, which forward it to their leader and suspect it if it does not get ordered
+
+func StartTxnTimer(conf *config.Config, txn *common.TxnRequest, cluster, retries int32) {
+	conf.TxnQueueLock.Lock()
+	defer conf.TxnQueueLock.Unlock()
+
+	conf.TxnTimers[txn.TxnID] = time.AfterFunc(time.Duration(conf.ClientTimeout)*time.Millisecond, func() {
+		RetryTxn(conf, txn, cluster, retries+1)
+	})
+}
+
+func StopTxnTimer(conf *config.Config, txnID string) {
+	conf.TxnQueueLock.Lock()
+	defer conf.TxnQueueLock.Unlock()
+
+	if timer, exists := conf.TxnTimers[txnID]; exists {
+		timer.Stop()
+		delete(conf.TxnTimers, txnID)
+	}
+}
+
+func RetryTxn(conf *config.Config, txn *common.TxnRequest, cluster, retries int32) {
+	conf.Lock.Lock()
+	_, isCompleted := conf.CompletedTxns[txn.TxnID]
+	conf.Lock.Unlock()
+	if isCompleted {
+		return
+	}
+
+	if retries > conf.MaxRetries {
+		fmt.Printf("giving up on txn %s after %d retries\n", txn.TxnID, conf.MaxRetries)
+		StopTxnTimer(conf, txn.TxnID)
+		return
+	}
+
+	fmt.Printf("txn %s timed out, broadcasting it to cluster %d (retry %d)\n", txn.TxnID, cluster, retries)
+	StartTxnTimer(conf, txn, cluster, retries)
+	BroadcastTxn(conf, txn, cluster)
+}
+
+func BroadcastTxn(conf *config.Config, txn *common.TxnRequest, cluster int32) {
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[cluster] {
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.ProcessTxn(context.Background(), txn)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(mapServerNoToServerAddr[serverNo])
+	}
+	wg.Wait()
+}
+
+// NextTimestamp returns the timestamp of the next request of this client, replicas use it along with the
+// client id to recognise a retried request. it must be called with TxnQueueLock held
+
+func NextTimestamp(conf *config.Config) int64 {
+	conf.Timestamp = max(conf.Timestamp+1, time.Now().UnixNano())
+	return conf.Timestamp
+}
+
 func GetContactServerForCluster(conf *config.Config, cluster int32, contactServers []string) string {
 	for _, serverNo := range conf.MapClusterToServers[cluster] {
 		for _, contactServer := range contactServers {
@@ -0,0 +1,150 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/client/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// ReadBalance reads the balance of a user linearizably with the read only optimization of pbft: every
+// replica of the user's cluster answers from its committed state with a signed reply and the balance is
+// accepted once 2f+1 of them agree on it at the same executed sequence number. otherwise the read is ordered through consensus like a txn
+
+func ReadBalance(ctx context.Context, req *common.ReadBalanceRequest, conf *config.Config) (*common.ReadBalanceResponse, error) {
+	cluster := int32(math.Ceil(float64(req.User) / float64(conf.DataItemsPerShard)))
+	if _, ok := conf.MapClusterToServers[cluster]; !ok {
+		return nil, errors.New("unknown user")
+	}
+
+	resp, ok := ReadBalanceFromReplicas(ctx, conf, req, cluster)
+	if ok {
+		return resp, nil
+	}
+
+	fmt.Printf("replicas of cluster %d did not agree on the balance of user %d, ordering the read\n", cluster, req.User)
+	return ReadBalanceOrdered(conf, req, cluster)
+}
+
+func ReadBalanceFromReplicas(ctx context.Context, conf *config.Config, req *common.ReadBalanceRequest, cluster int32) (*common.ReadBalanceResponse, bool) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(conf.ClientTimeout)*time.Millisecond)
+	defer cancel()
+
+	req = &common.ReadBalanceRequest{User: req.User, ReadID: uuid.NewString()}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var responses []*common.ReadBalanceResponse
+	for _, serverNo := range conf.MapClusterToServers[cluster] {
+		wg.Add(1)
+		go func(serverNo int32) {
+			defer wg.Done()
+			server, err := conf.Pool.GetServer(mapServerNoToServerAddr[serverNo])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			resp, err := server.ReadBalance(ctx, req)
+			if err != nil {
+				fmt.Printf("read from server %d failed: %v\n", serverNo, err)
+				return
+			}
+			resp.ServerNo = serverNo
+			err = VerifyReadReply(conf, req, resp)
+			if err != nil {
+				fmt.Printf("dropping read reply from server %d: %v\n", serverNo, err)
+				return
+			}
+
+			lock.Lock()
+			responses = append(responses, resp)
+			lock.Unlock()
+		}(serverNo)
+	}
+	wg.Wait()
+
+	type readResult struct {
+		balance float32
+		seqNo   int32
+	}
+	matching := make(map[readResult]int32)
+	for _, resp := range responses {
+		result := readResult{balance: resp.Balance, seqNo: resp.SeqNo}
+		matching[result]++
+		if matching[result] >= conf.ClusterSize-GetMaxFaulty(conf) {
+			return &common.ReadBalanceResponse{User: req.User, Balance: result.balance, SeqNo: result.seqNo}, true
+		}
+	}
+	return nil, false
+}
+
+// VerifyReadReply checks resp is signed by the server it claims to come from and answers req, the balance and
+// sequence number in resp are replaced with the signed ones
+
+func VerifyReadReply(conf *config.Config, req *common.ReadBalanceRequest, resp *common.ReadBalanceResponse) error {
+	serverAddr, ok := mapServerNoToServerAddr[resp.ServerNo]
+	if !ok {
+		return errors.New("unknown server")
+	}
+	err := VerifyServerSignature(conf, serverAddr, resp.SignedRead, resp.Sign)
+	if err != nil {
+		return err
+	}
+
+	signedRead := &common.SignedRead{}
+	err = signedPayload.Unmarshal(resp.SignedRead, signedRead)
+	if err != nil {
+		return err
+	}
+	if signedRead.ReadID != req.ReadID || signedRead.User != req.User {
+		return errors.New("reply for another read")
+	}
+	resp.Balance = signedRead.Balance
+	resp.SeqNo = signedRead.SeqNo
+	return nil
+}
+
+// ReadBalanceOrdered sends the read to every replica of the cluster as a read only txn, it is answered like
+// any other txn once f+1 replicas executed it and sent the same balance back
+
+func ReadBalanceOrdered(conf *config.Config, req *common.ReadBalanceRequest, cluster int32) (*common.ReadBalanceResponse, error) {
+	txn := &common.TxnRequest{
+		TxnID:    uuid.NewString(),
+		Sender:   req.User,
+		Type:     TypeReadOnly,
+		ClientID: conf.ClientID,
+	}
+
+	conf.TxnQueueLock.Lock()
+	txn.ClientTimestamp = NextTimestamp(conf)
+	conf.TxnQueueLock.Unlock()
+
+	pendingRead := make(chan float32, 1)
+	conf.Lock.Lock()
+	conf.PendingReads[txn.TxnID] = pendingRead
+	conf.Lock.Unlock()
+
+	for retries := int32(0); retries <= conf.MaxRetries; retries++ {
+		go BroadcastTxn(conf, txn, cluster)
+
+		select {
+		case balance := <-pendingRead:
+			return &common.ReadBalanceResponse{User: req.User, Balance: balance, IsOrdered: true}, nil
+		case <-time.After(time.Duration(conf.ClientTimeout) * time.Millisecond):
+			fmt.Printf("ordered read %s of user %d timed out (retry %d)\n", txn.TxnID, req.User, retries)
+		}
+	}
+
+	conf.Lock.Lock()
+	delete(conf.PendingReads, txn.TxnID)
+	conf.Lock.Unlock()
+	return nil, errors.New("ordered read timed out")
+}
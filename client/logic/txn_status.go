@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/client/config"
+)
+
+// GetTxnStatus asks every replica for the state of a txn. a txn id does not say which shards it touched, so
+// all clusters are asked and the ones where no replica knows the txn are left out. for each involved cluster
+// the state most replicas agree on is returned along with the replicas that report something else
+
+func GetTxnStatus(ctx context.Context, req *common.TxnStatusRequest, conf *config.Config) (*common.TxnStatusResponse, error) {
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	replicasByCluster := make(map[int32][]*common.ReplicaTxnStatus)
+
+	for cluster, serverNos := range conf.MapClusterToServers {
+		for _, serverNo := range serverNos {
+			wg.Add(1)
+			go func(cluster, serverNo int32) {
+				defer wg.Done()
+				status := GetReplicaTxnStatus(ctx, conf, req, serverNo)
+
+				lock.Lock()
+				replicasByCluster[cluster] = append(replicasByCluster[cluster], status)
+				lock.Unlock()
+			}(cluster, serverNo)
+		}
+	}
+	wg.Wait()
+
+	resp := &common.TxnStatusResponse{TxnID: req.TxnID}
+	for cluster, replicas := range replicasByCluster {
+		sort.Slice(replicas, func(i, j int) bool { return replicas[i].Server < replicas[j].Server })
+
+		isInvolved := false
+		for _, replica := range replicas {
+			if replica.Status != StatusNotFound && replica.Status != StatusUnreachable {
+				isInvolved = true
+			}
+		}
+		if !isInvolved {
+			continue
+		}
+		resp.Clusters = append(resp.Clusters, GetClusterTxnStatus(conf, cluster, replicas))
+	}
+	sort.Slice(resp.Clusters, func(i, j int) bool { return resp.Clusters[i].Cluster < resp.Clusters[j].Cluster })
+
+	return resp, nil
+}
+
+func GetReplicaTxnStatus(ctx context.Context, conf *config.Config, req *common.TxnStatusRequest, serverNo int32) *common.ReplicaTxnStatus {
+	unreachable := &common.ReplicaTxnStatus{Server: serverNo, Status: StatusUnreachable}
+
+	server, err := conf.Pool.GetServer(mapServerNoToServerAddr[serverNo])
+	if err != nil {
+		unreachable.Error = err.Error()
+		return unreachable
+	}
+	resp, err := server.GetTxnStatus(ctx, req)
+	if err != nil {
+		unreachable.Error = err.Error()
+		return unreachable
+	}
+	if len(resp.Replicas) == 0 {
+		unreachable.Error = "empty response"
+		return unreachable
+	}
+
+	status := resp.Replicas[0]
+	status.Server = serverNo
+	return status
+}
+
+// GetClusterTxnStatus picks the state reported by most reachable replicas of a cluster, it has a quorum if
+// 2f+1 of them report it. every other reachable replica is flagged as disagreeing
+
+func GetClusterTxnStatus(conf *config.Config, cluster int32, replicas []*common.ReplicaTxnStatus) *common.ClusterTxnStatus {
+	type txnState struct {
+		status string
+		seqNo  int32
+		viewNo int32
+	}
+
+	votes := make(map[txnState]int32)
+	var quorumState txnState
+	var quorumVotes int32
+	for _, replica := range replicas {
+		if replica.Status == StatusUnreachable {
+			continue
+		}
+		state := txnState{status: replica.Status, seqNo: replica.SeqNo, viewNo: replica.ViewNo}
+		votes[state]++
+		if votes[state] > quorumVotes {
+			quorumState = state
+			quorumVotes = votes[state]
+		}
+	}
+
+	clusterStatus := &common.ClusterTxnStatus{
+		Cluster:   cluster,
+		Status:    quorumState.status,
+		SeqNo:     quorumState.seqNo,
+		ViewNo:    quorumState.viewNo,
+		HasQuorum: quorumVotes >= conf.ClusterSize-GetMaxFaulty(conf),
+		Replicas:  replicas,
+	}
+	for _, replica := range replicas {
+		if replica.Status == StatusUnreachable {
+			continue
+		}
+		if replica.Status != quorumState.status || replica.SeqNo != quorumState.seqNo || replica.ViewNo != quorumState.viewNo {
+			clusterStatus.DisagreeingReplicas = append(clusterStatus.DisagreeingReplicas, replica.Server)
+		}
+	}
+	return clusterStatus
+}
@@ -15,6 +15,7 @@ func main() {
 	conf := config.GetConfig()
 	config.InitiateServerPool(conf)
 	config.InitiateConfig(conf)
+	config.InitiatePublicKeys(conf)
 	config.InitiateClusters(conf)
 	config.InitiateDB(conf)
 	ListenAndServe(conf)
@@ -3,6 +3,7 @@ module GolandProjects/2pcbyz-gautamsardana
 go 1.23.1
 
 require (
+	github.com/cloudflare/circl v1.6.1
 	github.com/go-sql-driver/mysql v1.8.1
 	github.com/google/uuid v1.6.0
 	google.golang.org/grpc v1.68.1
@@ -11,6 +12,7 @@ require (
 
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
+	golang.org/x/crypto v0.27.0 // indirect
 	golang.org/x/net v0.29.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
 	golang.org/x/text v0.18.0 // indirect
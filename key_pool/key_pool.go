@@ -1,98 +1,283 @@
 package key_pool
 
 import (
-	"crypto/rsa"
-	"crypto/x509"
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 const configPath = "/go/src/GolandProjects/2pcbyz-gautamsardana/key_pool/config.json"
 
+// a key directory holds a <node>.pub file with the public key of every node and the <node>.key file of the
+// nodes running from it, as written by keygen. every file is a pem block whose headers name the address of
+// the node and the version of its key. once a node rotated its key, <node>.history keeps every version of
+// its public key along with the signed announcement of each, so signatures made before the rotation still
+// verify and a node that missed an announcement can fetch it later. without a key directory the keys are
+// read from config.json
+
+const (
+	PublicKeyExtension  = ".pub"
+	PrivateKeyExtension = ".key"
+	KeyHistoryExtension = ".history"
+	AddressHeader       = "Address"
+	VersionHeader       = "Version"
+	SignHeader          = "Sign"
+)
+
 type Config struct {
-	PublicKeys  map[string]string `json:"public_keys"`
-	PrivateKeys map[string]string `json:"private_keys"`
+	PublicKeys          map[string]string `json:"public_keys"`
+	PrivateKeys         map[string]string `json:"private_keys"`
+	ThresholdKeys       map[string]string `json:"threshold_keys"`
+	ThresholdPublicKeys map[string]string `json:"threshold_public_keys"`
+	ClusterKeys         map[string]string `json:"cluster_keys"`
 }
 
 type KeyPool struct {
-	PublicKeys map[string]*rsa.PublicKey
-	PrivateKey map[string]*rsa.PrivateKey
+	Lock       sync.RWMutex
+	KeyDir     string
+	PublicKeys map[string]Verifier
+	Versions   map[string]int32
+	History    map[string][]*KeyVersion
+	PrivateKey map[string]Signer
+}
+
+// KeyVersion is a public key a node held, Sign is the signature of its announcement by the version before it
+type KeyVersion struct {
+	Version   int32
+	PublicKey Verifier
+	Sign      []byte
+}
+
+// keyRing verifies signatures made with any version of the key of a node, the newest first
+type keyRing struct {
+	keys []Verifier
+}
+
+func (r *keyRing) Verify(message, signature []byte) error {
+	var err error
+	for _, key := range r.keys {
+		err = key.Verify(message, signature)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *keyRing) KeyType() string {
+	return r.keys[0].KeyType()
+}
+
+func (r *keyRing) MarshalPublicKey() ([]byte, error) {
+	return r.keys[0].MarshalPublicKey()
 }
 
-func NewPublicKeyPool() (*KeyPool, error) {
-	conf := GetConfig()
+func NewPublicKeyPool(keyDir string) (*KeyPool, error) {
 	publicKeyPool := &KeyPool{
-		PublicKeys: make(map[string]*rsa.PublicKey),
+		KeyDir:     keyDir,
+		PublicKeys: make(map[string]Verifier),
+		Versions:   make(map[string]int32),
+		History:    make(map[string][]*KeyVersion),
 	}
 
-	for addr, pubKeyStr := range conf.PublicKeys {
-		pubKeyBytes, err := base64.StdEncoding.DecodeString(pubKeyStr)
+	blocks, err := ReadKeyBlocks(keyDir, PublicKeyExtension, AddressHeader, func(conf *Config) map[string]string { return conf.PublicKeys })
+	if err != nil {
+		return nil, err
+	}
+	for addr, block := range blocks {
+		pubKey, err := ParsePublicKey(block.Bytes)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64 public key for %s: %v", addr, err)
+			return nil, fmt.Errorf("failed to parse public key for %s: %v", addr, err)
 		}
+		publicKeyPool.PublicKeys[addr] = pubKey
+		publicKeyPool.Versions[addr] = GetKeyVersion(block)
+		publicKeyPool.History[addr] = []*KeyVersion{{Version: GetKeyVersion(block), PublicKey: pubKey}}
+	}
 
-		block, _ := pem.Decode(pubKeyBytes)
-		if block == nil {
-			return nil, fmt.Errorf("failed to parse PEM block containing the public key for %s", addr)
+	if keyDir != "" {
+		err = publicKeyPool.ReadKeyHistory()
+		if err != nil {
+			return nil, err
 		}
+	}
+	return publicKeyPool, nil
+}
 
-		pubInterface, err := x509.ParsePKIXPublicKey(block.Bytes)
+// ReadKeyHistory reads the versions of the key of every node with a history file, a history that does not end
+// with the current key, as after keygen wrote the key directory again, is ignored
+func (pkp *KeyPool) ReadKeyHistory() error {
+	paths, err := filepath.Glob(filepath.Join(pkp.KeyDir, "*"+KeyHistoryExtension))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		historyBytes, err := os.ReadFile(path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse public key for %s: %v", addr, err)
+			return err
 		}
-
-		pubKey, ok := pubInterface.(*rsa.PublicKey)
-		if !ok {
-			return nil, fmt.Errorf("not an RSA public key for %s", addr)
+		var history []*KeyVersion
+		var addr string
+		for block, rest := pem.Decode(historyBytes); block != nil; block, rest = pem.Decode(rest) {
+			addr = block.Headers[AddressHeader]
+			keyVersion, err := ParseKeyVersion(block)
+			if err != nil {
+				return fmt.Errorf("failed to parse key history in %s: %v", path, err)
+			}
+			history = append(history, keyVersion)
 		}
 
-		publicKeyPool.PublicKeys[addr] = pubKey
+		current, ok := pkp.History[addr]
+		if !ok || len(history) == 0 || !IsSameKey(history[len(history)-1].PublicKey, current[0].PublicKey) {
+			continue
+		}
+		pkp.History[addr] = history
 	}
+	return nil
+}
 
-	return publicKeyPool, nil
+func IsSameKey(key, otherKey Verifier) bool {
+	keyBytes, err := key.MarshalPublicKey()
+	if err != nil {
+		return false
+	}
+	otherKeyBytes, err := otherKey.MarshalPublicKey()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(keyBytes, otherKeyBytes)
 }
 
-func (pkp *KeyPool) GetPublicKey(addr string) (*rsa.PublicKey, error) {
+// GetPublicKey returns the public key of addr, which also verifies signatures made with its older versions,
+// like the prepared certificates of a view change or checkpoint proofs signed before a rotation
+func (pkp *KeyPool) GetPublicKey(addr string) (Verifier, error) {
+	pkp.Lock.RLock()
+	defer pkp.Lock.RUnlock()
 	pubKey, exists := pkp.PublicKeys[addr]
 	if !exists {
 		return nil, fmt.Errorf("public key not found for address %s", addr)
 	}
-	return pubKey, nil
+	history := pkp.History[addr]
+	if len(history) < 2 {
+		return pubKey, nil
+	}
+	ring := &keyRing{}
+	for i := len(history) - 1; i >= 0; i-- {
+		ring.keys = append(ring.keys, history[i].PublicKey)
+	}
+	return ring, nil
 }
 
-func NewPrivateKeyPool() (*KeyPool, error) {
-	conf := GetConfig()
-	privateKeyPool := &KeyPool{
-		PrivateKey: make(map[string]*rsa.PrivateKey),
+// GetKeyAnnouncements returns the versions of the key of addr after version that came with an announcement,
+// oldest first
+func (pkp *KeyPool) GetKeyAnnouncements(addr string, version int32) []*KeyVersion {
+	pkp.Lock.RLock()
+	defer pkp.Lock.RUnlock()
+	var announced []*KeyVersion
+	for _, keyVersion := range pkp.History[addr] {
+		if keyVersion.Version > version && len(keyVersion.Sign) > 0 {
+			announced = append(announced, keyVersion)
+		}
+	}
+	return announced
+}
+
+func (pkp *KeyPool) GetKeyVersion(addr string) int32 {
+	pkp.Lock.RLock()
+	defer pkp.Lock.RUnlock()
+	return pkp.Versions[addr]
+}
+
+// UpdatePublicKey replaces the public key of addr with a newer version announced with sign, and writes it to
+// the key directory so it is still known after a restart. the older versions are kept
+func (pkp *KeyPool) UpdatePublicKey(addr string, pubKey Verifier, version int32, sign []byte) error {
+	pkp.Lock.Lock()
+	defer pkp.Lock.Unlock()
+	if version <= pkp.Versions[addr] {
+		return fmt.Errorf("key version %d of %s is not newer than %d", version, addr, pkp.Versions[addr])
 	}
 
-	for addr, privateKeyStr := range conf.PrivateKeys {
-		privateKeyBytes, err := base64.StdEncoding.DecodeString(privateKeyStr)
+	history := append(pkp.History[addr], &KeyVersion{Version: version, PublicKey: pubKey, Sign: sign})
+	if pkp.KeyDir != "" {
+		err := WriteKeyHistory(pkp.KeyDir, addr, history)
+		if err != nil {
+			return err
+		}
+		err = WritePublicKey(pkp.KeyDir, addr, pubKey, version)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode base64 private key for %s: %v", addr, err)
+			return err
 		}
+	}
+	pkp.PublicKeys[addr] = pubKey
+	pkp.Versions[addr] = version
+	pkp.History[addr] = history
+	return nil
+}
 
-		block, _ := pem.Decode(privateKeyBytes)
-		if block == nil {
-			return nil, fmt.Errorf("failed to parse PEM block containing the private key for %s", addr)
+// AcceptRotatedKey switches addr to version, the DER encoded publicKey, once sign shows the version of the
+// key of addr right before it signed payload, the announcement of the new key
+func (pkp *KeyPool) AcceptRotatedKey(addr string, version int32, publicKey, payload, sign []byte) error {
+	previousKey, err := pkp.GetKeyOfVersion(addr, version-1)
+	if err != nil {
+		return err
+	}
+	err = previousKey.Verify(payload, sign)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse rotated public key for %s: %v", addr, err)
+	}
+	return pkp.UpdatePublicKey(addr, pubKey, version, sign)
+}
+
+func (pkp *KeyPool) GetKeyOfVersion(addr string, version int32) (Verifier, error) {
+	pkp.Lock.RLock()
+	defer pkp.Lock.RUnlock()
+	for _, keyVersion := range pkp.History[addr] {
+		if keyVersion.Version == version {
+			return keyVersion.PublicKey, nil
 		}
+	}
+	return nil, fmt.Errorf("key version %d of %s not known", version, addr)
+}
+
+func NewPrivateKeyPool(keyDir string) (*KeyPool, error) {
+	privateKeyPool := &KeyPool{
+		KeyDir:     keyDir,
+		Versions:   make(map[string]int32),
+		PrivateKey: make(map[string]Signer),
+	}
 
-		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	blocks, err := ReadKeyBlocks(keyDir, PrivateKeyExtension, AddressHeader, func(conf *Config) map[string]string { return conf.PrivateKeys })
+	if err != nil {
+		return nil, err
+	}
+	for addr, block := range blocks {
+		privateKey, err := ParsePrivateKey(block)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse private key for %s: %v", addr, err)
 		}
-
 		privateKeyPool.PrivateKey[addr] = privateKey
+		privateKeyPool.Versions[addr] = GetKeyVersion(block)
 	}
 
 	return privateKeyPool, nil
 }
 
-func (pkp *KeyPool) GetPrivateKey(addr string) (*rsa.PrivateKey, error) {
+func (pkp *KeyPool) GetPrivateKey(addr string) (Signer, error) {
+	pkp.Lock.RLock()
+	defer pkp.Lock.RUnlock()
 	privateKey, exists := pkp.PrivateKey[addr]
 	if !exists {
 		return nil, fmt.Errorf("private key not found for address %s", addr)
@@ -100,6 +285,148 @@ func (pkp *KeyPool) GetPrivateKey(addr string) (*rsa.PrivateKey, error) {
 	return privateKey, nil
 }
 
+// UpdatePrivateKey replaces the private key of addr after a rotation, the new key is written to the key
+// directory before it is used. without a key directory the key would be lost on a restart, so it is refused
+func (pkp *KeyPool) UpdatePrivateKey(addr string, privateKey Signer, version int32) error {
+	pkp.Lock.Lock()
+	defer pkp.Lock.Unlock()
+	if pkp.KeyDir == "" {
+		return errors.New("no key directory to keep the rotated key in")
+	}
+	err := WritePrivateKey(pkp.KeyDir, addr, privateKey, version)
+	if err != nil {
+		return err
+	}
+	pkp.PrivateKey[addr] = privateKey
+	pkp.Versions[addr] = version
+	return nil
+}
+
+// ReadKeyBlocks returns the pem blocks of the keys with extension in keyDir by the value of header, mostly
+// the node address, or the keys picked from config.json without a key directory
+func ReadKeyBlocks(keyDir, extension, header string, keys func(conf *Config) map[string]string) (map[string]*pem.Block, error) {
+	blocks := make(map[string]*pem.Block)
+	if keyDir == "" {
+		for key, keyStr := range keys(GetConfig()) {
+			keyBytes, err := base64.StdEncoding.DecodeString(keyStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode base64 key for %s: %v", key, err)
+			}
+			block, _ := pem.Decode(keyBytes)
+			if block == nil {
+				return nil, fmt.Errorf("failed to parse PEM block containing the key for %s", key)
+			}
+			blocks[key] = block
+		}
+		return blocks, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(keyDir, "*"+extension))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		keyBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(keyBytes)
+		if block == nil {
+			return nil, fmt.Errorf("failed to parse PEM block in %s", path)
+		}
+		key := block.Headers[header]
+		if key == "" {
+			return nil, fmt.Errorf("no %s header in %s", header, path)
+		}
+		blocks[key] = block
+	}
+	return blocks, nil
+}
+
+func GetKeyVersion(block *pem.Block) int32 {
+	version, _ := strconv.Atoi(block.Headers[VersionHeader])
+	return int32(version)
+}
+
+func GetKeyFileName(addr, extension string) string {
+	return strings.ReplaceAll(addr, ":", "_") + extension
+}
+
+func WritePublicKey(keyDir, addr string, pubKey Verifier, version int32) error {
+	keyBytes, err := pubKey.MarshalPublicKey()
+	if err != nil {
+		return err
+	}
+	block := &pem.Block{
+		Type:    "PUBLIC KEY",
+		Headers: map[string]string{AddressHeader: addr, VersionHeader: strconv.Itoa(int(version))},
+		Bytes:   keyBytes,
+	}
+	return WriteKeyFile(filepath.Join(keyDir, GetKeyFileName(addr, PublicKeyExtension)), block, 0644)
+}
+
+func WritePrivateKey(keyDir, addr string, privateKey Signer, version int32) error {
+	keyBytes, err := privateKey.MarshalPrivateKey()
+	if err != nil {
+		return err
+	}
+	block := &pem.Block{
+		Type:    "PRIVATE KEY",
+		Headers: map[string]string{AddressHeader: addr, VersionHeader: strconv.Itoa(int(version))},
+		Bytes:   keyBytes,
+	}
+	return WriteKeyFile(filepath.Join(keyDir, GetKeyFileName(addr, PrivateKeyExtension)), block, 0600)
+}
+
+func WriteKeyHistory(keyDir, addr string, history []*KeyVersion) error {
+	var historyBytes []byte
+	for _, keyVersion := range history {
+		keyBytes, err := keyVersion.PublicKey.MarshalPublicKey()
+		if err != nil {
+			return err
+		}
+		block := &pem.Block{
+			Type:    "PUBLIC KEY",
+			Headers: map[string]string{AddressHeader: addr, VersionHeader: strconv.Itoa(int(keyVersion.Version))},
+			Bytes:   keyBytes,
+		}
+		if len(keyVersion.Sign) > 0 {
+			block.Headers[SignHeader] = base64.StdEncoding.EncodeToString(keyVersion.Sign)
+		}
+		historyBytes = append(historyBytes, pem.EncodeToMemory(block)...)
+	}
+
+	path := filepath.Join(keyDir, GetKeyFileName(addr, KeyHistoryExtension))
+	tmpPath := path + ".tmp"
+	err := os.WriteFile(tmpPath, historyBytes, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func ParseKeyVersion(block *pem.Block) (*KeyVersion, error) {
+	pubKey, err := ParsePublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := base64.StdEncoding.DecodeString(block.Headers[SignHeader])
+	if err != nil {
+		return nil, err
+	}
+	return &KeyVersion{Version: GetKeyVersion(block), PublicKey: pubKey, Sign: sign}, nil
+}
+
+// WriteKeyFile replaces path through a temporary file so a crash never leaves a node with half a key
+func WriteKeyFile(path string, block *pem.Block, perm os.FileMode) error {
+	tmpPath := path + ".tmp"
+	err := os.WriteFile(tmpPath, pem.EncodeToMemory(block), perm)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 func GetConfig() *Config {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+)
+
+// keygen writes a key directory for every node of a topology: server_<n> for the servers and client_<n> for
+// the clients, each with the public keys of all nodes and the private key of that node only. point key_dir in
+// the server config at <out>/server_%d and in the client config at its client directory.
+// it also deals the threshold keys of every cluster, a server directory holds the key share of that server
+// next to the public key shares and cluster keys. with -config it only deals threshold keys and puts them in
+// that config.json instead, for servers that run without a key directory
+
+func main() {
+	out := flag.String("out", "keys", "directory to write the key directories to")
+	keyType := flag.String("type", KeyPool.KeyTypeEd25519, "key type: rsa, ed25519 or ecdsa")
+	clusters := flag.Int("clusters", 3, "number of clusters")
+	clusterSize := flag.Int("cluster-size", 4, "servers per cluster")
+	host := flag.String("host", "localhost", "host of the servers")
+	basePort := flag.Int("base-port", 8080, "server n listens on base-port+n")
+	clients := flag.String("clients", "localhost:8000", "comma separated client addresses")
+	threshold := flag.Int("threshold", 0, "signature shares needed for a cluster signature, 2f+1 if 0")
+	configPath := flag.String("config", "", "config.json to write the threshold keys to instead of key directories")
+	flag.Parse()
+
+	if *threshold == 0 {
+		*threshold = *clusterSize - (*clusterSize-1)/3
+	}
+
+	nodes := make(map[string]string)
+	var addresses []string
+	for serverNo := 1; serverNo <= *clusters**clusterSize; serverNo++ {
+		addr := fmt.Sprintf("%s:%d", *host, *basePort+serverNo)
+		nodes[addr] = fmt.Sprintf("server_%d", serverNo)
+		addresses = append(addresses, addr)
+	}
+
+	clusterKeys := make(map[int32]*KeyPool.ThresholdVerifier)
+	thresholdSigners := make(map[string]*KeyPool.ThresholdSigner)
+	for cluster := 1; cluster <= *clusters; cluster++ {
+		clusterKey, signers, err := KeyPool.DealThresholdKeys(*threshold, *clusterSize)
+		if err != nil {
+			log.Fatal(err)
+		}
+		clusterKeys[int32(cluster)] = clusterKey
+		for i, signer := range signers {
+			thresholdSigners[addresses[(cluster-1)**clusterSize+i]] = signer
+		}
+	}
+
+	if *configPath != "" {
+		err := WriteThresholdConfig(*configPath, clusterKeys, thresholdSigners)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("wrote %d of %d threshold keys for %d clusters to %s\n", *threshold, *clusterSize, *clusters, *configPath)
+		return
+	}
+	for i, addr := range strings.Split(*clients, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		nodes[addr] = fmt.Sprintf("client_%d", i+1)
+		addresses = append(addresses, addr)
+	}
+
+	signers := make(map[string]KeyPool.Signer)
+	for _, addr := range addresses {
+		signer, err := KeyPool.GenerateSigner(*keyType)
+		if err != nil {
+			log.Fatal(err)
+		}
+		signers[addr] = signer
+	}
+
+	for _, addr := range addresses {
+		nodeDir := filepath.Join(*out, nodes[addr])
+		if err := os.MkdirAll(nodeDir, 0700); err != nil {
+			log.Fatal(err)
+		}
+		for _, peer := range addresses {
+			if err := KeyPool.WritePublicKey(nodeDir, peer, signers[peer].Public(), 1); err != nil {
+				log.Fatal(err)
+			}
+			if thresholdSigner, ok := thresholdSigners[peer]; ok {
+				if err := KeyPool.WriteThresholdPublicKey(nodeDir, peer, thresholdSigner.Public()); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+		if err := KeyPool.WritePrivateKey(nodeDir, addr, signers[addr], 1); err != nil {
+			log.Fatal(err)
+		}
+		for cluster, clusterKey := range clusterKeys {
+			if err := KeyPool.WriteClusterKey(nodeDir, cluster, clusterKey); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if thresholdSigner, ok := thresholdSigners[addr]; ok {
+			if err := KeyPool.WriteThresholdKey(nodeDir, addr, thresholdSigner); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	fmt.Printf("wrote %s keys for %d nodes to %s\n", *keyType, len(addresses), *out)
+}
+
+// WriteThresholdConfig puts the threshold keys into the config.json at path, leaving the signing keys in it
+// as they are
+func WriteThresholdConfig(path string, clusterKeys map[int32]*KeyPool.ThresholdVerifier, signers map[string]*KeyPool.ThresholdSigner) error {
+	jsonConfig, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	conf := &KeyPool.Config{
+		ThresholdKeys:       make(map[string]string),
+		ThresholdPublicKeys: make(map[string]string),
+		ClusterKeys:         make(map[string]string),
+	}
+	if err = json.Unmarshal(jsonConfig, conf); err != nil {
+		return err
+	}
+
+	for addr, signer := range signers {
+		block, err := KeyPool.GetThresholdKeyBlock(addr, signer)
+		if err != nil {
+			return err
+		}
+		conf.ThresholdKeys[addr] = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block))
+
+		block, err = KeyPool.GetThresholdPublicKeyBlock(addr, signer.Public())
+		if err != nil {
+			return err
+		}
+		conf.ThresholdPublicKeys[addr] = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block))
+	}
+	for cluster, clusterKey := range clusterKeys {
+		block, err := KeyPool.GetClusterKeyBlock(cluster, clusterKey)
+		if err != nil {
+			return err
+		}
+		conf.ClusterKeys[strconv.Itoa(int(cluster))] = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block))
+	}
+	jsonConfig, err = json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(jsonConfig, '\n'), 0600)
+}
@@ -0,0 +1,274 @@
+package key_pool
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	KeyTypeRSA     = "rsa"
+	KeyTypeEd25519 = "ed25519"
+	KeyTypeECDSA   = "ecdsa"
+)
+
+// Signer signs messages with the private key of a node, whatever its type
+type Signer interface {
+	Sign(message []byte) ([]byte, error)
+	Public() Verifier
+	KeyType() string
+	MarshalPrivateKey() ([]byte, error)
+}
+
+// Verifier checks signatures against the public key of a node, whatever its type
+type Verifier interface {
+	Verify(message, signature []byte) error
+	KeyType() string
+	MarshalPublicKey() ([]byte, error)
+}
+
+func GenerateSigner(keyType string) (Signer, error) {
+	switch keyType {
+	case KeyTypeRSA:
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return &rsaSigner{privateKey: privateKey}, nil
+	case KeyTypeEd25519:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return &ed25519Signer{privateKey: privateKey}, nil
+	case KeyTypeECDSA:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsaSigner{privateKey: privateKey}, nil
+	}
+	return nil, fmt.Errorf("unknown key type %s", keyType)
+}
+
+// ParsePrivateKey reads a PKCS#1 RSA, SEC 1 EC or PKCS#8 private key
+func ParsePrivateKey(block *pem.Block) (Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return &rsaSigner{privateKey: privateKey}, nil
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsaSigner{privateKey: privateKey}, nil
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return &rsaSigner{privateKey: key}, nil
+	case ed25519.PrivateKey:
+		return &ed25519Signer{privateKey: key}, nil
+	case *ecdsa.PrivateKey:
+		return &ecdsaSigner{privateKey: key}, nil
+	}
+	return nil, errors.New("unsupported private key type")
+}
+
+// ParsePublicKey reads a DER encoded PKIX public key
+func ParsePublicKey(keyBytes []byte) (Verifier, error) {
+	publicKey, err := x509.ParsePKIXPublicKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return &rsaVerifier{publicKey: key}, nil
+	case ed25519.PublicKey:
+		return &ed25519Verifier{publicKey: key}, nil
+	case *ecdsa.PublicKey:
+		return &ecdsaVerifier{publicKey: key}, nil
+	}
+	return nil, errors.New("unsupported public key type")
+}
+
+func verificationError(err error) error {
+	return fmt.Errorf("signature verification failed: %v", err)
+}
+
+type rsaSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+func (s *rsaSigner) Sign(message []byte) ([]byte, error) {
+	hash := sha256.Sum256(message)
+	return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hash[:])
+}
+
+func (s *rsaSigner) Public() Verifier {
+	return &rsaVerifier{publicKey: &s.privateKey.PublicKey}
+}
+
+func (s *rsaSigner) KeyType() string {
+	return KeyTypeRSA
+}
+
+func (s *rsaSigner) MarshalPrivateKey() ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(s.privateKey)
+}
+
+type rsaVerifier struct {
+	publicKey *rsa.PublicKey
+}
+
+func (v *rsaVerifier) Verify(message, signature []byte) error {
+	hash := sha256.Sum256(message)
+	err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, hash[:], signature)
+	if err != nil {
+		return verificationError(err)
+	}
+	return nil
+}
+
+func (v *rsaVerifier) KeyType() string {
+	return KeyTypeRSA
+}
+
+func (v *rsaVerifier) MarshalPublicKey() ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(v.publicKey)
+}
+
+type ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(message []byte) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, message), nil
+}
+
+func (s *ed25519Signer) Public() Verifier {
+	return &ed25519Verifier{publicKey: s.privateKey.Public().(ed25519.PublicKey)}
+}
+
+func (s *ed25519Signer) KeyType() string {
+	return KeyTypeEd25519
+}
+
+func (s *ed25519Signer) MarshalPrivateKey() ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(s.privateKey)
+}
+
+type ed25519Verifier struct {
+	publicKey ed25519.PublicKey
+}
+
+func (v *ed25519Verifier) Verify(message, signature []byte) error {
+	if !ed25519.Verify(v.publicKey, message, signature) {
+		return verificationError(errors.New("ed25519: invalid signature"))
+	}
+	return nil
+}
+
+func (v *ed25519Verifier) KeyType() string {
+	return KeyTypeEd25519
+}
+
+func (v *ed25519Verifier) MarshalPublicKey() ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(v.publicKey)
+}
+
+type ecdsaSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func (s *ecdsaSigner) Sign(message []byte) ([]byte, error) {
+	hash := sha256.Sum256(message)
+	return ecdsa.SignASN1(rand.Reader, s.privateKey, hash[:])
+}
+
+func (s *ecdsaSigner) Public() Verifier {
+	return &ecdsaVerifier{publicKey: &s.privateKey.PublicKey}
+}
+
+func (s *ecdsaSigner) KeyType() string {
+	return KeyTypeECDSA
+}
+
+func (s *ecdsaSigner) MarshalPrivateKey() ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(s.privateKey)
+}
+
+type ecdsaVerifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+func (v *ecdsaVerifier) Verify(message, signature []byte) error {
+	hash := sha256.Sum256(message)
+	if !ecdsa.VerifyASN1(v.publicKey, hash[:], signature) {
+		return verificationError(errors.New("ecdsa: invalid signature"))
+	}
+	return nil
+}
+
+func (v *ecdsaVerifier) KeyType() string {
+	return KeyTypeECDSA
+}
+
+func (v *ecdsaVerifier) MarshalPublicKey() ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(v.publicKey)
+}
+
+// RotatingSigner is the signer of a node that can switch to a new key while other goroutines keep signing
+type RotatingSigner struct {
+	lock   sync.RWMutex
+	signer Signer
+}
+
+func NewRotatingSigner(signer Signer) *RotatingSigner {
+	return &RotatingSigner{signer: signer}
+}
+
+func (s *RotatingSigner) Sign(message []byte) ([]byte, error) {
+	return s.Current().Sign(message)
+}
+
+func (s *RotatingSigner) Public() Verifier {
+	return s.Current().Public()
+}
+
+func (s *RotatingSigner) KeyType() string {
+	return s.Current().KeyType()
+}
+
+func (s *RotatingSigner) MarshalPrivateKey() ([]byte, error) {
+	return s.Current().MarshalPrivateKey()
+}
+
+func (s *RotatingSigner) Current() Signer {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.signer
+}
+
+func (s *RotatingSigner) Rotate(signer Signer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.signer = signer
+}
@@ -0,0 +1,313 @@
+package key_pool
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	GG "github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/cloudflare/circl/sign/bls"
+)
+
+// every cluster has a bls key that no replica holds. a dealer, keygen, splits it with a random polynomial of
+// degree threshold-1 and hands replica i of the cluster the share p(i); any threshold signature shares on the
+// same message combine into the signature of the cluster key p(0), which is checked like a plain bls
+// signature against the public key of the cluster. keys live in G2 so the signatures are 48 byte G1 points.
+// threshold keys are not rotated along with the signing key of a replica
+
+const (
+	ThresholdKeyExtension       = ".tkey"
+	ThresholdPublicKeyExtension = ".tpub"
+	ClusterKeyExtension         = ".cpub"
+	IndexHeader                 = "Index"
+	ClusterHeader               = "Cluster"
+)
+
+// ThresholdSigner signs shares with the key share of replica Index of its cluster
+type ThresholdSigner struct {
+	Index      int32
+	privateKey *bls.PrivateKey[bls.KeyG2SigG1]
+}
+
+func (s *ThresholdSigner) SignShare(message []byte) []byte {
+	return bls.Sign(s.privateKey, message)
+}
+
+func (s *ThresholdSigner) Public() *ThresholdVerifier {
+	return &ThresholdVerifier{Index: s.Index, publicKey: s.privateKey.PublicKey()}
+}
+
+func (s *ThresholdSigner) MarshalPrivateKey() ([]byte, error) {
+	return s.privateKey.MarshalBinary()
+}
+
+// ThresholdVerifier checks signature shares against the public key share of replica Index, or combined
+// signatures against the public key of a cluster, whose Index is 0
+type ThresholdVerifier struct {
+	Index     int32
+	publicKey *bls.PublicKey[bls.KeyG2SigG1]
+}
+
+func (v *ThresholdVerifier) Verify(message, signature []byte) error {
+	if !bls.Verify(v.publicKey, message, signature) {
+		return verificationError(errors.New("bls: invalid signature"))
+	}
+	return nil
+}
+
+func (v *ThresholdVerifier) MarshalPublicKey() ([]byte, error) {
+	return v.publicKey.MarshalBinary()
+}
+
+type ThresholdKeyPool struct {
+	Signer      *ThresholdSigner
+	PublicKeys  map[string]*ThresholdVerifier
+	ClusterKeys map[int32]*ThresholdVerifier
+}
+
+// NewThresholdKeyPool reads the key share of addr, the public key shares of every replica and the public
+// keys of every cluster
+func NewThresholdKeyPool(keyDir, addr string) (*ThresholdKeyPool, error) {
+	pool := &ThresholdKeyPool{
+		PublicKeys:  make(map[string]*ThresholdVerifier),
+		ClusterKeys: make(map[int32]*ThresholdVerifier),
+	}
+
+	blocks, err := ReadKeyBlocks(keyDir, ThresholdKeyExtension, AddressHeader, func(conf *Config) map[string]string { return conf.ThresholdKeys })
+	if err != nil {
+		return nil, err
+	}
+	block, ok := blocks[addr]
+	if !ok {
+		return nil, fmt.Errorf("threshold key not found for address %s", addr)
+	}
+	pool.Signer, err = ParseThresholdKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse threshold key for %s: %v", addr, err)
+	}
+
+	blocks, err = ReadKeyBlocks(keyDir, ThresholdPublicKeyExtension, AddressHeader, func(conf *Config) map[string]string { return conf.ThresholdPublicKeys })
+	if err != nil {
+		return nil, err
+	}
+	for addr, block := range blocks {
+		pool.PublicKeys[addr], err = ParseThresholdPublicKey(block, IndexHeader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse threshold public key for %s: %v", addr, err)
+		}
+	}
+
+	blocks, err = ReadKeyBlocks(keyDir, ClusterKeyExtension, ClusterHeader, func(conf *Config) map[string]string { return conf.ClusterKeys })
+	if err != nil {
+		return nil, err
+	}
+	for cluster, block := range blocks {
+		clusterNo, err := strconv.Atoi(cluster)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster %s: %v", cluster, err)
+		}
+		pool.ClusterKeys[int32(clusterNo)], err = ParseThresholdPublicKey(block, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of cluster %s: %v", cluster, err)
+		}
+	}
+
+	return pool, nil
+}
+
+func (tkp *ThresholdKeyPool) GetPublicKey(addr string) (*ThresholdVerifier, error) {
+	publicKey, exists := tkp.PublicKeys[addr]
+	if !exists {
+		return nil, fmt.Errorf("threshold public key not found for address %s", addr)
+	}
+	return publicKey, nil
+}
+
+func (tkp *ThresholdKeyPool) GetClusterKey(cluster int32) (*ThresholdVerifier, error) {
+	publicKey, exists := tkp.ClusterKeys[cluster]
+	if !exists {
+		return nil, fmt.Errorf("public key not found for cluster %d", cluster)
+	}
+	return publicKey, nil
+}
+
+func ParseThresholdKey(block *pem.Block) (*ThresholdSigner, error) {
+	index, err := strconv.Atoi(block.Headers[IndexHeader])
+	if err != nil {
+		return nil, fmt.Errorf("invalid share index: %v", err)
+	}
+	privateKey := &bls.PrivateKey[bls.KeyG2SigG1]{}
+	err = privateKey.UnmarshalBinary(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &ThresholdSigner{Index: int32(index), privateKey: privateKey}, nil
+}
+
+// ParseThresholdPublicKey reads a public key share, or the public key of a cluster when indexHeader is empty
+func ParseThresholdPublicKey(block *pem.Block, indexHeader string) (*ThresholdVerifier, error) {
+	verifier := &ThresholdVerifier{publicKey: &bls.PublicKey[bls.KeyG2SigG1]{}}
+	if indexHeader != "" {
+		index, err := strconv.Atoi(block.Headers[indexHeader])
+		if err != nil {
+			return nil, fmt.Errorf("invalid share index: %v", err)
+		}
+		verifier.Index = int32(index)
+	}
+	err := verifier.publicKey.UnmarshalBinary(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+// DealThresholdKeys splits a fresh cluster key into shares for replicas 1 to shares, threshold of which
+// sign for the cluster
+func DealThresholdKeys(threshold, shares int) (*ThresholdVerifier, []*ThresholdSigner, error) {
+	if threshold < 1 || threshold > shares {
+		return nil, nil, fmt.Errorf("invalid threshold %d of %d", threshold, shares)
+	}
+
+	coefficients := make([]GG.Scalar, threshold)
+	for i := range coefficients {
+		err := coefficients[i].Random(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	clusterKey, err := NewThresholdPrivateKey(&coefficients[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var signers []*ThresholdSigner
+	for index := 1; index <= shares; index++ {
+		var x, share GG.Scalar
+		x.SetUint64(uint64(index))
+		for i := threshold - 1; i >= 0; i-- {
+			share.Mul(&share, &x)
+			share.Add(&share, &coefficients[i])
+		}
+		privateKey, err := NewThresholdPrivateKey(&share)
+		if err != nil {
+			return nil, nil, err
+		}
+		signers = append(signers, &ThresholdSigner{Index: int32(index), privateKey: privateKey})
+	}
+
+	return &ThresholdVerifier{publicKey: clusterKey.PublicKey()}, signers, nil
+}
+
+func NewThresholdPrivateKey(scalar *GG.Scalar) (*bls.PrivateKey[bls.KeyG2SigG1], error) {
+	scalarBytes, err := scalar.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	privateKey := &bls.PrivateKey[bls.KeyG2SigG1]{}
+	err = privateKey.UnmarshalBinary(scalarBytes)
+	if err != nil {
+		return nil, err
+	}
+	return privateKey, nil
+}
+
+// CombineShares interpolates the signature shares, by the index of their signer, at 0. the result is only
+// the signature of the cluster if there are at least threshold shares and all of them are valid
+func CombineShares(shares map[int32][]byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no signature shares")
+	}
+
+	var signature GG.G1
+	signature.SetIdentity()
+	for index, share := range shares {
+		var point GG.G1
+		err := point.SetBytes(share)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature share of %d: %v", index, err)
+		}
+
+		// lagrange coefficient of index at 0: the product of j/(j-index) over the other indices j
+		var coefficient, x, xj, difference GG.Scalar
+		coefficient.SetOne()
+		x.SetUint64(uint64(index))
+		for j := range shares {
+			if j == index {
+				continue
+			}
+			xj.SetUint64(uint64(j))
+			difference.Sub(&xj, &x)
+			difference.Inv(&difference)
+			coefficient.Mul(&coefficient, &xj)
+			coefficient.Mul(&coefficient, &difference)
+		}
+
+		point.ScalarMult(&coefficient, &point)
+		signature.Add(&signature, &point)
+	}
+	return signature.BytesCompressed(), nil
+}
+
+func GetThresholdKeyBlock(addr string, signer *ThresholdSigner) (*pem.Block, error) {
+	keyBytes, err := signer.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{
+		Type:    "BLS KEY SHARE",
+		Headers: map[string]string{AddressHeader: addr, IndexHeader: strconv.Itoa(int(signer.Index))},
+		Bytes:   keyBytes,
+	}, nil
+}
+
+func GetThresholdPublicKeyBlock(addr string, publicKey *ThresholdVerifier) (*pem.Block, error) {
+	keyBytes, err := publicKey.MarshalPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{
+		Type:    "BLS PUBLIC KEY SHARE",
+		Headers: map[string]string{AddressHeader: addr, IndexHeader: strconv.Itoa(int(publicKey.Index))},
+		Bytes:   keyBytes,
+	}, nil
+}
+
+func GetClusterKeyBlock(cluster int32, publicKey *ThresholdVerifier) (*pem.Block, error) {
+	keyBytes, err := publicKey.MarshalPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &pem.Block{
+		Type:    "BLS PUBLIC KEY",
+		Headers: map[string]string{ClusterHeader: strconv.Itoa(int(cluster))},
+		Bytes:   keyBytes,
+	}, nil
+}
+
+func WriteThresholdKey(keyDir, addr string, signer *ThresholdSigner) error {
+	block, err := GetThresholdKeyBlock(addr, signer)
+	if err != nil {
+		return err
+	}
+	return WriteKeyFile(filepath.Join(keyDir, GetKeyFileName(addr, ThresholdKeyExtension)), block, 0600)
+}
+
+func WriteThresholdPublicKey(keyDir, addr string, publicKey *ThresholdVerifier) error {
+	block, err := GetThresholdPublicKeyBlock(addr, publicKey)
+	if err != nil {
+		return err
+	}
+	return WriteKeyFile(filepath.Join(keyDir, GetKeyFileName(addr, ThresholdPublicKeyExtension)), block, 0644)
+}
+
+func WriteClusterKey(keyDir string, cluster int32, publicKey *ThresholdVerifier) error {
+	block, err := GetClusterKeyBlock(cluster, publicKey)
+	if err != nil {
+		return err
+	}
+	return WriteKeyFile(filepath.Join(keyDir, fmt.Sprintf("cluster_%d%s", cluster, ClusterKeyExtension)), block, 0644)
+}
@@ -0,0 +1,126 @@
+package key_pool
+
+import (
+	"testing"
+)
+
+var thresholdMessage = []byte("cluster 1 votes commit on txn-1")
+
+func dealShares(t *testing.T, signers []*ThresholdSigner, message []byte) map[int32][]byte {
+	t.Helper()
+	shares := make(map[int32][]byte)
+	for _, signer := range signers {
+		share := signer.SignShare(message)
+		if err := signer.Public().Verify(message, share); err != nil {
+			t.Fatalf("share of %d does not verify against its own key: %v", signer.Index, err)
+		}
+		shares[signer.Index] = share
+	}
+	return shares
+}
+
+func TestCombineAnyThresholdShares(t *testing.T) {
+	clusterKey, signers, err := DealThresholdKeys(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for left := range signers {
+		var quorum []*ThresholdSigner
+		for i, signer := range signers {
+			if i != left {
+				quorum = append(quorum, signer)
+			}
+		}
+		signature, err := CombineShares(dealShares(t, quorum, thresholdMessage))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = clusterKey.Verify(thresholdMessage, signature); err != nil {
+			t.Fatalf("shares without replica %d did not combine into the cluster signature: %v", left+1, err)
+		}
+	}
+
+	signature, err := CombineShares(dealShares(t, signers, thresholdMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = clusterKey.Verify(thresholdMessage, signature); err != nil {
+		t.Fatalf("all shares did not combine into the cluster signature: %v", err)
+	}
+}
+
+func TestCombineRejectsTooFewShares(t *testing.T) {
+	clusterKey, signers, err := DealThresholdKeys(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := CombineShares(dealShares(t, signers[:2], thresholdMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterKey.Verify(thresholdMessage, signature) == nil {
+		t.Fatal("two shares combined into a valid cluster signature")
+	}
+}
+
+func TestCombineRejectsForgedShare(t *testing.T) {
+	clusterKey, signers, err := DealThresholdKeys(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a replica passes its own share off as the share of another one
+	shares := dealShares(t, signers[:2], thresholdMessage)
+	shares[3] = shares[1]
+	if signers[2].Public().Verify(thresholdMessage, shares[3]) == nil {
+		t.Fatal("forged share verified against the key share of replica 3")
+	}
+	signature, err := CombineShares(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterKey.Verify(thresholdMessage, signature) == nil {
+		t.Fatal("forged share combined into a valid cluster signature")
+	}
+}
+
+func TestCombinedSignatureIsBoundToMessage(t *testing.T) {
+	clusterKey, signers, err := DealThresholdKeys(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := CombineShares(dealShares(t, signers[:3], thresholdMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterKey.Verify([]byte("cluster 1 votes commit on txn-2"), signature) == nil {
+		t.Fatal("cluster signature verified for another message")
+	}
+
+	_, otherSigners, err := DealThresholdKeys(3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err = CombineShares(dealShares(t, otherSigners[:3], thresholdMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clusterKey.Verify(thresholdMessage, signature) == nil {
+		t.Fatal("shares of another cluster combined into a valid signature of this one")
+	}
+}
+
+func TestDealThresholdKeysRejectsInvalidThreshold(t *testing.T) {
+	if _, _, err := DealThresholdKeys(0, 4); err == nil {
+		t.Fatal("threshold 0 was accepted")
+	}
+	if _, _, err := DealThresholdKeys(5, 4); err == nil {
+		t.Fatal("threshold above the number of shares was accepted")
+	}
+	if _, err := CombineShares(nil); err == nil {
+		t.Fatal("no shares combined into a signature")
+	}
+}
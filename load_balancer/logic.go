@@ -16,6 +16,19 @@ func PrintBalance(client common.Byz2PCClient, user int32) {
 	fmt.Printf("Balance of user %v: %v\n", user, resp.Balance)
 }
 
+func ReadBalance(client common.Byz2PCClient, user int32) {
+	resp, err := client.ReadBalance(context.Background(), &common.ReadBalanceRequest{User: user})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	if resp.IsOrdered {
+		fmt.Printf("Balance of user %v: %v (ordered read)\n", user, resp.Balance)
+		return
+	}
+	fmt.Printf("Balance of user %v: %v (as of sequence %v)\n", user, resp.Balance, resp.SeqNo)
+}
+
 func PrintDB(client common.Byz2PCClient, server int32) {
 	resp, err := client.PrintDB(context.Background(), &common.PrintDBRequest{Server: server})
 	if err != nil {
@@ -29,6 +42,70 @@ func PrintDB(client common.Byz2PCClient, server int32) {
 	}
 }
 
+func PrintLocks(client common.Byz2PCClient, server int32) {
+	resp, err := client.PrintLocks(context.Background(), &common.PrintLocksRequest{Server: server})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("\nLocks of server %v: \n", server)
+	for _, lock := range resp.Locks {
+		fmt.Printf("user %v held by %v, waiting: %v\n", lock.User, lock.Holder, lock.Waiters)
+	}
+}
+
+func PrintMisbehaviorEvidence(client common.Byz2PCClient, server int32) {
+	resp, err := client.GetMisbehaviorEvidence(context.Background(), &common.EvidenceRequest{Server: server})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("\nBlacklisted by server %v: %v\n", server, resp.Blacklisted)
+	for _, evidence := range resp.Evidence {
+		fmt.Printf("server %v signed conflicting digests for view %v seq %v, reported by server %v\n",
+			evidence.Accused, evidence.ViewNumber, evidence.SequenceNumber, evidence.Reporter)
+	}
+}
+
+func RotateKey(client common.Byz2PCClient, server int32, keyType string) {
+	resp, err := client.RotateKey(context.Background(), &common.RotateKeyRequest{Server: server, KeyType: keyType})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("\nServer %v rotated to %v key version %v\n", server, resp.KeyType, resp.Version)
+}
+
+func PrintTxnStatus(client common.Byz2PCClient, txnID string) {
+	resp, err := client.GetTxnStatus(context.Background(), &common.TxnStatusRequest{TxnID: txnID})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	if len(resp.Clusters) == 0 {
+		fmt.Printf("\ntxn %v is not known to any server\n", txnID)
+		return
+	}
+
+	fmt.Printf("\nStatus of txn %v: \n", txnID)
+	for _, cluster := range resp.Clusters {
+		quorum := "quorum"
+		if !cluster.HasQuorum {
+			quorum = "no quorum"
+		}
+		fmt.Printf("cluster %v: %v seq %v view %v (%v), disagreeing: %v\n", cluster.Cluster, cluster.Status,
+			cluster.SeqNo, cluster.ViewNo, quorum, cluster.DisagreeingReplicas)
+		for _, replica := range cluster.Replicas {
+			fmt.Printf("  server %v: %v seq %v view %v %v\n", replica.Server, replica.Status, replica.SeqNo,
+				replica.ViewNo, replica.Error)
+		}
+	}
+}
+
 func Performance(client common.Byz2PCClient) {
 	resp, err := client.Performance(context.Background(), nil)
 	if err != nil {
@@ -34,6 +34,7 @@ func loadCSV(filename string) error {
 	var liveServers []string
 	var contactServers []string
 	var byzantineServers []string
+	var faults map[string]*common.FaultConfig
 
 	for {
 		setRow, err := reader.Read()
@@ -57,8 +58,15 @@ func loadCSV(filename string) error {
 			}
 
 			byzantineServers = strings.Split(strings.Trim(setRow[4], "[] "), ",")
+			faults = make(map[string]*common.FaultConfig)
 			for i := range byzantineServers {
 				byzantineServers[i] = strings.TrimSpace(byzantineServers[i])
+				// a byzantine server can be given behaviors to inject, eg. S1:equivocate+delay=500
+				server, behaviors, found := strings.Cut(byzantineServers[i], ":")
+				if found {
+					byzantineServers[i] = server
+					faults[server] = parseFaults(behaviors)
+				}
 			}
 
 			if _, exists := sets[currentSetNo]; !exists {
@@ -68,6 +76,7 @@ func loadCSV(filename string) error {
 					LiveServers:      liveServers,
 					ContactServers:   contactServers,
 					ByzantineServers: byzantineServers,
+					Faults:           faults,
 				}
 			}
 		}
@@ -76,12 +85,20 @@ func loadCSV(filename string) error {
 		for _, txn := range txnStrings {
 			txn = strings.Trim(txn, "() ")
 			parts := strings.Split(txn, ",")
-			if len(parts) != 3 {
+			// (sender, receiver, amount) or a multi-shard txn (sender, receiver1, amount1, receiver2, amount2, ...)
+			if len(parts) < 3 || len(parts)%2 == 0 {
 				continue
 			}
 			sender, _ := strconv.Atoi(strings.TrimSpace(parts[0]))
-			receiver, _ := strconv.Atoi(strings.TrimSpace(parts[1]))
-			amount, _ := strconv.ParseFloat(strings.TrimSpace(parts[2]), 32)
+
+			var legs []*common.TxnLeg
+			var total float32
+			for i := 1; i < len(parts); i += 2 {
+				receiver, _ := strconv.Atoi(strings.TrimSpace(parts[i]))
+				amount, _ := strconv.ParseFloat(strings.TrimSpace(parts[i+1]), 32)
+				legs = append(legs, &common.TxnLeg{Receiver: int32(receiver), Amount: float32(amount)})
+				total += float32(amount)
+			}
 
 			if sets[currentSetNo] == nil {
 				sets[currentSetNo] = &common.TxnSet{
@@ -90,17 +107,41 @@ func loadCSV(filename string) error {
 				}
 			}
 
-			sets[currentSetNo].Txns = append(sets[currentSetNo].Txns, &common.TxnRequest{
+			txnReq := &common.TxnRequest{
 				Sender:   int32(sender),
-				Receiver: int32(receiver),
-				Amount:   float32(amount),
-			})
+				Receiver: legs[0].Receiver,
+				Amount:   total,
+			}
+			if len(legs) > 1 {
+				txnReq.Legs = legs
+			}
+			sets[currentSetNo].Txns = append(sets[currentSetNo].Txns, txnReq)
 		}
 	}
 
 	return nil
 }
 
+// parseFaults reads behaviors separated by '+', delay and drop can carry the delay in ms and the drop rate,
+// eg. equivocate+delay=500+drop=0.3
+
+func parseFaults(behaviors string) *common.FaultConfig {
+	fault := &common.FaultConfig{}
+	for _, behavior := range strings.Split(behaviors, "+") {
+		name, value, _ := strings.Cut(strings.TrimSpace(behavior), "=")
+		switch name {
+		case "delay":
+			delay, _ := strconv.Atoi(value)
+			fault.DelayMs = int32(delay)
+		case "drop":
+			dropRate, _ := strconv.ParseFloat(value, 32)
+			fault.DropRate = float32(dropRate)
+		}
+		fault.Behaviors = append(fault.Behaviors, name)
+	}
+	return fault
+}
+
 func main() {
 	client := InitiateClient()
 
@@ -122,7 +163,12 @@ func main() {
 		for {
 			fmt.Println("\nType 'next' to process the next set, " +
 				"'balance' to get balance, " +
+				"'read' to read a balance linearizably, " +
 				"'db' to print database, " +
+				"'locks' to print locks, " +
+				"'status' to get the status of a txn, " +
+				"'evidence' to print misbehavior evidence, " +
+				"'rotate' to rotate the key of a server, " +
 				" 'perf' to print performance" +
 				" or 'bench' to print benchmark metrics")
 			scanner.Scan()
@@ -137,6 +183,34 @@ func main() {
 				serverNo, _ := strconv.Atoi(serverNoString)
 				PrintDB(client, int32(serverNo))
 
+			} else if input == "locks" {
+				fmt.Println("Which server? (eg. '1' without quotes)")
+				scanner.Scan()
+				serverNoString := scanner.Text()
+				serverNo, _ := strconv.Atoi(serverNoString)
+				PrintLocks(client, int32(serverNo))
+
+			} else if input == "evidence" {
+				fmt.Println("Which server? (eg. '1' without quotes)")
+				scanner.Scan()
+				serverNoString := scanner.Text()
+				serverNo, _ := strconv.Atoi(serverNoString)
+				PrintMisbehaviorEvidence(client, int32(serverNo))
+
+			} else if input == "rotate" {
+				fmt.Println("Which server? (eg. '1' without quotes)")
+				scanner.Scan()
+				serverNoString := scanner.Text()
+				serverNo, _ := strconv.Atoi(serverNoString)
+				fmt.Println("Which key type? ('rsa', 'ed25519' or 'ecdsa', empty to keep the current one)")
+				scanner.Scan()
+				RotateKey(client, int32(serverNo), strings.TrimSpace(scanner.Text()))
+
+			} else if input == "status" {
+				fmt.Println("Which txn? (eg. the txn id without quotes)")
+				scanner.Scan()
+				PrintTxnStatus(client, strings.TrimSpace(scanner.Text()))
+
 			} else if input == "balance" {
 				fmt.Println("Which user? (eg. '100' without quotes)")
 				scanner.Scan()
@@ -144,6 +218,13 @@ func main() {
 				user, _ := strconv.Atoi(userString)
 				PrintBalance(client, int32(user))
 
+			} else if input == "read" {
+				fmt.Println("Which user? (eg. '100' without quotes)")
+				scanner.Scan()
+				userString := scanner.Text()
+				user, _ := strconv.Atoi(userString)
+				ReadBalance(client, int32(user))
+
 			} else if input == "perf" {
 				Performance(client)
 			} else if input == "bench" {
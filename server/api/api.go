@@ -19,6 +19,10 @@ func (s *Server) UpdateServerState(ctx context.Context, req *common.UpdateServer
 	fmt.Printf("isAlive set to %t\n", req.IsAlive)
 	s.Config.IsAlive = req.IsAlive
 	s.Config.IsByzantine = req.IsByzantine
+	s.Config.Faults.SetFaults(req.Faults)
+	if len(req.Faults.GetBehaviors()) > 0 {
+		fmt.Printf("injecting faults %v\n", req.Faults.Behaviors)
+	}
 	//s.Config.ClusterNumber = req.ClusterNumber
 	//s.Config.DataItemsPerShard = req.DataItemsPerShard
 
@@ -75,6 +79,42 @@ func (s *Server) Sync(ctx context.Context, req *common.PBFTRequestResponse) (*co
 	return resp, nil
 }
 
+func (s *Server) TwoPCDecision(ctx context.Context, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
+	resp, err := logic.ReceiveTwoPCDecisionRequest(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("TwoPCDecisionError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) ViewChange(ctx context.Context, req *common.PBFTRequestResponse) (*emptypb.Empty, error) {
+	err := logic.ReceiveViewChange(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("ViewChangeError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Server) NewView(ctx context.Context, req *common.PBFTRequestResponse) (*emptypb.Empty, error) {
+	err := logic.ReceiveNewView(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("NewViewError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Server) Checkpoint(ctx context.Context, req *common.PBFTRequestResponse) (*emptypb.Empty, error) {
+	err := logic.ReceiveCheckpoint(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("CheckpointError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
 func (s *Server) TwoPCPrepareRequest(ctx context.Context, req *common.PBFTRequestResponse) (*emptypb.Empty, error) {
 	err := logic.ReceiveTwoPCPrepareRequest(ctx, s.Config, req)
 	if err != nil {
@@ -120,6 +160,15 @@ func (s *Server) TwoPCAbort(ctx context.Context, req *common.TxnRequest) (*empty
 	return nil, nil
 }
 
+func (s *Server) AbortVoteShare(ctx context.Context, req *common.PBFTRequestResponse) (*emptypb.Empty, error) {
+	err := logic.ReceiveAbortVoteShare(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("AbortVoteShareError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
 func (s *Server) PrintBalance(ctx context.Context, req *common.PrintBalanceRequest) (*common.PrintBalanceResponse, error) {
 	fmt.Printf("received PrintBalance request\n")
 	resp, err := logic.PrintBalance(ctx, s.Config, req)
@@ -129,6 +178,16 @@ func (s *Server) PrintBalance(ctx context.Context, req *common.PrintBalanceReque
 	return resp, nil
 }
 
+func (s *Server) ReadBalance(ctx context.Context, req *common.ReadBalanceRequest) (*common.ReadBalanceResponse, error) {
+	fmt.Printf("received ReadBalance request for user %d\n", req.User)
+	resp, err := logic.ReadBalance(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("ReadBalanceError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *Server) PrintDB(ctx context.Context, req *common.PrintDBRequest) (*common.PrintDBResponse, error) {
 	fmt.Printf("received PrintDB request\n")
 	resp, err := logic.PrintDB(ctx, s.Config, req)
@@ -137,3 +196,80 @@ func (s *Server) PrintDB(ctx context.Context, req *common.PrintDBRequest) (*comm
 	}
 	return resp, nil
 }
+
+func (s *Server) PrintLocks(ctx context.Context, req *common.PrintLocksRequest) (*common.PrintLocksResponse, error) {
+	fmt.Printf("received PrintLocks request\n")
+	return logic.PrintLocks(ctx, s.Config, req), nil
+}
+
+func (s *Server) ShareStatement(ctx context.Context, req *common.SignedStatement) (*emptypb.Empty, error) {
+	err := logic.ReceiveStatement(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("ShareStatementError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Server) ExchangeSessionKey(ctx context.Context, req *common.SessionKey) (*common.SessionKey, error) {
+	resp, err := logic.ReceiveSessionKey(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("ExchangeSessionKeyError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) ReportMisbehavior(ctx context.Context, req *common.MisbehaviorEvidence) (*emptypb.Empty, error) {
+	fmt.Printf("received misbehavior report against server %d from server %d\n", req.Accused, req.Reporter)
+	err := logic.ReceiveMisbehaviorReport(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("ReportMisbehaviorError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Server) GetMisbehaviorEvidence(ctx context.Context, req *common.EvidenceRequest) (*common.EvidenceResponse, error) {
+	fmt.Printf("received GetMisbehaviorEvidence request\n")
+	return logic.GetMisbehaviorEvidence(ctx, s.Config, req), nil
+}
+
+func (s *Server) RotateKey(ctx context.Context, req *common.RotateKeyRequest) (*common.KeyAnnouncement, error) {
+	fmt.Printf("received RotateKey request for key type %q\n", req.KeyType)
+	resp, err := logic.RotateKey(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("RotateKeyError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) AnnounceKey(ctx context.Context, req *common.KeyAnnouncement) (*emptypb.Empty, error) {
+	fmt.Printf("received key announcement version %d from %s\n", req.Version, req.Address)
+	err := logic.ReceiveKeyAnnouncement(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("AnnounceKeyError: %v\n", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *Server) GetKeyAnnouncements(ctx context.Context, req *common.KeyAnnouncementsRequest) (*common.KeyAnnouncementsResponse, error) {
+	resp, err := logic.GetKeyAnnouncements(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("GetKeyAnnouncementsError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) GetTxnStatus(ctx context.Context, req *common.TxnStatusRequest) (*common.TxnStatusResponse, error) {
+	fmt.Printf("received GetTxnStatus request for txn %s\n", req.TxnID)
+	resp, err := logic.GetTxnStatus(ctx, s.Config, req)
+	if err != nil {
+		fmt.Printf("GetTxnStatusError: %v\n", err)
+		return nil, err
+	}
+	return resp, nil
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"crypto/rsa"
 	"database/sql"
 	"encoding/json"
 	"flag"
@@ -14,9 +13,24 @@ import (
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+	"GolandProjects/2pcbyz-gautamsardana/server/lockmanager"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/filestore"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/memstore"
 	serverPool "GolandProjects/2pcbyz-gautamsardana/server_pool"
 )
 
+const (
+	StorageMySQL  = "mysql"
+	StorageMemory = "memory"
+	StorageFile   = "file"
+)
+
+const (
+	AuthSignature = "signature"
+	AuthMAC       = "mac"
+)
+
 const configPath = "/Users/gautamsardana/go/src/GolandProjects/2pcbyz-gautamsardana/server/config/config.json"
 
 type Config struct {
@@ -26,43 +40,82 @@ type Config struct {
 	ServerTotal         int32  `json:"server_total"`
 	Majority            int32  `json:"majority"`
 	DBDSN               string `json:"db_dsn"`
-	DataStore           *sql.DB
+	StorageBackend      string `json:"storage_backend"`
+	StorageDir          string `json:"storage_dir"`
+	SnapshotInterval    int64  `json:"snapshot_interval"`
+	DataStore           datastore.Store
 	ServerAddresses     []string `json:"server_addresses"`
 	Pool                *serverPool.ServerPool
 	ClusterSize         int32 `json:"cluster_size"`
 	ClusterNumber       int32
 	MapClusterToServers map[int32][]int32
-	DataItemsPerShard   int32 `json:"data_items_per_shard"`
+	DataItemsPerShard   int32  `json:"data_items_per_shard"`
+	ViewChangeTimeout   int32  `json:"view_change_timeout_ms"`
+	CheckpointInterval  int32  `json:"checkpoint_interval"`
+	WatermarkWindow     int32  `json:"watermark_window"`
+	BatchSize           int32  `json:"batch_size"`
+	BatchDelay          int32  `json:"batch_delay_ms"`
+	PipelineWindow      int32  `json:"pipeline_window"`
+	RPCTimeout          int32  `json:"rpc_timeout_ms"`
+	LockWaitTimeout     int32  `json:"lock_wait_timeout_ms"`
+	AuthMode            string `json:"auth_mode"`
+	KeyDir              string `json:"key_dir"`
 	IsAlive             bool
 	IsByzantine         bool
+	Faults              *FaultConfig
 
 	PendingTransactions      map[int32]*common.TxnRequest
 	PendingTransactionsMutex sync.Mutex
 	ExecuteSignal            chan struct{}
+	InstanceSlots            chan struct{}
+
+	PublicKeys    *KeyPool.KeyPool
+	PrivateKeys   *KeyPool.KeyPool
+	PrivateKey    *KeyPool.RotatingSigner
+	SessionKeys   *SessionKeyConfig
+	ThresholdKeys *KeyPool.ThresholdKeyPool
 
-	PublicKeys *KeyPool.KeyPool
-	PrivateKey *rsa.PrivateKey
+	PBFT        *PBFTConfig
+	Misbehavior *MisbehaviorConfig
 
-	PBFT *PBFTConfig
+	TwoPCLock   sync.Mutex
+	LockManager *lockmanager.LockManager
+	TwoPCTimer  map[string]map[int32]*time.Timer
+	TwoPCChan   map[string]chan *common.PBFTRequestResponse
 
-	TwoPCLock  sync.Mutex
-	UserLocks  []sync.Mutex
-	TwoPCTimer map[string]*time.Timer
-	TwoPCChan  map[string]chan *common.PBFTRequestResponse
+	ViewChangeLock    sync.Mutex
+	RequestTimers     map[string]*time.Timer
+	ForwardedRequests map[string]*common.TxnRequest
+	ViewChangeTimer   *time.Timer
+
+	StateTransferLock sync.Mutex
+	CheckpointLock    sync.Mutex
+
+	BatchLock    sync.Mutex
+	PendingBatch []*common.TxnRequest
+	BatchTimer   *time.Timer
 }
 
 func InitiateConfig(conf *Config) {
 	InitiateServerPool(conf)
 	InitiatePublicKeys(conf)
 	InitiatePrivateKey(conf)
+	InitiateSessionKeys(conf)
+	InitiateThresholdKeys(conf)
 	conf.ClusterNumber = (conf.ServerNumber-1)/conf.ClusterSize + 1
 	conf.MapClusterToServers = map[int32][]int32{1: {1, 2, 3, 4}, 2: {5, 6, 7, 8}, 3: {9, 10, 11, 12}}
-	conf.PBFT = &PBFTConfig{ViewNumber: 1, NextSequenceNumber: 1}
+	conf.PBFT = &PBFTConfig{ViewNumber: 1, NextSequenceNumber: 1, HighWatermark: conf.WatermarkWindow}
+	conf.Misbehavior = NewMisbehaviorConfig()
+	conf.Faults = &FaultConfig{}
+	conf.Faults.SetFaults(nil)
 	conf.PendingTransactions = make(map[int32]*common.TxnRequest)
 	conf.ExecuteSignal = make(chan struct{}, 1000)
-	conf.TwoPCTimer = make(map[string]*time.Timer)
+	conf.InstanceSlots = make(chan struct{}, max(conf.PipelineWindow, 1))
+	conf.TwoPCTimer = make(map[string]map[int32]*time.Timer)
 	conf.TwoPCChan = make(map[string]chan *common.PBFTRequestResponse)
-	conf.UserLocks = make([]sync.Mutex, conf.DataItemsPerShard)
+	conf.LockManager = lockmanager.NewLockManager(time.Duration(conf.LockWaitTimeout) * time.Millisecond)
+	conf.RequestTimers = make(map[string]*time.Timer)
+	conf.ForwardedRequests = make(map[string]*common.TxnRequest)
 }
 
 func InitiateServerPool(conf *Config) {
@@ -74,7 +127,7 @@ func InitiateServerPool(conf *Config) {
 }
 
 func InitiatePublicKeys(conf *Config) {
-	pool, err := KeyPool.NewPublicKeyPool()
+	pool, err := KeyPool.NewPublicKeyPool(conf.KeyDir)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -82,17 +135,35 @@ func InitiatePublicKeys(conf *Config) {
 }
 
 func InitiatePrivateKey(conf *Config) {
-	pool, err := KeyPool.NewPrivateKeyPool()
+	pool, err := KeyPool.NewPrivateKeyPool(conf.KeyDir)
 	if err != nil {
-		fmt.Println(err)
+		log.Fatal(err)
 	}
+	conf.PrivateKeys = pool
 
 	serverAddr := MapServerNumberToAddress[conf.ServerNumber]
 
-	conf.PrivateKey, err = pool.GetPrivateKey(serverAddr)
+	privateKey, err := pool.GetPrivateKey(serverAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.PrivateKey = KeyPool.NewRotatingSigner(privateKey)
+}
+
+func InitiateSessionKeys(conf *Config) {
+	sessionKeys, err := NewSessionKeyConfig(conf.PrivateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.SessionKeys = sessionKeys
+}
+
+func InitiateThresholdKeys(conf *Config) {
+	pool, err := KeyPool.NewThresholdKeyPool(conf.KeyDir, MapServerNumberToAddress[conf.ServerNumber])
 	if err != nil {
 		log.Fatal(err)
 	}
+	conf.ThresholdKeys = pool
 }
 
 func GetConfig() *Config {
@@ -111,15 +182,64 @@ func GetConfig() *Config {
 	conf.Port = fmt.Sprintf("%d", int(conf.BasePort)+*serverNumber)
 
 	conf.DBDSN = fmt.Sprintf(conf.DBDSN, conf.ServerNumber)
+	conf.StorageDir = fmt.Sprintf(conf.StorageDir, conf.ServerNumber)
+	if conf.KeyDir != "" {
+		conf.KeyDir = fmt.Sprintf(conf.KeyDir, conf.ServerNumber)
+	}
 	return conf
 }
 
+// SetupStore opens the storage backend chosen in config.json, the in-memory and file stores start out with
+// every user of the shard at the same balance the db script gives them
+
+func SetupStore(config *Config) {
+	switch config.StorageBackend {
+	case StorageMemory:
+		SetupMemStore(config)
+	case StorageFile:
+		SetupFileStore(config)
+	case StorageMySQL, "":
+		SetupDB(config)
+	default:
+		log.Fatalf("unknown storage backend %s", config.StorageBackend)
+	}
+}
+
+func SetupMemStore(config *Config) {
+	store := memstore.NewMemStore()
+	if err := store.ReplaceBalances(GetShardUsers(config)); err != nil {
+		log.Fatal(err)
+	}
+	config.DataStore = store
+	fmt.Println("In-memory store ready!!")
+}
+
+func SetupFileStore(config *Config) {
+	store, err := filestore.NewFileStore(config.StorageDir, config.SnapshotInterval, GetShardUsers(config))
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.DataStore = store
+	fmt.Printf("File store ready at %s!!\n", config.StorageDir)
+}
+
+func GetShardUsers(config *Config) []datastore.User {
+	var users []datastore.User
+	for user := (config.ClusterNumber-1)*config.DataItemsPerShard + 1; user <= config.ClusterNumber*config.DataItemsPerShard; user++ {
+		users = append(users, datastore.User{User: user, Balance: 10})
+	}
+	return users
+}
+
 func SetupDB(config *Config) {
 	db, err := sql.Open("mysql", config.DBDSN)
 	if err != nil {
 		log.Fatal(err)
 	}
-	config.DataStore = db
+	config.DataStore = datastore.NewMySQLStore(db)
+	if err = datastore.CreateTables(db); err != nil {
+		log.Fatal(err)
+	}
 	fmt.Println("MySQL Connected!!")
 	db.SetMaxOpenConns(1001)
 	db.SetMaxIdleConns(50)
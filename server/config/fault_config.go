@@ -0,0 +1,68 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+)
+
+const (
+	defaultFaultDelay    = time.Second
+	defaultFaultDropRate = 1
+)
+
+// FaultConfig holds the byzantine behaviors injected into this replica for testing, they are set along
+// with the rest of the server state before every set
+type FaultConfig struct {
+	Lock      sync.RWMutex
+	Behaviors map[string]bool
+	Delay     time.Duration
+	DropRate  float32
+}
+
+// SetFaults replaces the injected behaviors with faults, a nil faults clears them.
+func (c *FaultConfig) SetFaults(faults *common.FaultConfig) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	c.Behaviors = make(map[string]bool)
+	c.Delay = defaultFaultDelay
+	c.DropRate = defaultFaultDropRate
+	if faults == nil {
+		return
+	}
+
+	for _, behavior := range faults.Behaviors {
+		c.Behaviors[behavior] = true
+	}
+	if faults.DelayMs > 0 {
+		c.Delay = time.Duration(faults.DelayMs) * time.Millisecond
+	}
+	if faults.DropRate > 0 {
+		c.DropRate = faults.DropRate
+	}
+}
+
+func (c *FaultConfig) HasFault(behavior string) bool {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	return c.Behaviors[behavior]
+}
+
+func (c *FaultConfig) HasAnyFault() bool {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	return len(c.Behaviors) > 0
+}
+
+func (c *FaultConfig) GetDelay() time.Duration {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	return c.Delay
+}
+
+func (c *FaultConfig) GetDropRate() float32 {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	return c.DropRate
+}
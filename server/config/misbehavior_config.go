@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+)
+
+type StatementKey struct {
+	Sender         int32
+	ViewNumber     int32
+	SequenceNumber int32
+}
+
+type RecordedStatement struct {
+	Digest    string
+	Statement *common.SignedStatement
+}
+
+// MisbehaviorConfig keeps the signed statements this replica verified, to catch a replica signing two
+// conflicting ones, and the evidence against the replicas that did, which are no longer counted in quorums
+type MisbehaviorConfig struct {
+	Lock       sync.Mutex
+	Statements map[StatementKey]RecordedStatement
+	Evidence   map[string]*common.MisbehaviorEvidence
+	Blacklist  map[int32]bool
+}
+
+func NewMisbehaviorConfig() *MisbehaviorConfig {
+	return &MisbehaviorConfig{
+		Statements: make(map[StatementKey]RecordedStatement),
+		Evidence:   make(map[string]*common.MisbehaviorEvidence),
+		Blacklist:  make(map[int32]bool),
+	}
+}
+
+// RecordStatement keeps the first statement signed for key and returns it if a later one carries a
+// different digest.
+func (c *MisbehaviorConfig) RecordStatement(key StatementKey, digest string, statement *common.SignedStatement) (*common.SignedStatement, bool) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	recorded, ok := c.Statements[key]
+	if !ok {
+		c.Statements[key] = RecordedStatement{Digest: digest, Statement: statement}
+		return nil, false
+	}
+	if recorded.Digest == digest {
+		return nil, false
+	}
+	return recorded.Statement, true
+}
+
+func (c *MisbehaviorConfig) GetStatement(key StatementKey) (RecordedStatement, bool) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	recorded, ok := c.Statements[key]
+	return recorded, ok
+}
+
+// PruneStatements drops the statements below a stable checkpoint, nothing can be ordered there anymore.
+func (c *MisbehaviorConfig) PruneStatements(seqNo int32) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	for key := range c.Statements {
+		if key.SequenceNumber < seqNo {
+			delete(c.Statements, key)
+		}
+	}
+}
+
+// AddEvidence stores evidence and blacklists the accused replica, it reports false if the same misbehavior
+// is already known.
+func (c *MisbehaviorConfig) AddEvidence(evidence *common.MisbehaviorEvidence) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	evidenceID := fmt.Sprintf("%d-%d-%d", evidence.Accused, evidence.ViewNumber, evidence.SequenceNumber)
+	if _, ok := c.Evidence[evidenceID]; ok {
+		return false
+	}
+	c.Evidence[evidenceID] = evidence
+	c.Blacklist[evidence.Accused] = true
+	return true
+}
+
+func (c *MisbehaviorConfig) IsBlacklisted(serverNo int32) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return c.Blacklist[serverNo]
+}
+
+func (c *MisbehaviorConfig) GetEvidence() []*common.MisbehaviorEvidence {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	var evidence []*common.MisbehaviorEvidence
+	for _, e := range c.Evidence {
+		evidence = append(evidence, e)
+	}
+	sort.Slice(evidence, func(i, j int) bool {
+		if evidence[i].Accused != evidence[j].Accused {
+			return evidence[i].Accused < evidence[j].Accused
+		}
+		return evidence[i].SequenceNumber < evidence[j].SequenceNumber
+	})
+	return evidence
+}
+
+func (c *MisbehaviorConfig) GetBlacklist() []int32 {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	var blacklist []int32
+	for serverNo := range c.Blacklist {
+		blacklist = append(blacklist, serverNo)
+	}
+	sort.Slice(blacklist, func(i, j int) bool { return blacklist[i] < blacklist[j] })
+	return blacklist
+}
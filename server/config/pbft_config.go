@@ -1,6 +1,9 @@
 package config
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 type PBFTConfig struct {
 	Lock               sync.Mutex
@@ -8,6 +11,11 @@ type PBFTConfig struct {
 	SequenceNumber     int32
 	NextSequenceNumber int32
 	LastExecutedSeq    int32
+	LowWatermark       int32
+	HighWatermark      int32
+
+	ViewChangeInProgress bool
+	PendingCheckpoints   []int32
 }
 
 func (c *PBFTConfig) GetSequenceNumber() int32 {
@@ -57,3 +65,118 @@ func (c *PBFTConfig) IncrementNextSequenceNumber() {
 	c.NextSequenceNumber++
 	c.Lock.Unlock()
 }
+
+// SetExecutedSequenceNumber fast-forwards execution to seqNo after a checkpoint is installed through state transfer.
+func (c *PBFTConfig) SetExecutedSequenceNumber(seqNo int32) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	c.NextSequenceNumber = seqNo + 1
+	c.LastExecutedSeq = seqNo
+	if c.SequenceNumber < seqNo {
+		c.SequenceNumber = seqNo
+	}
+}
+
+func (c *PBFTConfig) IsViewChangeInProgress() bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return c.ViewChangeInProgress
+}
+
+// MoveToView sets the view to newView and marks a view change as in progress
+// until InstallView is called for it.
+func (c *PBFTConfig) MoveToView(newView int32) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	if newView <= c.ViewNumber {
+		return false
+	}
+	c.ViewNumber = newView
+	c.ViewChangeInProgress = true
+	return true
+}
+
+// InstallView completes the view change into view, unless the replica has already installed it or a later view.
+func (c *PBFTConfig) InstallView(view int32) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	if view < c.ViewNumber || (view == c.ViewNumber && !c.ViewChangeInProgress) {
+		return false
+	}
+	c.ViewNumber = view
+	c.ViewChangeInProgress = false
+	return true
+}
+
+// AssignSequenceNumber hands out the next sequence number, unless it would go above the high watermark.
+func (c *PBFTConfig) AssignSequenceNumber() (int32, bool) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	if c.SequenceNumber+1 > c.HighWatermark {
+		return 0, false
+	}
+	c.SequenceNumber++
+	return c.SequenceNumber, true
+}
+
+func (c *PBFTConfig) GetLowWatermark() int32 {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return c.LowWatermark
+}
+
+func (c *PBFTConfig) GetHighWatermark() int32 {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return c.HighWatermark
+}
+
+func (c *PBFTConfig) IsWithinWatermarks(seqNo int32) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return seqNo > c.LowWatermark && seqNo <= c.HighWatermark
+}
+
+// AdvanceWatermarks moves the window to start at a new stable checkpoint, ignoring older ones.
+func (c *PBFTConfig) AdvanceWatermarks(stableCheckpoint, window int32) bool {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	if stableCheckpoint <= c.LowWatermark {
+		return false
+	}
+	c.LowWatermark = stableCheckpoint
+	c.HighWatermark = stableCheckpoint + window
+	return true
+}
+
+// AddPendingCheckpoint queues a checkpoint at seqNo until every txn up to it has its final outcome.
+func (c *PBFTConfig) AddPendingCheckpoint(seqNo int32) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	for _, pending := range c.PendingCheckpoints {
+		if pending == seqNo {
+			return
+		}
+	}
+	c.PendingCheckpoints = append(c.PendingCheckpoints, seqNo)
+	sort.Slice(c.PendingCheckpoints, func(i, j int) bool { return c.PendingCheckpoints[i] < c.PendingCheckpoints[j] })
+}
+
+func (c *PBFTConfig) GetPendingCheckpoints() []int32 {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	return append([]int32(nil), c.PendingCheckpoints...)
+}
+
+// RemovePendingCheckpoints drops the queued checkpoints up to seqNo, once taken or covered by an installed one.
+func (c *PBFTConfig) RemovePendingCheckpoints(seqNo int32) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	var pendingCheckpoints []int32
+	for _, pending := range c.PendingCheckpoints {
+		if pending > seqNo {
+			pendingCheckpoints = append(pendingCheckpoints, pending)
+		}
+	}
+	c.PendingCheckpoints = pendingCheckpoints
+}
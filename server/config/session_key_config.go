@@ -0,0 +1,93 @@
+package config
+
+import (
+	"crypto/ecdh"
+	"crypto/sha256"
+	"sync"
+
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+)
+
+// SessionKeyConfig holds the x25519 key this replica agrees on pairwise session keys with, and the session keys
+// agreed with the other replicas of its cluster. the x25519 key is derived from the private key of the replica
+// so the session keys, and the authenticators computed under them, survive a restart
+type SessionKeyConfig struct {
+	Lock       sync.RWMutex
+	PrivateKey *ecdh.PrivateKey
+	Keys       map[int32][]byte
+}
+
+func NewSessionKeyConfig(signer KeyPool.Signer) (*SessionKeyConfig, error) {
+	sessionPrivateKey, err := DeriveSessionPrivateKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionKeyConfig{
+		PrivateKey: sessionPrivateKey,
+		Keys:       make(map[int32][]byte),
+	}, nil
+}
+
+func DeriveSessionPrivateKey(signer KeyPool.Signer) (*ecdh.PrivateKey, error) {
+	privateKeyBytes, err := signer.MarshalPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	seed := sha256.Sum256(append([]byte("session-key:"), privateKeyBytes...))
+	return ecdh.X25519().NewPrivateKey(seed[:])
+}
+
+// Reset derives a new x25519 key from a rotated signer and forgets every session key agreed with the old one.
+func (c *SessionKeyConfig) Reset(signer KeyPool.Signer) error {
+	sessionPrivateKey, err := DeriveSessionPrivateKey(signer)
+	if err != nil {
+		return err
+	}
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	c.PrivateKey = sessionPrivateKey
+	c.Keys = make(map[int32][]byte)
+	return nil
+}
+
+func (c *SessionKeyConfig) GetPublicKey() []byte {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	return c.PrivateKey.PublicKey().Bytes()
+}
+
+// DeriveKey returns the session key shared with the replica holding peerPublicKey.
+func (c *SessionKeyConfig) DeriveKey(peerPublicKey []byte) ([]byte, error) {
+	publicKey, err := ecdh.X25519().NewPublicKey(peerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	c.Lock.RLock()
+	secret, err := c.PrivateKey.ECDH(publicKey)
+	c.Lock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256(append([]byte("pbft-mac:"), secret...))
+	return key[:], nil
+}
+
+func (c *SessionKeyConfig) GetKey(serverNo int32) ([]byte, bool) {
+	c.Lock.RLock()
+	defer c.Lock.RUnlock()
+	key, ok := c.Keys[serverNo]
+	return key, ok
+}
+
+func (c *SessionKeyConfig) SetKey(serverNo int32, key []byte) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	c.Keys[serverNo] = key
+}
+
+// DeleteKey forgets the session key shared with serverNo, it is agreed on again the next time it is needed.
+func (c *SessionKeyConfig) DeleteKey(serverNo int32) {
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	delete(c.Keys, serverNo)
+}
@@ -0,0 +1,199 @@
+package lockmanager
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrLockTimeout = errors.New("timed out waiting for lock")
+	ErrLockDie     = errors.New("lock held or awaited by an older txn")
+)
+
+// LockManager hands out exclusive locks on data items to txns, a txn that finds an item locked joins the
+// FIFO queue of that item and gives up once the lock wait timeout runs out.
+//
+// AcquireOrDie adds wait-die on top of that: every txn carries the timestamp it was created with, an older
+// txn may wait for a younger one but a younger one asking for a lock an older txn holds or waits for is
+// refused right away. the leaders of the sender and the participant clusters admit txns this way with the
+// same timestamp, so the waits that decide which txns get in only point from older to younger txns and
+// cannot form a cycle across clusters. followers, and a new leader filling in prepared txns, cannot refuse
+// what was already ordered and wait in line with Acquire; the lock wait timeout is what ends such a wait
+
+type LockManager struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	locks   map[int32]*lockState
+}
+
+type lockState struct {
+	holder          string
+	holderTimestamp time.Time
+	waiters         []*waiter
+}
+
+type waiter struct {
+	owner     string
+	timestamp time.Time
+	granted   chan struct{}
+}
+
+// LockInfo describes one locked item, Waiters are listed in the order they will get the lock
+
+type LockInfo struct {
+	Item    int32
+	Holder  string
+	Waiters []string
+}
+
+func NewLockManager(timeout time.Duration) *LockManager {
+	return &LockManager{
+		timeout: timeout,
+		locks:   make(map[int32]*lockState),
+	}
+}
+
+// Acquire locks item for owner, blocking behind earlier waiters until the lock is handed over or the
+// timeout expires. acquiring an item owner already holds succeeds immediately
+
+func (lm *LockManager) Acquire(item int32, owner string, timestamp time.Time) error {
+	return lm.acquire(item, owner, timestamp, false)
+}
+
+// AcquireOrDie is Acquire with wait-die, it fails with ErrLockDie instead of waiting behind an older txn
+
+func (lm *LockManager) AcquireOrDie(item int32, owner string, timestamp time.Time) error {
+	return lm.acquire(item, owner, timestamp, true)
+}
+
+func (lm *LockManager) acquire(item int32, owner string, timestamp time.Time, waitDie bool) error {
+	lm.mu.Lock()
+	state, exists := lm.locks[item]
+	if !exists {
+		lm.locks[item] = &lockState{holder: owner, holderTimestamp: timestamp}
+		lm.mu.Unlock()
+		return nil
+	}
+	if state.holder == owner {
+		lm.mu.Unlock()
+		return nil
+	}
+
+	if waitDie {
+		older := IsOlder(timestamp, owner, state.holderTimestamp, state.holder)
+		for _, queued := range state.waiters {
+			older = older && IsOlder(timestamp, owner, queued.timestamp, queued.owner)
+		}
+		if !older {
+			lm.mu.Unlock()
+			return ErrLockDie
+		}
+	}
+
+	w := &waiter{owner: owner, timestamp: timestamp, granted: make(chan struct{})}
+	state.waiters = append(state.waiters, w)
+	lm.mu.Unlock()
+
+	timer := time.NewTimer(lm.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-w.granted:
+		return nil
+	case <-timer.C:
+	}
+
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	// the lock may have been handed over while the timer fired
+	select {
+	case <-w.granted:
+		return nil
+	default:
+	}
+	for i, queued := range state.waiters {
+		if queued == w {
+			state.waiters = append(state.waiters[:i], state.waiters[i+1:]...)
+			break
+		}
+	}
+	return ErrLockTimeout
+}
+
+// TryAcquire locks item for owner only if nobody holds it
+
+func (lm *LockManager) TryAcquire(item int32, owner string, timestamp time.Time) bool {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	state, exists := lm.locks[item]
+	if !exists {
+		lm.locks[item] = &lockState{holder: owner, holderTimestamp: timestamp}
+		return true
+	}
+	return state.holder == owner
+}
+
+// Release unlocks item if owner holds it and hands it to the first waiter in line
+
+func (lm *LockManager) Release(item int32, owner string) bool {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	state, exists := lm.locks[item]
+	if !exists || state.holder != owner {
+		return false
+	}
+
+	if len(state.waiters) == 0 {
+		delete(lm.locks, item)
+		return true
+	}
+	next := state.waiters[0]
+	state.waiters = state.waiters[1:]
+	state.holder = next.owner
+	state.holderTimestamp = next.timestamp
+	close(next.granted)
+	return true
+}
+
+func (lm *LockManager) Holder(item int32) (string, bool) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	state, exists := lm.locks[item]
+	if !exists {
+		return "", false
+	}
+	return state.holder, true
+}
+
+// Locks lists every held lock along with its waiters, ordered by item
+
+func (lm *LockManager) Locks() []LockInfo {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	var locks []LockInfo
+	for item, state := range lm.locks {
+		info := LockInfo{Item: item, Holder: state.holder}
+		for _, w := range state.waiters {
+			info.Waiters = append(info.Waiters, w.owner)
+		}
+		locks = append(locks, info)
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Item < locks[j].Item })
+	return locks
+}
+
+// IsOlder orders txns by timestamp, breaking ties by owner so every replica agrees on the order
+
+func IsOlder(timestamp time.Time, owner string, otherTimestamp time.Time, otherOwner string) bool {
+	if !timestamp.Equal(otherTimestamp) {
+		return timestamp.Before(otherTimestamp)
+	}
+	return owner < otherOwner
+}
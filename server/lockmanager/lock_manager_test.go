@@ -0,0 +1,144 @@
+package lockmanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireOrDieRefusesYoungerTxn(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	older := time.Now()
+	younger := older.Add(time.Millisecond)
+
+	if err := lm.AcquireOrDie(1, "older", older); err != nil {
+		t.Fatalf("older txn could not lock a free item: %v", err)
+	}
+	if err := lm.AcquireOrDie(1, "younger", younger); err != ErrLockDie {
+		t.Fatalf("younger txn got %v instead of dying", err)
+	}
+	if holder, _ := lm.Holder(1); holder != "older" {
+		t.Fatalf("item is held by %q, want older", holder)
+	}
+}
+
+func TestAcquireOrDieLetsOlderTxnWait(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	older := time.Now()
+	younger := older.Add(time.Millisecond)
+
+	if err := lm.AcquireOrDie(1, "younger", younger); err != nil {
+		t.Fatalf("younger txn could not lock a free item: %v", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- lm.AcquireOrDie(1, "older", older)
+	}()
+	waitForWaiters(t, lm, 1, 1)
+
+	if !lm.Release(1, "younger") {
+		t.Fatal("younger txn could not release its lock")
+	}
+	if err := <-acquired; err != nil {
+		t.Fatalf("older txn did not get the lock handed over: %v", err)
+	}
+	if holder, _ := lm.Holder(1); holder != "older" {
+		t.Fatalf("item is held by %q, want older", holder)
+	}
+}
+
+func TestAcquireOrDieRefusesTxnYoungerThanAWaiter(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	start := time.Now()
+
+	if err := lm.AcquireOrDie(1, "holder", start.Add(2*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = lm.AcquireOrDie(1, "oldest", start)
+	}()
+	waitForWaiters(t, lm, 1, 1)
+
+	// older than the holder but younger than the txn already waiting
+	if err := lm.AcquireOrDie(1, "middle", start.Add(time.Millisecond)); err != ErrLockDie {
+		t.Fatalf("txn younger than a waiter got %v instead of dying", err)
+	}
+	lm.Release(1, "holder")
+}
+
+func TestAcquireOrDieBreaksTimestampTiesByOwner(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	timestamp := time.Now()
+
+	if err := lm.AcquireOrDie(1, "b", timestamp); err != nil {
+		t.Fatal(err)
+	}
+	if err := lm.AcquireOrDie(2, "a", timestamp); err != nil {
+		t.Fatal(err)
+	}
+	if err := lm.AcquireOrDie(2, "b", timestamp); err != ErrLockDie {
+		t.Fatalf("b is younger than a on a tie, got %v instead of dying", err)
+	}
+}
+
+func TestAcquireTimesOut(t *testing.T) {
+	lm := NewLockManager(20 * time.Millisecond)
+	now := time.Now()
+
+	if err := lm.Acquire(1, "holder", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := lm.Acquire(1, "waiter", now.Add(-time.Second)); err != ErrLockTimeout {
+		t.Fatalf("waiter got %v, want a timeout", err)
+	}
+	if locks := lm.Locks(); len(locks) != 1 || len(locks[0].Waiters) != 0 {
+		t.Fatalf("timed out waiter is still queued: %+v", locks)
+	}
+
+	// the lock goes back to free once released, nobody is left to hand it to
+	lm.Release(1, "holder")
+	if _, held := lm.Holder(1); held {
+		t.Fatal("item is still held after its only holder released it")
+	}
+}
+
+func TestReleaseHandsLockOverInOrder(t *testing.T) {
+	lm := NewLockManager(time.Second)
+	now := time.Now()
+
+	if err := lm.Acquire(1, "first", now); err != nil {
+		t.Fatal(err)
+	}
+	granted := make(chan string, 2)
+	for i, owner := range []string{"second", "third"} {
+		go func(owner string) {
+			if lm.Acquire(1, owner, now) == nil {
+				granted <- owner
+			}
+		}(owner)
+		waitForWaiters(t, lm, 1, i+1)
+	}
+
+	lm.Release(1, "first")
+	if owner := <-granted; owner != "second" {
+		t.Fatalf("lock went to %s before second", owner)
+	}
+	lm.Release(1, "second")
+	if owner := <-granted; owner != "third" {
+		t.Fatalf("lock went to %s, want third", owner)
+	}
+}
+
+func waitForWaiters(t *testing.T, lm *LockManager, item int32, waiters int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		for _, lock := range lm.Locks() {
+			if lock.Item == item && len(lock.Waiters) == waiters {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("item %d never had %d waiters", item, waiters)
+}
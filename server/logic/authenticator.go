@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// in mac mode the messages of the leader carry an authenticator instead of a signature: a vector with a hmac
+// of the payload for every replica of the cluster, each under the session key the sender shares with that
+// replica. a replica only checks its own entry, so a faulty sender can hand out an authenticator that the
+// leader accepts and another replica rejects. such messages convince their receiver and nobody else, which
+// is why the prepare and commit votes stay signed: the leader bundles them into certificates that get
+// passed on, and view changes and state transfers carry them again. checkpoints, view changes, state
+// transfers and the 2PC messages stay signed too. other clusters only ever check the threshold signature of
+// a cluster, not the commit votes behind it. mac authenticated messages cannot serve as proof of misbehavior
+// either
+
+func IsMACMode(conf *config.Config) bool {
+	return conf.AuthMode == config.AuthMAC
+}
+
+// IsVoteTransferable reports whether the commit votes for txn carry a threshold share for the certificate
+// sent to another cluster. abort votes do not, their shares only go out once the vote executed
+
+func IsVoteTransferable(txn *common.TxnRequest) bool {
+	return txn.Type == TypeCrossShardSender || txn.Type == TypeCrossShardReceiver
+}
+
+// AuthenticateMessage returns a signature of payload, or an authenticator for it in mac mode
+
+func AuthenticateMessage(conf *config.Config, payload []byte) ([]byte, []byte, error) {
+	if !IsMACMode(conf) {
+		sign, err := SignMessage(conf.PrivateKey, payload)
+		return sign, nil, err
+	}
+
+	authenticator, err := GetAuthenticator(conf, payload)
+	return nil, authenticator, err
+}
+
+// SignVote signs a prepare or commit vote whatever the auth mode, the vote ends up in certificates that every
+// replica of the cluster has to be able to check
+
+func SignVote(conf *config.Config, payload []byte) ([]byte, error) {
+	return SignMessage(conf.PrivateKey, payload)
+}
+
+// GetAuthenticator leaves out the replicas no session key could be agreed with, they could not check their
+// entry anyway
+
+func GetAuthenticator(conf *config.Config, payload []byte) ([]byte, error) {
+	authenticator := &common.Authenticator{}
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		key, err := GetSessionKey(conf, serverNo)
+		if err != nil {
+			fmt.Printf("GetAuthenticator: no session key with server %d: %v\n", serverNo, err)
+			continue
+		}
+		authenticator.Macs = append(authenticator.Macs, &common.MAC{
+			Receiver: serverNo,
+			Mac:      ComputeMAC(key, payload),
+		})
+	}
+	return signedPayload.Marshal(authenticator)
+}
+
+func ComputeMAC(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// VerifyMessage checks the signature of payload if sender signed it, and the entry of this replica in its
+// authenticator otherwise
+
+func VerifyMessage(conf *config.Config, sender int32, payload, sign, authenticator []byte) error {
+	if len(sign) > 0 {
+		publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[sender])
+		if err != nil {
+			return err
+		}
+		return VerifySignature(publicKey, payload, sign)
+	}
+	if len(authenticator) == 0 {
+		return errors.New("message neither signed nor authenticated")
+	}
+	if !IsServerInCluster(conf, sender, conf.ClusterNumber) {
+		return errors.New("authenticator from server outside cluster")
+	}
+
+	macs := &common.Authenticator{}
+	err := signedPayload.Unmarshal(authenticator, macs)
+	if err != nil {
+		return err
+	}
+	key, err := GetSessionKey(conf, sender)
+	if err != nil {
+		return err
+	}
+	for _, mac := range macs.Macs {
+		if mac.Receiver != conf.ServerNumber {
+			continue
+		}
+		if !hmac.Equal(mac.Mac, ComputeMAC(key, payload)) {
+			return errors.New("mac verification failed")
+		}
+		return nil
+	}
+	return errors.New("authenticator has no mac for this server")
+}
+
+// GetSessionKey returns the session key shared with serverNo, agreeing on it with serverNo first if this
+// replica has not yet
+
+func GetSessionKey(conf *config.Config, serverNo int32) ([]byte, error) {
+	key, ok := conf.SessionKeys.GetKey(serverNo)
+	if ok {
+		return key, nil
+	}
+
+	peerPublicKey := conf.SessionKeys.GetPublicKey()
+	if serverNo != conf.ServerNumber {
+		peerKey, err := ExchangeSessionKey(conf, serverNo)
+		if err != nil {
+			return nil, err
+		}
+		peerPublicKey = peerKey.PublicKey
+	}
+
+	key, err := conf.SessionKeys.DeriveKey(peerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	conf.SessionKeys.SetKey(serverNo, key)
+	return key, nil
+}
+
+func ExchangeSessionKey(conf *config.Config, serverNo int32) (*common.SessionKey, error) {
+	sessionKey, err := GetSignedSessionKey(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[serverNo])
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), GetRPCTimeout(conf))
+	defer cancel()
+
+	peerKey, err := server.ExchangeSessionKey(ctx, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	if peerKey.ServerNo != serverNo {
+		return nil, errors.New("session key from another server")
+	}
+	err = VerifySessionKey(conf, peerKey)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("agreed on a session key with server %d\n", serverNo)
+	return peerKey, nil
+}
+
+// ReceiveSessionKey derives the session key shared with the sender of req and answers with the public key of
+// this replica so the sender derives the same
+
+func ReceiveSessionKey(ctx context.Context, conf *config.Config, req *common.SessionKey) (*common.SessionKey, error) {
+	if !IsServerInCluster(conf, req.ServerNo, conf.ClusterNumber) {
+		return nil, errors.New("session key from server outside cluster")
+	}
+	err := VerifySessionKey(conf, req)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := conf.SessionKeys.DeriveKey(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	conf.SessionKeys.SetKey(req.ServerNo, key)
+
+	return GetSignedSessionKey(conf)
+}
+
+func GetSignedSessionKey(conf *config.Config) (*common.SessionKey, error) {
+	sessionKey := &common.SessionKey{
+		ServerNo:  conf.ServerNumber,
+		PublicKey: conf.SessionKeys.GetPublicKey(),
+	}
+	payload, err := signedPayload.Marshal(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	sessionKey.Sign, err = SignMessage(conf.PrivateKey, payload)
+	if err != nil {
+		return nil, err
+	}
+	return sessionKey, nil
+}
+
+func VerifySessionKey(conf *config.Config, sessionKey *common.SessionKey) error {
+	payload, err := signedPayload.Marshal(&common.SessionKey{
+		ServerNo:  sessionKey.ServerNo,
+		PublicKey: sessionKey.PublicKey,
+	})
+	if err != nil {
+		return err
+	}
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[sessionKey.ServerNo])
+	if err != nil {
+		return err
+	}
+	return VerifySignature(publicKey, payload, sessionKey.Sign)
+}
@@ -0,0 +1,206 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+)
+
+// the leader buffers intra-shard requests and runs a single consensus instance for up to BatchSize of them,
+// proposing a partial batch once the oldest request has waited BatchDelay
+
+func AddToBatch(conf *config.Config, req *common.TxnRequest) {
+	conf.BatchLock.Lock()
+	// a retried request can come in again before its batch is proposed
+	for _, pending := range conf.PendingBatch {
+		if pending.TxnID == req.TxnID {
+			conf.BatchLock.Unlock()
+			return
+		}
+	}
+	conf.PendingBatch = append(conf.PendingBatch, req)
+	if int32(len(conf.PendingBatch)) < conf.BatchSize {
+		if conf.BatchTimer == nil {
+			conf.BatchTimer = time.AfterFunc(GetBatchDelay(conf), func() {
+				FlushBatch(conf)
+			})
+		}
+		conf.BatchLock.Unlock()
+		return
+	}
+	batch := TakePendingBatch(conf)
+	conf.BatchLock.Unlock()
+
+	go func() {
+		err := ProposeBatch(conf, batch)
+		if err != nil {
+			fmt.Printf("ProposeBatch error: %v\n", err)
+		}
+	}()
+}
+
+func FlushBatch(conf *config.Config) {
+	conf.BatchLock.Lock()
+	batch := TakePendingBatch(conf)
+	conf.BatchLock.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+	err := ProposeBatch(conf, batch)
+	if err != nil {
+		fmt.Printf("ProposeBatch error: %v\n", err)
+	}
+}
+
+// TakePendingBatch empties the batch buffer, the caller must hold BatchLock
+
+func TakePendingBatch(conf *config.Config) []*common.TxnRequest {
+	if conf.BatchTimer != nil {
+		conf.BatchTimer.Stop()
+		conf.BatchTimer = nil
+	}
+	batch := conf.PendingBatch
+	conf.PendingBatch = nil
+	return batch
+}
+
+func ProposeBatch(conf *config.Config, members []*common.TxnRequest) error {
+	AcquireInstanceSlot(conf)
+	defer ReleaseInstanceSlot(conf)
+
+	seqNo, ok := conf.PBFT.AssignSequenceNumber()
+	if !ok {
+		for _, txn := range members {
+			ReleaseLock(conf, txn)
+		}
+		return errors.New("sequence number above high watermark")
+	}
+
+	viewNo := conf.PBFT.GetViewNumber()
+	batch := &common.TxnRequest{
+		TxnID:  GetBatchID(viewNo, seqNo),
+		SeqNo:  seqNo,
+		ViewNo: viewNo,
+		Type:   TypeBatch,
+		Status: StatusInit,
+		Batch:  members,
+	}
+	batch.Digest = GetTxnDigest(batch)
+
+	fmt.Printf("proposing batch %s with %d txns\n", batch.TxnID, len(members))
+
+	err := InsertTxnWithBatch(conf, batch)
+	if err != nil {
+		return err
+	}
+
+	err = StartConsensus(conf, batch, EmptyString)
+	if err != nil {
+		return err
+	}
+
+	SendExecuteSignal(conf, batch)
+	return nil
+}
+
+// DropPendingBatch hands buffered requests back to the view change logic so they are resent to the
+// leader of the next view, the caller must hold ViewChangeLock
+
+func DropPendingBatch(conf *config.Config) {
+	conf.BatchLock.Lock()
+	batch := TakePendingBatch(conf)
+	conf.BatchLock.Unlock()
+
+	for _, txn := range batch {
+		ReleaseLock(conf, txn)
+		conf.ForwardedRequests[txn.TxnID] = txn
+	}
+}
+
+// InsertTxnWithBatch stores txn along with its batch members, which share its sequence and view numbers
+
+func InsertTxnWithBatch(conf *config.Config, txn *common.TxnRequest) error {
+	err := conf.DataStore.InsertTransaction(txn)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range txn.Batch {
+		member.Type = GetTxnType(conf, member)
+		member.SeqNo = txn.SeqNo
+		member.ViewNo = txn.ViewNo
+		member.BatchID = txn.TxnID
+		member.Digest = GetTxnDigest(member)
+		member.Status = StatusInit
+		err = conf.DataStore.InsertTransaction(member)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadBatchMembers fills in the members of a batch read back from the transaction table
+
+func LoadBatchMembers(conf *config.Config, txn *common.TxnRequest) error {
+	if txn.Type != TypeBatch || len(txn.Batch) > 0 {
+		return nil
+	}
+	members, err := conf.DataStore.GetTransactionsByBatchID(txn.TxnID)
+	if err != nil {
+		return err
+	}
+	txn.Batch = members
+	return nil
+}
+
+// VerifyBatch checks a proposed batch only holds intra-shard txns touching distinct users, since replicas
+// lock all of them at once
+
+func VerifyBatch(conf *config.Config, batch *common.TxnRequest) error {
+	if len(batch.Batch) == 0 || int32(len(batch.Batch)) > conf.BatchSize {
+		return errors.New("invalid batch size")
+	}
+
+	txnIDs := make(map[string]bool)
+	users := make(map[int32]bool)
+	for _, txn := range batch.Batch {
+		if GetTxnType(conf, txn) != TypeIntraShard {
+			return errors.New("batch contains a cross-shard txn")
+		}
+		if txnIDs[txn.TxnID] || users[txn.Sender] || VerifyTxnLegs(txn) != nil {
+			return errors.New("batch txns overlap")
+		}
+		txnIDs[txn.TxnID] = true
+		users[txn.Sender] = true
+		for _, leg := range GetTxnLegs(txn) {
+			if users[leg.Receiver] || leg.Receiver == txn.Sender {
+				return errors.New("batch txns overlap")
+			}
+			users[leg.Receiver] = true
+		}
+		txn.Type = TypeIntraShard
+	}
+	return nil
+}
+
+func GetBatchDigest(batch *common.TxnRequest) string {
+	batchDigest := &common.BatchDigest{}
+	for _, txn := range batch.Batch {
+		batchDigest.TxnIDs = append(batchDigest.TxnIDs, txn.TxnID)
+		batchDigest.Digests = append(batchDigest.Digests, GetTxnDigest(txn))
+	}
+	return GetMessageDigest(batchDigest)
+}
+
+func GetBatchID(view, seqNo int32) string {
+	return fmt.Sprintf("batch-%d-%d", view, seqNo)
+}
+
+func GetBatchDelay(conf *config.Config) time.Duration {
+	return time.Duration(conf.BatchDelay) * time.Millisecond
+}
@@ -0,0 +1,472 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// every CheckpointInterval executed sequence numbers, replicas snapshot the balances and exchange a signed
+// digest of the snapshot; 2f+1 matching digests make the checkpoint stable and move the watermarks.
+// cross-shard txns move balances when they execute but are only committed or rolled back once their 2PC
+// outcome arrives, which every replica sees at a different point of its execution. so a checkpoint waits
+// until every txn up to its sequence number has its outcome, and snapshots the balances as of that sequence
+// number, leaving out whatever txns executed after it
+
+func TakeCheckpoint(conf *config.Config, seqNo int32) error {
+	conf.PBFT.AddPendingCheckpoint(seqNo)
+	return TakePendingCheckpoints(conf)
+}
+
+// TakePendingCheckpoints takes the queued checkpoints in order, up to the first one still waiting for a 2PC outcome
+
+func TakePendingCheckpoints(conf *config.Config) error {
+	conf.CheckpointLock.Lock()
+	defer conf.CheckpointLock.Unlock()
+
+	for _, seqNo := range conf.PBFT.GetPendingCheckpoints() {
+		balances, isDecided, err := GetCheckpointBalances(conf, seqNo)
+		if err != nil {
+			return err
+		}
+		if !isDecided {
+			fmt.Printf("checkpoint at sequence %d waits for 2PC outcomes\n", seqNo)
+			return nil
+		}
+		conf.PBFT.RemovePendingCheckpoints(seqNo)
+
+		state, err := json.Marshal(balances)
+		if err != nil {
+			return err
+		}
+
+		checkpoint := datastore.Checkpoint{
+			SeqNo:  seqNo,
+			Digest: GetStateDigest(balances),
+			State:  string(state),
+		}
+		err = conf.DataStore.InsertCheckpoint(checkpoint)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("took checkpoint at sequence %d with digest %s\n", seqNo, checkpoint.Digest)
+
+		go func() {
+			err := SendCheckpoint(conf, checkpoint)
+			if err != nil {
+				fmt.Printf("SendCheckpoint error: %v\n", err)
+			}
+		}()
+	}
+	return nil
+}
+
+// GetCheckpointBalances returns the balances right after seqNo executed, with the outcome of every txn up to
+// it applied, or false if a cross-shard txn up to seqNo has no outcome yet
+
+func GetCheckpointBalances(conf *config.Config, seqNo int32) ([]datastore.User, bool, error) {
+	balances, txns, err := GetBalancesWithTxns(conf)
+	if err != nil {
+		return nil, false, err
+	}
+
+	users := GetBalanceIndex(balances)
+	for _, txn := range txns {
+		if txn.SeqNo <= seqNo {
+			if IsTwoPCUndecided(txn) {
+				return nil, false, nil
+			}
+			continue
+		}
+		if IsBalanceApplied(txn) {
+			TakeOutTxn(conf, balances, users, txn)
+		}
+	}
+	return balances, true, nil
+}
+
+// GetBalancesWithTxns reads the balances along with the txns after the low watermark. the txns are read before
+// and after the balances, and read again if one of them changed in between, so the balances hold the effects
+// of exactly the txns returned
+
+func GetBalancesWithTxns(conf *config.Config) ([]datastore.User, []*common.TxnRequest, error) {
+	for {
+		lowWatermark := conf.PBFT.GetLowWatermark()
+		txns, err := conf.DataStore.GetTransactionsAfterSequence(lowWatermark)
+		if err != nil {
+			return nil, nil, err
+		}
+		balances, err := conf.DataStore.GetBalances()
+		if err != nil {
+			return nil, nil, err
+		}
+		recheckedTxns, err := conf.DataStore.GetTransactionsAfterSequence(lowWatermark)
+		if err != nil {
+			return nil, nil, err
+		}
+		if IsSameTxnStatus(txns, recheckedTxns) {
+			return balances, txns, nil
+		}
+	}
+}
+
+func GetBalanceIndex(balances []datastore.User) map[int32]int {
+	users := make(map[int32]int)
+	for i, balance := range balances {
+		users[balance.User] = i
+	}
+	return users
+}
+
+// TakeOutTxn undoes the balance changes of txn in balances, whose positions are given by users
+
+func TakeOutTxn(conf *config.Config, balances []datastore.User, users map[int32]int, txn *common.TxnRequest) {
+	if !IsClientTxnType(txn.Type) {
+		return
+	}
+	if i, ok := users[txn.Sender]; ok && (txn.Type == TypeIntraShard || txn.Type == TypeCrossShardSender) {
+		balances[i].Balance += txn.Amount
+	}
+	for _, leg := range GetLocalLegs(conf, txn) {
+		if i, ok := users[leg.Receiver]; ok {
+			balances[i].Balance -= leg.Amount
+		}
+	}
+}
+
+func IsSameTxnStatus(txns, recheckedTxns []*common.TxnRequest) bool {
+	if len(txns) != len(recheckedTxns) {
+		return false
+	}
+	status := make(map[string]string)
+	for _, txn := range txns {
+		status[txn.TxnID] = txn.Status
+	}
+	for _, txn := range recheckedTxns {
+		if txnStatus, ok := status[txn.TxnID]; !ok || txnStatus != txn.Status {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTwoPCUndecided reports whether txn executed as part of a 2PC that has no outcome here yet
+
+func IsTwoPCUndecided(txn *common.TxnRequest) bool {
+	switch txn.Status {
+	case Status2PCPending, Status2PCPrePrepared, Status2PCPrepared, Status2PCCommitted:
+		return true
+	}
+	return false
+}
+
+// IsBalanceApplied reports whether the balance changes of txn are in the balances, an aborted txn was either
+// never executed or rolled back
+
+func IsBalanceApplied(txn *common.TxnRequest) bool {
+	return IsClientTxnType(txn.Type) && txn.Status != StatusAborted && IsTxnExecutedLocally(txn)
+}
+
+func SendCheckpoint(conf *config.Config, checkpoint datastore.Checkpoint) error {
+	signedMessage := &common.SignedMessage{
+		SequenceNumber: checkpoint.SeqNo,
+		Digest:         checkpoint.Digest,
+	}
+	signedMsgBytes, err := signedPayload.Marshal(signedMessage)
+	if err != nil {
+		return err
+	}
+	sign, err := SignMessage(conf.PrivateKey, signedMsgBytes)
+	if err != nil {
+		return err
+	}
+
+	checkpointReq := &common.PBFTRequestResponse{
+		SignedMessage: signedMsgBytes,
+		Sign:          sign,
+		ServerNo:      conf.ServerNumber,
+	}
+
+	err = AddCheckpointMessage(conf, checkpointReq, signedMessage)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.Checkpoint(context.Background(), checkpointReq)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(config.MapServerNumberToAddress[serverNo])
+	}
+	wg.Wait()
+
+	CheckCheckpointQuorum(conf, checkpoint.SeqNo)
+	return nil
+}
+
+func ReceiveCheckpoint(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+
+	signedMessage, err := VerifyCheckpointMessage(conf, req)
+	if err != nil {
+		return err
+	}
+	if signedMessage.SequenceNumber <= conf.PBFT.GetLowWatermark() {
+		return nil
+	}
+
+	fmt.Printf("received checkpoint for sequence %d from server %d\n", signedMessage.SequenceNumber, req.ServerNo)
+
+	err = AddCheckpointMessage(conf, req, signedMessage)
+	if err != nil {
+		return err
+	}
+
+	CheckCheckpointQuorum(conf, signedMessage.SequenceNumber)
+	return nil
+}
+
+func CheckCheckpointQuorum(conf *config.Config, seqNo int32) {
+	stableDigest, _, err := GetCheckpointProof(conf, seqNo)
+	if err != nil || stableDigest == EmptyString {
+		return
+	}
+
+	checkpoint, err := conf.DataStore.GetCheckpoint(seqNo)
+	if err != nil {
+		fmt.Printf("checkpoint %d is stable but i don't have it yet\n", seqNo)
+		if seqNo >= conf.PBFT.GetNextSequenceNumber() {
+			go func() {
+				err := StateTransfer(conf)
+				if err != nil {
+					fmt.Printf("StateTransfer error: %v\n", err)
+				}
+			}()
+		}
+		return
+	}
+	if checkpoint.Digest != stableDigest {
+		fmt.Printf("my checkpoint %d does not match the stable digest\n", seqNo)
+		return
+	}
+
+	if !conf.PBFT.AdvanceWatermarks(seqNo, conf.WatermarkWindow) {
+		return
+	}
+	fmt.Printf("checkpoint %d is stable, watermarks moved to (%d, %d]\n", seqNo, seqNo, seqNo+conf.WatermarkWindow)
+
+	err = GarbageCollect(conf, seqNo)
+	if err != nil {
+		fmt.Printf("GarbageCollect error: %v\n", err)
+	}
+}
+
+// GetCheckpointProof returns the digest that 2f+1 replicas signed for seqNo along with their messages,
+// or an empty digest if no digest has a quorum yet
+
+func GetCheckpointProof(conf *config.Config, seqNo int32) (string, []*common.PBFTMessage, error) {
+	checkpointMessages, err := conf.DataStore.GetPBFTMessages(GetCheckpointID(seqNo), MessageTypeCheckpoint)
+	if err != nil {
+		return EmptyString, nil, err
+	}
+
+	messagesByDigest := make(map[string][]*common.PBFTMessage)
+	for _, checkpointMessage := range checkpointMessages {
+		signedMessage := &common.SignedMessage{}
+		if err = signedPayload.Unmarshal(checkpointMessage.Payload, signedMessage); err != nil {
+			continue
+		}
+		messagesByDigest[signedMessage.Digest] = append(messagesByDigest[signedMessage.Digest], checkpointMessage)
+	}
+
+	for digest, messages := range messagesByDigest {
+		if int32(len(messages)) >= conf.Majority {
+			return digest, messages, nil
+		}
+	}
+	return EmptyString, nil, nil
+}
+
+// GarbageCollect truncates the log below a stable checkpoint: messages and records of finished txns,
+// older checkpoints and view changes for views already left behind. the outcome of every dropped txn is
+// kept so GetTxnStatus still answers for it
+
+func GarbageCollect(conf *config.Config, stableCheckpoint int32) error {
+	conf.Misbehavior.PruneStatements(stableCheckpoint)
+
+	messagesDeleted, err := conf.DataStore.DeletePBFTMessagesBeforeSequence(stableCheckpoint)
+	if err != nil {
+		return err
+	}
+	txnsDeleted, err := conf.DataStore.DeleteTransactionsBeforeSequence(stableCheckpoint)
+	if err != nil {
+		return err
+	}
+
+	checkpointIDs, err := conf.DataStore.GetPBFTMessageIDs(MessageTypeCheckpoint)
+	if err != nil {
+		return err
+	}
+	for _, checkpointID := range checkpointIDs {
+		var seqNo int32
+		if _, err = fmt.Sscanf(checkpointID, "checkpoint-%d", &seqNo); err != nil || seqNo >= stableCheckpoint {
+			continue
+		}
+		_, err = conf.DataStore.DeletePBFTMessagesByByTxnID(checkpointID)
+		if err != nil {
+			return err
+		}
+	}
+
+	viewChangeIDs, err := conf.DataStore.GetPBFTMessageIDs(MessageTypeViewChange)
+	if err != nil {
+		return err
+	}
+	for _, viewChangeID := range viewChangeIDs {
+		var view int32
+		if _, err = fmt.Sscanf(viewChangeID, "view-change-%d", &view); err != nil || view >= conf.PBFT.GetViewNumber() {
+			continue
+		}
+		_, err = conf.DataStore.DeletePBFTMessagesByByTxnID(viewChangeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = conf.DataStore.DeleteCheckpointsBeforeSequence(stableCheckpoint)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("garbage collected %d messages and %d txns below checkpoint %d\n", messagesDeleted, txnsDeleted, stableCheckpoint)
+	return nil
+}
+
+func VerifyCheckpointMessage(conf *config.Config, req *common.PBFTRequestResponse) (*common.SignedMessage, error) {
+	if !IsServerInCluster(conf, req.ServerNo, conf.ClusterNumber) {
+		return nil, errors.New("checkpoint from server outside cluster")
+	}
+	if IsBlacklisted(conf, req.ServerNo) {
+		return nil, errors.New("checkpoint from blacklisted server")
+	}
+
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[req.ServerNo])
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	signedMessage := &common.SignedMessage{}
+	err = signedPayload.Unmarshal(req.SignedMessage, signedMessage)
+	if err != nil {
+		return nil, err
+	}
+	return signedMessage, nil
+}
+
+// VerifyCheckpointProof checks that messages hold 2f+1 valid checkpoint signatures for the same seqNo and digest
+
+func VerifyCheckpointProof(conf *config.Config, seqNo int32, messages []*common.PBFTMessage) (string, error) {
+	digests := make(map[string]map[int32]bool)
+	for _, checkpointMessage := range messages {
+		signedMessage, err := VerifyCheckpointMessage(conf, &common.PBFTRequestResponse{
+			SignedMessage: checkpointMessage.Payload,
+			Sign:          checkpointMessage.Sign,
+			ServerNo:      checkpointMessage.Sender,
+		})
+		if err != nil || signedMessage.SequenceNumber != seqNo {
+			continue
+		}
+		if digests[signedMessage.Digest] == nil {
+			digests[signedMessage.Digest] = make(map[int32]bool)
+		}
+		digests[signedMessage.Digest][checkpointMessage.Sender] = true
+	}
+
+	for digest, senders := range digests {
+		if int32(len(senders)) >= conf.Majority {
+			return digest, nil
+		}
+	}
+	return EmptyString, fmt.Errorf("not enough valid checkpoint messages for sequence %d", seqNo)
+}
+
+func AddCheckpointMessage(conf *config.Config, req *common.PBFTRequestResponse, signedMessage *common.SignedMessage) error {
+	checkpointID := GetCheckpointID(signedMessage.SequenceNumber)
+	checkpointMessages, err := conf.DataStore.GetPBFTMessages(checkpointID, MessageTypeCheckpoint)
+	if err != nil {
+		return err
+	}
+	for _, checkpointMessage := range checkpointMessages {
+		if checkpointMessage.Sender == req.ServerNo {
+			return nil
+		}
+	}
+
+	pbftMessage := &common.PBFTMessage{
+		TxnID:       checkpointID,
+		MessageType: MessageTypeCheckpoint,
+		Sender:      req.ServerNo,
+		Sign:        req.Sign,
+		Payload:     req.SignedMessage,
+		CreatedAt:   timestamppb.New(time.Now()),
+	}
+	return conf.DataStore.InsertPBFTMessage(pbftMessage)
+}
+
+func GetCheckpointID(seqNo int32) string {
+	return fmt.Sprintf("checkpoint-%d", seqNo)
+}
+
+// GetStateDigest hashes the balances of a checkpoint as deterministic protobuf, the json state only carries
+// them between replicas
+
+func GetStateDigest(balances []datastore.User) string {
+	state := &common.CheckpointState{}
+	for _, balance := range balances {
+		state.Balances = append(state.Balances, &common.UserBalance{User: balance.User, Balance: balance.Balance})
+	}
+	return GetMessageDigest(state)
+}
+
+// GetCheckpointDigest returns the digest of the balances in the json state of a checkpoint
+
+func GetCheckpointDigest(state string) (string, error) {
+	var balances []datastore.User
+	err := json.Unmarshal([]byte(state), &balances)
+	if err != nil {
+		return EmptyString, err
+	}
+	return GetStateDigest(balances), nil
+}
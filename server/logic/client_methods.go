@@ -2,24 +2,142 @@ package logic
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func PrintBalance(ctx context.Context, conf *config.Config, req *common.PrintBalanceRequest) (*common.PrintBalanceResponse, error) {
-	balance, err := datastore.GetBalance(conf.DataStore, req.User)
+	balance, err := conf.DataStore.GetBalance(req.User)
 	if err != nil {
 		return nil, err
 	}
 	return &common.PrintBalanceResponse{Balance: map[int32]float32{conf.ServerNumber: balance}}, nil
 }
 
+// ReadBalance answers a read only request from the local state without ordering it. the balance is tagged
+// with the last sequence number executed here, the client only trusts it if 2f+1 replicas answer with the
+// same balance at the same sequence number. only committed state is read: cross-shard txns still waiting for
+// their 2PC outcome are left out of the balance, and the answer is signed along with the id of the read
+
+func ReadBalance(ctx context.Context, conf *config.Config, req *common.ReadBalanceRequest) (*common.ReadBalanceResponse, error) {
+	if !conf.IsAlive {
+		return nil, errors.New("server dead")
+	}
+	if GetClusterNumber(conf, req.User) != conf.ClusterNumber {
+		return nil, errors.New("user does not belong to this cluster")
+	}
+
+	seqNo := conf.PBFT.GetNextSequenceNumber() - 1
+	balance, err := GetCommittedBalance(conf, req.User)
+	if err != nil {
+		return nil, err
+	}
+	// a txn executed while reading could be half seen, the client falls back to an ordered read then
+	if conf.PBFT.GetNextSequenceNumber()-1 != seqNo {
+		return nil, errors.New("state changed while reading")
+	}
+
+	signedReadBytes, err := signedPayload.Marshal(&common.SignedRead{
+		ReadID:  req.ReadID,
+		User:    req.User,
+		Balance: balance,
+		SeqNo:   seqNo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sign, err := SignMessage(conf.PrivateKey, signedReadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ReadBalanceResponse{
+		User:       req.User,
+		Balance:    balance,
+		SeqNo:      seqNo,
+		ServerNo:   conf.ServerNumber,
+		SignedRead: signedReadBytes,
+		Sign:       sign,
+	}, nil
+}
+
+// GetCommittedBalance returns the balance of user without the changes of the cross-shard txns that have no
+// 2PC outcome yet, those are rolled back if the txn aborts
+
+func GetCommittedBalance(conf *config.Config, user int32) (float32, error) {
+	balances, txns, err := GetBalancesWithTxns(conf)
+	if err != nil {
+		return 0, err
+	}
+
+	users := GetBalanceIndex(balances)
+	for _, txn := range txns {
+		if IsTwoPCUndecided(txn) {
+			TakeOutTxn(conf, balances, users, txn)
+		}
+	}
+
+	i, ok := users[user]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return balances[i].Balance, nil
+}
+
 func PrintDB(ctx context.Context, conf *config.Config, req *common.PrintDBRequest) (*common.PrintDBResponse, error) {
-	executedTxns, err := datastore.GetExecutedTxns(conf.DataStore)
+	executedTxns, err := conf.DataStore.GetExecutedTxns()
 	if err != nil {
 		return nil, err
 	}
 	return &common.PrintDBResponse{Txns: executedTxns}, err
 }
+
+// PrintLocks lists the locks held on this server and the txns queued behind them
+
+func PrintLocks(ctx context.Context, conf *config.Config, req *common.PrintLocksRequest) *common.PrintLocksResponse {
+	resp := &common.PrintLocksResponse{}
+	for _, lock := range conf.LockManager.Locks() {
+		resp.Locks = append(resp.Locks, &common.LockInfo{
+			User:    lock.Item,
+			Holder:  lock.Holder,
+			Waiters: lock.Waiters,
+		})
+	}
+	return resp
+}
+
+// GetTxnStatus reports the state of a txn as logged on this server, or its outcome once the log was truncated
+// past it. StatusNotFound means this server never handled the txn, which is also the case for txns below a
+// checkpoint it installed through state transfer
+
+func GetTxnStatus(ctx context.Context, conf *config.Config, req *common.TxnStatusRequest) (*common.TxnStatusResponse, error) {
+	status := &common.ReplicaTxnStatus{Server: conf.ServerNumber, Status: StatusNotFound}
+	resp := &common.TxnStatusResponse{TxnID: req.TxnID, Replicas: []*common.ReplicaTxnStatus{status}}
+
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
+	if err == nil {
+		status.Status = dbTxn.Status
+		status.SeqNo = dbTxn.SeqNo
+		status.ViewNo = dbTxn.ViewNo
+		status.Error = dbTxn.Error
+		return resp, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	outcome, err := conf.DataStore.GetTxnOutcome(req.TxnID)
+	if err == nil {
+		status.Status = outcome.Status
+		status.SeqNo = outcome.SeqNo
+		status.ViewNo = outcome.ViewNo
+		status.Error = outcome.Error
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+	return resp, nil
+}
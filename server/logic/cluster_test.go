@@ -0,0 +1,177 @@
+package logic_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+	"GolandProjects/2pcbyz-gautamsardana/server/api"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/logic"
+)
+
+const clusterSize = 4
+
+// startCluster runs the replicas of cluster 1 in this process on memstores, each behind its own grpc server on
+// a free port, with a key directory dealt the way keygen does it
+func startCluster(t *testing.T) []*config.Config {
+	t.Helper()
+
+	listeners := make(map[int32]net.Listener)
+	addresses := make(map[int32]string)
+	for serverNo := int32(1); serverNo <= 3*clusterSize; serverNo++ {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if serverNo <= clusterSize {
+			listeners[serverNo] = lis
+		} else {
+			lis.Close()
+		}
+		addresses[serverNo] = lis.Addr().String()
+	}
+	oldAddresses := config.MapServerNumberToAddress
+	config.MapServerNumberToAddress = addresses
+	t.Cleanup(func() { config.MapServerNumberToAddress = oldAddresses })
+
+	keyDir := t.TempDir()
+	clusterKey, thresholdSigners, err := KeyPool.DealThresholdKeys(clusterSize-(clusterSize-1)/3, clusterSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = KeyPool.WriteClusterKey(keyDir, 1, clusterKey); err != nil {
+		t.Fatal(err)
+	}
+	serverAddresses := []string{logic.GetClientAddress()}
+	for serverNo := int32(1); serverNo <= clusterSize; serverNo++ {
+		addr := addresses[serverNo]
+		serverAddresses = append(serverAddresses, addr)
+		signer, err := KeyPool.GenerateSigner(KeyPool.KeyTypeEd25519)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = KeyPool.WritePublicKey(keyDir, addr, signer.Public(), 1); err != nil {
+			t.Fatal(err)
+		}
+		if err = KeyPool.WritePrivateKey(keyDir, addr, signer, 1); err != nil {
+			t.Fatal(err)
+		}
+		thresholdSigner := thresholdSigners[serverNo-1]
+		if err = KeyPool.WriteThresholdPublicKey(keyDir, addr, thresholdSigner.Public()); err != nil {
+			t.Fatal(err)
+		}
+		if err = KeyPool.WriteThresholdKey(keyDir, addr, thresholdSigner); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var confs []*config.Config
+	for serverNo := int32(1); serverNo <= clusterSize; serverNo++ {
+		conf := &config.Config{
+			ServerNumber:       serverNo,
+			ServerTotal:        3 * clusterSize,
+			Majority:           clusterSize - (clusterSize-1)/3,
+			ServerAddresses:    serverAddresses,
+			ClusterSize:        clusterSize,
+			DataItemsPerShard:  10,
+			ViewChangeTimeout:  60000,
+			CheckpointInterval: 1,
+			WatermarkWindow:    40,
+			BatchSize:          1,
+			PipelineWindow:     4,
+			RPCTimeout:         2000,
+			LockWaitTimeout:    1000,
+			AuthMode:           config.AuthSignature,
+			KeyDir:             keyDir,
+			IsAlive:            true,
+		}
+		config.InitiateConfig(conf)
+		config.SetupMemStore(conf)
+		go logic.WorkerProcess(conf)
+
+		s := grpc.NewServer()
+		common.RegisterByz2PCServer(s, &api.Server{Config: conf})
+		go s.Serve(listeners[serverNo])
+		t.Cleanup(s.Stop)
+
+		confs = append(confs, conf)
+	}
+	return confs
+}
+
+func sendTxn(t *testing.T, txn *common.TxnRequest) {
+	t.Helper()
+	conn, err := grpc.NewClient(config.MapServerNumberToAddress[1], grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err = common.NewByz2PCClient(conn).ProcessTxn(ctx, txn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitForReplicas polls every replica until check passes on all of them
+func waitForReplicas(t *testing.T, confs []*config.Config, check func(conf *config.Config) error) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for _, conf := range confs {
+		for {
+			err := check(conf)
+			if err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("server %d: %v", conf.ServerNumber, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestClusterExecutesIntraShardTxns(t *testing.T) {
+	confs := startCluster(t)
+
+	txns := []*common.TxnRequest{
+		{TxnID: "txn-1", Sender: 1, Receiver: 2, Amount: 3, ClientID: "client-1", ClientTimestamp: 1},
+		{TxnID: "txn-2", Sender: 2, Receiver: 3, Amount: 5, ClientID: "client-1", ClientTimestamp: 2},
+	}
+	balances := []map[int32]float32{
+		{1: 7, 2: 13, 3: 10, 4: 10},
+		{1: 7, 2: 8, 3: 15, 4: 10},
+	}
+	for i, txn := range txns {
+		sendTxn(t, txn)
+
+		// the leader only sends the commit certificate to the 2f replicas whose commit messages it collected, a
+		// replica left out catches up through state transfer once the checkpoint after the txn is stable. a
+		// replica only moves its watermarks past a checkpoint when its own digest matches the one 2f+1 signed
+		seqNo := int32(i + 1)
+		waitForReplicas(t, confs, func(conf *config.Config) error {
+			if conf.PBFT.GetLowWatermark() < seqNo {
+				return fmt.Errorf("checkpoint %d is not stable, low watermark is %d", seqNo, conf.PBFT.GetLowWatermark())
+			}
+			for user, balance := range balances[i] {
+				got, err := conf.DataStore.GetBalance(user)
+				if err != nil {
+					return err
+				}
+				if got != balance {
+					return fmt.Errorf("balance of user %d is %v, want %v", user, got, balance)
+				}
+			}
+			return nil
+		})
+	}
+}
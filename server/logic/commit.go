@@ -2,14 +2,12 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func SendCommit(conf *config.Config, req *common.TxnRequest, outcome string) error {
@@ -22,27 +20,36 @@ func SendCommit(conf *config.Config, req *common.TxnRequest, outcome string) err
 		messageType = MessageTypeTwoPCCommit
 	}
 
-	commitMessages, err := datastore.GetPBFTMessages(conf.DataStore, req.TxnID, messageType)
+	commitMessages, err := conf.DataStore.GetPBFTMessages(req.TxnID, messageType)
 	if err != nil {
 		return err
 	}
+	if HasFault(conf, FaultForgeCertificate) {
+		commitMessages = ForgeCertificateMessages(conf, req, messageType, commitMessages)
+	}
 	if len(commitMessages) < int(conf.Majority)-1 {
 		return errors.New("not enough commit messages")
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
 		return err
 	}
-
-	GetTxnUpdatedStatusLeader(dbTxn, MessageTypeCommit)
-	req.Status = dbTxn.Status
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+	err = LoadBatchMembers(conf, dbTxn)
 	if err != nil {
 		return err
 	}
 
-	txnBytes, err := json.Marshal(dbTxn)
+	if outcome != EmptyString || !IsSequenceExecuted(conf, dbTxn.SeqNo) {
+		GetTxnUpdatedStatusLeader(dbTxn, MessageTypeCommit)
+		req.Status = dbTxn.Status
+		err = conf.DataStore.UpdateTransactionStatus(dbTxn)
+		if err != nil {
+			return err
+		}
+	}
+
+	txnBytes, err := signedPayload.Marshal(dbTxn)
 	if err != nil {
 		return err
 	}
@@ -53,12 +60,12 @@ func SendCommit(conf *config.Config, req *common.TxnRequest, outcome string) err
 		Messages:       commitMessages,
 	}
 
-	certBytes, err := json.Marshal(cert)
+	certBytes, err := signedPayload.Marshal(cert)
 	if err != nil {
 		return err
 	}
 
-	sign, err := SignMessage(conf.PrivateKey, certBytes)
+	sign, authenticator, err := AuthenticateMessage(conf, certBytes)
 	if err != nil {
 		return err
 	}
@@ -66,36 +73,28 @@ func SendCommit(conf *config.Config, req *common.TxnRequest, outcome string) err
 	commitReq := &common.PBFTRequestResponse{
 		SignedMessage: certBytes,
 		Sign:          sign,
+		Authenticator: authenticator,
 		TxnRequest:    txnBytes,
 		ServerNo:      conf.ServerNumber,
 		Outcome:       outcome,
 	}
 
-	var wg sync.WaitGroup
+	var followers []int32
 	for _, commitMessage := range commitMessages {
-		serverNo := commitMessage.Sender
-		wg.Add(1)
-		go func(serverAddress string) {
-			defer wg.Done()
-			server, err := conf.Pool.GetServer(serverAddress)
-			if err != nil {
-				fmt.Println(err)
-			}
-			_, err = server.Commit(context.Background(), commitReq)
-			if err != nil {
-				fmt.Println(err)
-			}
-
-		}(config.MapServerNumberToAddress[serverNo])
+		followers = append(followers, commitMessage.Sender)
 	}
-	wg.Wait()
+
+	MulticastToQuorum(conf, followers, func(ctx context.Context, _ int32, server common.Byz2PCClient) error {
+		_, err := server.Commit(ctx, commitReq)
+		return err
+	})
 
 	return nil
 }
 
 func ReceiveCommit(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
 	txnReq := &common.TxnRequest{}
-	err := json.Unmarshal(req.TxnRequest, txnReq)
+	err := signedPayload.Unmarshal(req.TxnRequest, txnReq)
 	if err != nil {
 		UpdateTxnFailed(conf, txnReq, err)
 		return err
@@ -118,7 +117,7 @@ func ReceiveCommit(ctx context.Context, conf *config.Config, req *common.PBFTReq
 		return err
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txnReq.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnReq.TxnID)
 	if err != nil {
 		return err
 	}
@@ -140,6 +139,7 @@ func ReceiveCommit(ctx context.Context, conf *config.Config, req *common.PBFTReq
 					return err
 				}
 			}
+			go SendReplyToClient(conf, txnReq)
 		}
 	}
 
@@ -2,42 +2,45 @@ package logic
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func VerifyCommit(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse, txnReq *common.TxnRequest) error {
-	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
-	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
-	if err != nil {
-		return err
-	}
-
-	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	err := VerifyMessage(conf, req.ServerNo, req.SignedMessage, req.Sign, req.Authenticator)
 	if err != nil {
 		return err
 	}
 
 	cert := &common.Certificate{}
-	err = json.Unmarshal(req.SignedMessage, cert)
+	err = signedPayload.Unmarshal(req.SignedMessage, cert)
 	if err != nil {
 		return err
 	}
 
-	validPrepareCount := int32(0)
-	for _, prepareMessage := range cert.Messages {
-		payload, _ := base64.StdEncoding.DecodeString(prepareMessage.Payload)
-		sign, _ := base64.StdEncoding.DecodeString(prepareMessage.Sign)
+	RecordStatement(conf, &common.SignedStatement{
+		Sender:        req.ServerNo,
+		StatementType: StatementCertificate,
+		Payload:       req.SignedMessage,
+		Sign:          req.Sign,
+	})
+	ShareStatementOnMismatch(conf, req.ServerNo, cert)
 
+	// a quorum counts replicas, not messages, so a replica repeated in the certificate counts once
+	senders := make(map[int32]bool)
+	for _, prepareMessage := range cert.Messages {
+		if senders[prepareMessage.Sender] || !IsServerInCluster(conf, prepareMessage.Sender, conf.ClusterNumber) ||
+			len(prepareMessage.Sign) == 0 {
+			continue
+		}
 		verifyReq := &common.PBFTRequestResponse{
-			SignedMessage: payload,
-			Sign:          sign,
+			SignedMessage: prepareMessage.Payload,
+			Sign:          prepareMessage.Sign,
+			Authenticator: prepareMessage.Authenticator,
 			ServerNo:      prepareMessage.Sender,
 		}
 
@@ -46,10 +49,10 @@ func VerifyCommit(ctx context.Context, conf *config.Config, req *common.PBFTRequ
 			fmt.Println(err)
 			continue
 		}
-		validPrepareCount++
+		senders[prepareMessage.Sender] = true
 	}
 
-	if validPrepareCount < conf.Majority-1 {
+	if len(senders) < int(conf.Majority)-1 {
 		return errors.New("not enough valid prepares")
 	}
 
@@ -58,13 +61,13 @@ func VerifyCommit(ctx context.Context, conf *config.Config, req *common.PBFTRequ
 
 func AddCommitMessages(conf *config.Config, req *common.PBFTRequestResponse) error {
 	cert := &common.Certificate{}
-	err := json.Unmarshal(req.SignedMessage, cert)
+	err := signedPayload.Unmarshal(req.SignedMessage, cert)
 	if err != nil {
 		return err
 	}
 
 	for _, prepareRequest := range cert.Messages {
-		err = datastore.InsertPBFTMessage(conf.DataStore, prepareRequest)
+		err = conf.DataStore.InsertPBFTMessage(prepareRequest)
 		if err != nil {
 			return err
 		}
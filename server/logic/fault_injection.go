@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// the behaviors a byzantine replica can be set up with through UpdateServerState, a byzantine replica
+// without any of them keeps refusing to take part in pbft rounds
+
+const (
+	FaultEquivocate       = "equivocate"
+	FaultWrongDigest      = "wrong-digest"
+	FaultForgeCertificate = "forge-certificate"
+	FaultDelay            = "delay"
+	FaultDrop             = "drop"
+	FaultDoubleVote       = "double-vote"
+	FaultLieSync          = "lie-sync"
+	FaultBadCombine       = "bad-combine"
+)
+
+func HasFault(conf *config.Config, behavior string) bool {
+	return conf.IsByzantine && conf.Faults.HasFault(behavior)
+}
+
+// IsCrashByzantine reports whether this replica is byzantine without any behavior injected, it then fails
+// the pbft rounds it is part of like a crashed replica would
+
+func IsCrashByzantine(conf *config.Config) bool {
+	return conf.IsByzantine && !conf.Faults.HasAnyFault()
+}
+
+// DelayOrDropMessage holds back an outgoing message if this replica delays messages and reports whether it
+// drops the message instead of sending it
+
+func DelayOrDropMessage(conf *config.Config) bool {
+	if HasFault(conf, FaultDelay) {
+		time.Sleep(conf.Faults.GetDelay())
+	}
+	if HasFault(conf, FaultDrop) && rand.Float32() < conf.Faults.GetDropRate() {
+		fmt.Printf("fault injection: dropping outgoing message\n")
+		return true
+	}
+	return false
+}
+
+// GetSignedDigest returns the digest this replica signs for a request, which is not the digest of the request
+// if it signs wrong digests
+
+func GetSignedDigest(conf *config.Config, digest string) string {
+	if !HasFault(conf, FaultWrongDigest) {
+		return digest
+	}
+	wrongDigest := sha256.Sum256([]byte("byzantine-" + digest))
+	return fmt.Sprintf("%x", wrongDigest[:])
+}
+
+// GetEquivocatedTxn returns a request that conflicts with req, an equivocating leader proposes it to half
+// of its followers under the same view and sequence number as req
+
+func GetEquivocatedTxn(req *common.TxnRequest) *common.TxnRequest {
+	equivocatedTxn := proto.Clone(req).(*common.TxnRequest)
+	equivocatedTxn.Amount++
+	if len(equivocatedTxn.Legs) > 0 {
+		equivocatedTxn.Legs[0].Amount++
+	}
+	equivocatedTxn.Digest = GetTxnDigest(equivocatedTxn)
+	return equivocatedTxn
+}
+
+// ForgeCertificateMessages fills messages up with a message for every other server of the cluster that has
+// not sent one, all of them signed by this replica. the forged certificate looks like a quorum to anyone
+// who only counts its messages
+
+func ForgeCertificateMessages(conf *config.Config, req *common.TxnRequest, messageType string, messages []*common.PBFTMessage) []*common.PBFTMessage {
+	payload, err := signedPayload.Marshal(&common.SignedMessage{
+		ViewNumber:     req.ViewNo,
+		SequenceNumber: req.SeqNo,
+		Digest:         req.Digest,
+	})
+	if err != nil {
+		return messages
+	}
+	sign, err := SignMessage(conf.PrivateKey, payload)
+	if err != nil {
+		return messages
+	}
+
+	senders := make(map[int32]bool)
+	for _, message := range messages {
+		senders[message.Sender] = true
+	}
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber || senders[serverNo] {
+			continue
+		}
+		messages = append(messages, &common.PBFTMessage{
+			TxnID:       req.TxnID,
+			MessageType: messageType,
+			Sender:      serverNo,
+			Sign:        sign,
+			Payload:     payload,
+			CreatedAt:   timestamppb.New(time.Now()),
+		})
+	}
+	fmt.Printf("fault injection: forged %s certificate for txn %s\n", messageType, req.TxnID)
+	return messages
+}
+
+// LieInStateTransfer changes the balances and txns sent to a lagging replica, leaving their certificates
+// as they are
+
+func LieInStateTransfer(stMessage *common.StateTransferMessage) {
+	if stMessage.State != EmptyString {
+		var users []datastore.User
+		if err := json.Unmarshal([]byte(stMessage.State), &users); err == nil {
+			for i := range users {
+				users[i].Balance += 100
+			}
+			state, _ := json.Marshal(users)
+			stMessage.State = string(state)
+		}
+	}
+	for _, cert := range stMessage.CommittedTxns {
+		cert.Txn.Amount++
+	}
+	fmt.Printf("fault injection: lying in state transfer response\n")
+}
@@ -2,27 +2,29 @@ package logic
 
 import (
 	"context"
-	"crypto"
-	"crypto/rand"
-	"crypto/rsa"
 	"crypto/sha256"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"math"
+	"sort"
 	"time"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 const (
 	TypeIntraShard         = "IntraShard"
 	TypeCrossShardSender   = "CrossShard-Sender"
 	TypeCrossShardReceiver = "CrossShard-Receiver"
+	TypeNoOp               = "No-Op"
+	TypeBatch              = "Batch"
+	TypeTwoPCAbortVote     = "TwoPC-Abort-Vote"
+	TypeReadOnly           = "Read-Only"
 
 	StatusInit           = "Init"
 	StatusPrePrepared    = "Pre-Prepared"
@@ -35,11 +37,17 @@ const (
 	StatusAborted        = "Aborted"
 	StatusFailed         = "Failed"
 	StatusExecuted       = "Executed"
+	StatusNotFound       = "Not-Found"
 
 	OutcomeCommit = "Commit"
 	OutcomeAbort  = "Abort"
 
+	RoleCoordinator = "Coordinator"
+	RoleParticipant = "Participant"
+
 	EmptyString = ""
+
+	InitialBalance = 10
 )
 
 const (
@@ -47,6 +55,11 @@ const (
 	MessageTypePrepare    = "Prepare"
 	MessageTypeCommit     = "Commit"
 
+	MessageTypeViewChange = "View-Change"
+	MessageTypeCheckpoint = "Checkpoint"
+
+	MessageTypeAbortVoteShare = "Abort-Vote-Share"
+
 	MessageTypeTwoPCPrePrepare = "TwoPC-Pre-Prepare"
 	MessageTypeTwoPCPrepare    = "TwoPC-Prepare"
 	MessageTypeTwoPCCommit     = "TwoPC-Commit"
@@ -55,35 +68,38 @@ const (
 	MessageTypeTwoPCPrepareFromParticipant = "TwoPC-Prepare-Participant"
 	MessageTypeTwoPCCommitFromCoordinator  = "TwoPC-Commit-Coordinator"
 	MessageTypeTwoPCCommitFromParticipant  = "TwoPC-Commit-Participant"
+	MessageTypeTwoPCAbortFromParticipant   = "TwoPC-Abort-Participant"
 )
 
-func SignMessage(privateKey *rsa.PrivateKey, message []byte) ([]byte, error) {
-	hash := sha256.Sum256(message)
-	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash[:])
-	if err != nil {
-		return nil, err
-	}
-	return signature, nil
+func SignMessage(signer KeyPool.Signer, message []byte) ([]byte, error) {
+	return signer.Sign(message)
 }
 
-func VerifySignature(publicKey *rsa.PublicKey, message, signature []byte) error {
-	hash := sha256.Sum256(message)
-	err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature)
-	if err != nil {
-		return fmt.Errorf("signature verification failed: %v", err)
-	}
-	return nil
+func VerifySignature(publicKey KeyPool.Verifier, message, signature []byte) error {
+	return publicKey.Verify(message, signature)
 }
 
 func GetTxnType(conf *config.Config, req *common.TxnRequest) string {
-	senderCluster := math.Ceil(float64(req.Sender) / float64(conf.DataItemsPerShard))
-	receiverCluster := math.Ceil(float64(req.Receiver) / float64(conf.DataItemsPerShard))
+	// a read only request is typed by the client, it moves no money
+	if req.Type == TypeReadOnly {
+		return TypeReadOnly
+	}
+
+	senderCluster := GetClusterNumber(conf, req.Sender)
+	receivesHere, receivesElsewhere := false, false
+	for _, leg := range GetTxnLegs(req) {
+		if GetClusterNumber(conf, leg.Receiver) == conf.ClusterNumber {
+			receivesHere = true
+		} else {
+			receivesElsewhere = true
+		}
+	}
 
-	if conf.ClusterNumber == int32(senderCluster) && conf.ClusterNumber == int32(receiverCluster) {
+	if conf.ClusterNumber == senderCluster && !receivesElsewhere {
 		return TypeIntraShard
-	} else if conf.ClusterNumber == int32(senderCluster) {
+	} else if conf.ClusterNumber == senderCluster {
 		return TypeCrossShardSender
-	} else if conf.ClusterNumber == int32(receiverCluster) {
+	} else if receivesHere {
 		return TypeCrossShardReceiver
 	}
 	fmt.Printf("error: invalid txn type\n")
@@ -91,8 +107,14 @@ func GetTxnType(conf *config.Config, req *common.TxnRequest) string {
 }
 
 func ValidateBalance(conf *config.Config, req *common.TxnRequest) error {
+	for _, txn := range req.Batch {
+		if err := ValidateBalance(conf, txn); err != nil {
+			return err
+		}
+	}
+
 	if req.Type == TypeIntraShard || req.Type == TypeCrossShardSender {
-		balance, err := datastore.GetBalance(conf.DataStore, req.Sender)
+		balance, err := conf.DataStore.GetBalance(req.Sender)
 		if err != nil {
 			return err
 		}
@@ -104,17 +126,32 @@ func ValidateBalance(conf *config.Config, req *common.TxnRequest) error {
 }
 
 func GetTxnDigest(req *common.TxnRequest) string {
-	txn := &datastore.Txn{
+	if req.Type == TypeBatch {
+		return GetBatchDigest(req)
+	}
+	if req.Type == TypeTwoPCAbortVote {
+		return GetTwoPCVoteDigest(req, OutcomeAbort)
+	}
+
+	return GetMessageDigest(&common.TxnDigest{
 		Sender:   req.Sender,
 		Receiver: req.Receiver,
 		Amount:   req.Amount,
-	}
-	requestBytes, _ := json.Marshal(txn)
+		Legs:     req.Legs,
+		ReadOnly: req.Type == TypeReadOnly,
+
+		ClientID:        req.ClientID,
+		ClientTimestamp: req.ClientTimestamp,
+	})
+}
+
+// GetMessageDigest hashes the deterministic protobuf encoding of message, the same bytes a signed payload carries
 
-	digest := sha256.Sum256(requestBytes)
-	dHex := fmt.Sprintf("%x", digest[:])
+func GetMessageDigest(message proto.Message) string {
+	messageBytes, _ := signedPayload.Marshal(message)
 
-	return dHex
+	digest := sha256.Sum256(messageBytes)
+	return fmt.Sprintf("%x", digest[:])
 }
 
 func HandlePBFTResponse(conf *config.Config, resp *common.PBFTRequestResponse, messageType string) {
@@ -124,71 +161,178 @@ func HandlePBFTResponse(conf *config.Config, resp *common.PBFTRequestResponse, m
 	}
 
 	txnReq := &common.TxnRequest{}
-	err := json.Unmarshal(resp.TxnRequest, txnReq)
+	err := signedPayload.Unmarshal(resp.TxnRequest, txnReq)
 	if err != nil {
 		fmt.Printf("HandlePBFTResponse: error %v\n", err)
 		return
 	}
 
 	fmt.Printf("received %s response from server %d for txn request: %v\n", messageType, resp.ServerNo, txnReq.TxnID)
+	if IsBlacklisted(conf, resp.ServerNo) {
+		fmt.Printf("HandlePBFTResponse: server %d is blacklisted\n", resp.ServerNo)
+		return
+	}
 
 	pbftMessage := &common.PBFTMessage{
-		TxnID:       txnReq.TxnID,
-		MessageType: messageType,
-		Sender:      resp.ServerNo,
-		Sign:        base64.StdEncoding.EncodeToString(resp.Sign),
-		Payload:     base64.StdEncoding.EncodeToString(resp.SignedMessage),
-		CreatedAt:   timestamppb.New(time.Now()),
+		TxnID:          txnReq.TxnID,
+		MessageType:    messageType,
+		Sender:         resp.ServerNo,
+		Sign:           resp.Sign,
+		Payload:        resp.SignedMessage,
+		Authenticator:  resp.Authenticator,
+		ThresholdShare: resp.ThresholdShare,
+		CreatedAt:      timestamppb.New(time.Now()),
 	}
 
-	err = datastore.InsertPBFTMessage(conf.DataStore, pbftMessage)
+	err = conf.DataStore.InsertPBFTMessage(pbftMessage)
 	if err != nil {
 		fmt.Printf("HandlePBFTResponse: error %v\n", err)
 	}
 }
 
-func AcquireLockWithAbort(conf *config.Config, req *common.TxnRequest) error {
-	if req.Type == TypeIntraShard || req.Type == TypeCrossShardSender {
-		if !conf.UserLocks[req.Sender%conf.DataItemsPerShard].TryLock() {
-			return errors.New("lock not available for sender")
+// MulticastToQuorum calls send on every server in parallel, each call with its own timeout, and returns the
+// number of successful calls as soon as 2f of them succeed or all of them are done; slower servers are left
+// to finish in the background
+
+func MulticastToQuorum(conf *config.Config, servers []int32, send func(ctx context.Context, serverNo int32, server common.Byz2PCClient) error) int {
+	results := make(chan error, len(servers))
+	for _, serverNo := range servers {
+		if IsBlacklisted(conf, serverNo) {
+			results <- errors.New("server blacklisted")
+			continue
 		}
+		go func(serverNo int32) {
+			if DelayOrDropMessage(conf) {
+				results <- errors.New("message dropped")
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), GetRPCTimeout(conf))
+			defer cancel()
+
+			server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[serverNo])
+			if err != nil {
+				results <- err
+				return
+			}
+			results <- send(ctx, serverNo, server)
+		}(serverNo)
 	}
-	if req.Type == TypeIntraShard || req.Type == TypeCrossShardReceiver {
-		if !conf.UserLocks[req.Receiver%conf.DataItemsPerShard].TryLock() {
-			return errors.New("lock not available for receiver")
+
+	succeeded := 0
+	for i := 0; i < len(servers) && succeeded < int(conf.Majority)-1; i++ {
+		err := <-results
+		if err != nil {
+			fmt.Println(err)
+			continue
 		}
+		succeeded++
 	}
-	return nil
+	return succeeded
 }
 
-func AcquireLock(conf *config.Config, req *common.TxnRequest) {
-	if req.Type == TypeIntraShard || req.Type == TypeCrossShardSender {
-		conf.UserLocks[req.Sender%conf.DataItemsPerShard].Lock()
-		fmt.Printf("acquired lock for sender %d\n", req.Sender)
+func GetRPCTimeout(conf *config.Config) time.Duration {
+	return time.Duration(conf.RPCTimeout) * time.Millisecond
+}
+
+func AcquireLockWithAbort(conf *config.Config, req *common.TxnRequest) error {
+	locks := GetTxnLocks(conf, req)
+	for i, lock := range locks {
+		if !conf.LockManager.TryAcquire(lock.User, lock.Txn.TxnID, GetTxnTimestamp(lock.Txn)) {
+			for _, acquired := range locks[:i] {
+				conf.LockManager.Release(acquired.User, acquired.Txn.TxnID)
+			}
+			return fmt.Errorf("lock not available for user %d", lock.User)
+		}
 	}
+	return nil
+}
+
+// AcquireLock locks every user req touches in this cluster, waiting in line for each of them; if one
+// wait times out the locks taken so far are given back and the txn should fail
+
+func AcquireLock(conf *config.Config, req *common.TxnRequest) error {
+	return AcquireTxnLocks(conf, req, false)
+}
+
+// AcquireLockOrDie is used by the leader when it admits a txn, which is where conflicting txns race for the
+// same users; a txn younger than one it would wait for is failed instead (wait-die). the leader of a
+// participant cluster admits through it as well, with the timestamp the coordinator gave the txn
+
+func AcquireLockOrDie(conf *config.Config, req *common.TxnRequest) error {
+	return AcquireTxnLocks(conf, req, true)
+}
 
-	if req.Type == TypeIntraShard || req.Type == TypeCrossShardReceiver {
-		conf.UserLocks[req.Receiver%conf.DataItemsPerShard].Lock()
-		fmt.Printf("acquired lock for receiver %d\n", req.Receiver)
+// AcquireTxnLocks takes the locks in ascending user order, including those of batch members, so two txns
+// locking the same users never wait for each other in opposite orders
+
+func AcquireTxnLocks(conf *config.Config, req *common.TxnRequest, waitDie bool) error {
+	locks := GetTxnLocks(conf, req)
+	for i, lock := range locks {
+		var err error
+		if waitDie {
+			err = conf.LockManager.AcquireOrDie(lock.User, lock.Txn.TxnID, GetTxnTimestamp(lock.Txn))
+		} else {
+			err = conf.LockManager.Acquire(lock.User, lock.Txn.TxnID, GetTxnTimestamp(lock.Txn))
+		}
+		if err != nil {
+			for _, acquired := range locks[:i] {
+				conf.LockManager.Release(acquired.User, acquired.Txn.TxnID)
+			}
+			return fmt.Errorf("could not lock user %d: %v", lock.User, err)
+		}
+		fmt.Printf("acquired lock for user %d\n", lock.User)
 	}
+	return nil
 }
 
 func ReleaseLock(conf *config.Config, req *common.TxnRequest) {
+	for _, lock := range GetTxnLocks(conf, req) {
+		if conf.LockManager.Release(lock.User, lock.Txn.TxnID) {
+			fmt.Printf("released lock for user %d\n", lock.User)
+		}
+	}
+}
+
+type TxnLock struct {
+	User int32
+	Txn  *common.TxnRequest
+}
+
+// GetTxnLocks returns the users of this cluster req and its batch members read or write, sorted by user
+
+func GetTxnLocks(conf *config.Config, req *common.TxnRequest) []TxnLock {
+	var locks []TxnLock
+	for _, txn := range req.Batch {
+		locks = append(locks, GetTxnLocks(conf, txn)...)
+	}
+
 	if req.Type == TypeIntraShard || req.Type == TypeCrossShardSender {
-		conf.UserLocks[req.Sender%conf.DataItemsPerShard].Unlock()
-		fmt.Printf("released lock for sender %d\n", req.Sender)
+		locks = append(locks, TxnLock{User: req.Sender, Txn: req})
+	}
+	if IsClientTxnType(req.Type) {
+		for _, leg := range GetLocalLegs(conf, req) {
+			locks = append(locks, TxnLock{User: leg.Receiver, Txn: req})
+		}
 	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].User < locks[j].User })
+	return locks
+}
+
+// GetTxnTimestamp is the wait-die priority of a txn, stamped once by the leader of its sender cluster
+// and carried along to every replica and participant cluster
 
-	if req.Type == TypeIntraShard || req.Type == TypeCrossShardReceiver {
-		conf.UserLocks[req.Receiver%conf.DataItemsPerShard].Unlock()
-		fmt.Printf("released lock for receiver %d\n", req.Receiver)
+func GetTxnTimestamp(req *common.TxnRequest) time.Time {
+	if req.CreatedAt == nil {
+		return time.Time{}
 	}
+	return req.CreatedAt.AsTime()
 }
 
 func UpdateTxnFailed(conf *config.Config, req *common.TxnRequest, err error) {
 	req.Status = StatusFailed
 	req.Error = err.Error()
-	err = datastore.UpdateTransactionStatus(conf.DataStore, req)
+	err = conf.DataStore.UpdateTransactionStatus(req)
 	if err != nil {
 		fmt.Println("Update transaction error:", err)
 	}
@@ -197,23 +341,84 @@ func UpdateTxnFailed(conf *config.Config, req *common.TxnRequest, err error) {
 func InsertFailedTxn(conf *config.Config, req *common.TxnRequest, err error) {
 	req.Status = StatusFailed
 	req.Error = err.Error()
-	err = datastore.InsertTransaction(conf.DataStore, req)
+	err = conf.DataStore.InsertTransaction(req)
 	if err != nil {
 		fmt.Println("Update transaction error:", err)
 	}
 }
 
 func SendReplyToClient(conf *config.Config, txn *common.TxnRequest) {
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txn.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txn.TxnID)
+	if err != nil {
+		fmt.Println("SendReplyToClient error:", err)
+		return
+	}
+
+	response, err := GetSignedReply(conf, dbTxn, 0)
 	if err != nil {
 		fmt.Println("SendReplyToClient error:", err)
+		return
+	}
+	err = CacheReply(conf, response)
+	if err != nil {
+		fmt.Println("CacheReply error:", err)
+	}
+	SendResponseToClient(conf, response)
+}
+
+// SendReadReplyToClient answers an ordered read with the balance read when it was executed
+
+func SendReadReplyToClient(conf *config.Config, txn *common.TxnRequest, balance float32) {
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txn.TxnID)
+	if err != nil {
+		fmt.Println("SendReadReplyToClient error:", err)
+		return
+	}
+
+	response, err := GetSignedReply(conf, dbTxn, balance)
+	if err != nil {
+		fmt.Println("SendReadReplyToClient error:", err)
+		return
+	}
+	err = CacheReply(conf, response)
+	if err != nil {
+		fmt.Println("CacheReply error:", err)
 	}
+	SendResponseToClient(conf, response)
+}
 
-	response := &common.ProcessTxnResponse{
-		Txn:    dbTxn,
-		Status: dbTxn.Status,
-		Error:  dbTxn.Error,
+// GetSignedReply signs the outcome of txn for the client, which accepts it once f+1 replicas sent matching ones.
+// balance is only set for read only txns
+
+func GetSignedReply(conf *config.Config, txn *common.TxnRequest, balance float32) (*common.ProcessTxnResponse, error) {
+	signedReplyBytes, err := signedPayload.Marshal(&common.SignedReply{
+		TxnID:           txn.TxnID,
+		ClientID:        txn.ClientID,
+		ClientTimestamp: txn.ClientTimestamp,
+		Status:          txn.Status,
+		Error:           txn.Error,
+		Balance:         balance,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sign, err := SignMessage(conf.PrivateKey, signedReplyBytes)
+	if err != nil {
+		return nil, err
 	}
+
+	return &common.ProcessTxnResponse{
+		Txn:         txn,
+		Status:      txn.Status,
+		Error:       txn.Error,
+		ServerNo:    conf.ServerNumber,
+		SignedReply: signedReplyBytes,
+		Sign:        sign,
+		Balance:     balance,
+	}, nil
+}
+
+func SendResponseToClient(conf *config.Config, response *common.ProcessTxnResponse) {
 	fmt.Printf("sending reply back to client txn with resp: %v\n", response)
 
 	client, err := conf.Pool.GetServer(GetClientAddress())
@@ -232,11 +437,55 @@ func GetClientAddress() string {
 }
 
 func GetLeaderNumber(conf *config.Config, clusterNumber int32) int32 {
+	return GetLeaderNumberForView(conf, clusterNumber, conf.PBFT.GetViewNumber())
+}
+
+func GetLeaderNumberForView(conf *config.Config, clusterNumber, viewNumber int32) int32 {
 	servers := conf.MapClusterToServers[clusterNumber]
-	leaderIndex := (conf.PBFT.GetViewNumber() - 1) % int32(len(servers))
+	leaderIndex := (viewNumber - 1) % int32(len(servers))
 	return servers[leaderIndex]
 }
 
+func GetClusterNumber(conf *config.Config, user int32) int32 {
+	return int32(math.Ceil(float64(user) / float64(conf.DataItemsPerShard)))
+}
+
+// IsInternalTxn reports whether txn was created by the replicas themselves rather than sent by a client,
+// its type is then set by the leader and never derived from the users it touches
+func IsInternalTxn(txn *common.TxnRequest) bool {
+	return txn.Type == TypeNoOp || txn.Type == TypeBatch || txn.Type == TypeTwoPCAbortVote
+}
+
+func GetServerClusterNumber(conf *config.Config, serverNo int32) int32 {
+	for cluster, servers := range conf.MapClusterToServers {
+		for _, server := range servers {
+			if server == serverNo {
+				return cluster
+			}
+		}
+	}
+	return 0
+}
+
+func GetFaultTolerance(conf *config.Config) int32 {
+	return (conf.Majority - 1) / 2
+}
+
+func IsServerInCluster(conf *config.Config, serverNo, clusterNumber int32) bool {
+	for _, server := range conf.MapClusterToServers[clusterNumber] {
+		if server == serverNo {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSequenceExecuted reports whether the local worker has already executed seqNo, which happens
+// when a new primary re-proposes sequence numbers from an earlier view.
+func IsSequenceExecuted(conf *config.Config, seqNo int32) bool {
+	return seqNo < conf.PBFT.GetNextSequenceNumber()
+}
+
 func GetTxnUpdatedStatusLeader(txn *common.TxnRequest, messageType string) {
 	switch messageType {
 	case MessageTypePrePrepare:
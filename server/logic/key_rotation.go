@@ -0,0 +1,214 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+const KeySyncInterval = 10 * time.Second
+
+// a rotation replaces the key pair of a server while it keeps running. the new public key is announced to
+// every other node signed with the old key, so only the holder of the old key can rotate it, and carries a
+// version that has to grow so an old announcement cannot be replayed. the new key pair and the announcement
+// are written to the key directory first, so a rotation needs one, and the server only switches to the new
+// key once the announcement went out. nodes keep the older versions to verify what was signed before, and
+// the ones that missed the announcement fetch it later in SyncKeys
+
+func RotateKey(ctx context.Context, conf *config.Config, req *common.RotateKeyRequest) (*common.KeyAnnouncement, error) {
+	if !conf.IsAlive {
+		return nil, errors.New("server dead")
+	}
+	if conf.KeyDir == "" {
+		return nil, errors.New("key rotation needs a key_dir to keep the new key in")
+	}
+
+	keyType := req.KeyType
+	if keyType == "" {
+		keyType = conf.PrivateKey.KeyType()
+	}
+	signer, err := KeyPool.GenerateSigner(keyType)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := signer.Public().MarshalPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	serverAddr := config.MapServerNumberToAddress[conf.ServerNumber]
+	announcement := &common.KeyAnnouncement{
+		Address:   serverAddr,
+		Version:   conf.PublicKeys.GetKeyVersion(serverAddr) + 1,
+		KeyType:   keyType,
+		PublicKey: publicKey,
+	}
+	payload, err := signedPayload.Marshal(announcement)
+	if err != nil {
+		return nil, err
+	}
+	announcement.Sign, err = SignMessage(conf.PrivateKey, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conf.PrivateKeys.UpdatePrivateKey(serverAddr, signer, announcement.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = conf.PublicKeys.UpdatePublicKey(serverAddr, signer.Public(), announcement.Version, announcement.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	AnnounceKey(conf, announcement)
+
+	conf.PrivateKey.Rotate(signer)
+	err = conf.SessionKeys.Reset(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("rotated to %s key version %d\n", keyType, announcement.Version)
+	return announcement, nil
+}
+
+// AnnounceKey sends announcement to the client and every other server, and waits for them to answer or time out
+
+func AnnounceKey(conf *config.Config, announcement *common.KeyAnnouncement) {
+	var wg sync.WaitGroup
+	for _, serverAddress := range conf.ServerAddresses {
+		if serverAddress == announcement.Address {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), GetRPCTimeout(conf))
+			defer cancel()
+
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.AnnounceKey(ctx, announcement)
+			if err != nil {
+				fmt.Printf("AnnounceKey to %s failed: %v\n", serverAddress, err)
+			}
+		}(serverAddress)
+	}
+	wg.Wait()
+}
+
+// ReceiveKeyAnnouncement switches to the announced key of another server, the session key shared with it was
+// derived from its old key so it is agreed on again
+
+func ReceiveKeyAnnouncement(ctx context.Context, conf *config.Config, req *common.KeyAnnouncement) error {
+	payload, err := signedPayload.Marshal(&common.KeyAnnouncement{
+		Address:   req.Address,
+		Version:   req.Version,
+		KeyType:   req.KeyType,
+		PublicKey: req.PublicKey,
+	})
+	if err != nil {
+		return err
+	}
+	publicKey, err := KeyPool.ParsePublicKey(req.PublicKey)
+	if err != nil {
+		return err
+	}
+	if publicKey.KeyType() != req.KeyType {
+		return errors.New("announced key type does not match the key")
+	}
+
+	err = conf.PublicKeys.AcceptRotatedKey(req.Address, req.Version, req.PublicKey, payload, req.Sign)
+	if err != nil {
+		return err
+	}
+
+	for serverNo, serverAddr := range config.MapServerNumberToAddress {
+		if serverAddr == req.Address {
+			conf.SessionKeys.DeleteKey(serverNo)
+		}
+	}
+	fmt.Printf("accepted %s key version %d of %s\n", req.KeyType, req.Version, req.Address)
+	return nil
+}
+
+// GetKeyAnnouncements returns the announcements this server knows of the keys of req.Address after req.Version
+
+func GetKeyAnnouncements(ctx context.Context, conf *config.Config, req *common.KeyAnnouncementsRequest) (*common.KeyAnnouncementsResponse, error) {
+	resp := &common.KeyAnnouncementsResponse{}
+	for _, keyVersion := range conf.PublicKeys.GetKeyAnnouncements(req.Address, req.Version) {
+		publicKey, err := keyVersion.PublicKey.MarshalPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		resp.Announcements = append(resp.Announcements, &common.KeyAnnouncement{
+			Address:   req.Address,
+			Version:   keyVersion.Version,
+			KeyType:   keyVersion.PublicKey.KeyType(),
+			PublicKey: publicKey,
+			Sign:      keyVersion.Sign,
+		})
+	}
+	return resp, nil
+}
+
+func KeySyncCron(conf *config.Config) {
+	ticker := time.NewTicker(KeySyncInterval)
+	for range ticker.C {
+		SyncKeys(conf)
+	}
+}
+
+// SyncKeys asks every other server for the announcements of its key this server missed, a server that was
+// down or cut off during a rotation would otherwise keep verifying it against the old key
+
+func SyncKeys(conf *config.Config) {
+	if !conf.IsAlive {
+		return
+	}
+
+	for serverNo, serverAddr := range config.MapServerNumberToAddress {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		err := FetchKeyAnnouncements(conf, serverAddr)
+		if err != nil {
+			fmt.Printf("FetchKeyAnnouncements from %s failed: %v\n", serverAddr, err)
+		}
+	}
+}
+
+func FetchKeyAnnouncements(conf *config.Config, serverAddr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), GetRPCTimeout(conf))
+	defer cancel()
+
+	server, err := conf.Pool.GetServer(serverAddr)
+	if err != nil {
+		return err
+	}
+	resp, err := server.GetKeyAnnouncements(ctx, &common.KeyAnnouncementsRequest{
+		Address: serverAddr,
+		Version: conf.PublicKeys.GetKeyVersion(serverAddr),
+	})
+	if err != nil {
+		return err
+	}
+	for _, announcement := range resp.Announcements {
+		err = ReceiveKeyAnnouncement(ctx, conf, announcement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// a replica that signs two different digests for the same view and sequence number hands out a proof of
+// its own misbehavior. every replica keeps the statements it verified, turns such a pair into evidence,
+// gossips the evidence in its cluster and stops counting the accused replica in any quorum, voting it out
+// if it is the leader
+
+const (
+	StatementSignedMessage = "signed-message"
+	StatementCertificate   = "certificate"
+)
+
+func IsBlacklisted(conf *config.Config, serverNo int32) bool {
+	return conf.Misbehavior.IsBlacklisted(serverNo)
+}
+
+// RecordStatement keeps a statement whose signature was verified, and turns it into evidence if its
+// sender signed another digest for the same view and sequence number before
+
+func RecordStatement(conf *config.Config, statement *common.SignedStatement) {
+	// a mac authenticated message proves nothing to anyone else
+	if len(statement.Sign) == 0 {
+		return
+	}
+
+	view, seqNo, digest, err := GetStatementContent(statement)
+	if err != nil {
+		return
+	}
+
+	key := config.StatementKey{Sender: statement.Sender, ViewNumber: view, SequenceNumber: seqNo}
+	previous, isConflicting := conf.Misbehavior.RecordStatement(key, digest, statement)
+	if !isConflicting {
+		return
+	}
+
+	AddMisbehaviorEvidence(conf, &common.MisbehaviorEvidence{
+		Accused:        statement.Sender,
+		ViewNumber:     view,
+		SequenceNumber: seqNo,
+		First:          previous,
+		Second:         statement,
+		Reporter:       conf.ServerNumber,
+	})
+}
+
+// GetStatementContent returns the view, sequence number and digest a statement vouches for. a certificate
+// vouches for the digest all of its messages carry, one with messages for different digests vouches for
+// nothing since a correct leader can be sent a wrong message too
+
+func GetStatementContent(statement *common.SignedStatement) (int32, int32, string, error) {
+	switch statement.StatementType {
+	case StatementSignedMessage:
+		signedMessage := &common.SignedMessage{}
+		err := signedPayload.Unmarshal(statement.Payload, signedMessage)
+		if err != nil {
+			return 0, 0, EmptyString, err
+		}
+		return signedMessage.ViewNumber, signedMessage.SequenceNumber, signedMessage.Digest, nil
+
+	case StatementCertificate:
+		cert := &common.Certificate{}
+		err := signedPayload.Unmarshal(statement.Payload, cert)
+		if err != nil {
+			return 0, 0, EmptyString, err
+		}
+		digest := EmptyString
+		for _, message := range cert.Messages {
+			signedMessage, err := DecodeSignedMessage(message)
+			if err != nil {
+				return 0, 0, EmptyString, err
+			}
+			if digest != EmptyString && signedMessage.Digest != digest {
+				return 0, 0, EmptyString, errors.New("certificate messages carry different digests")
+			}
+			digest = signedMessage.Digest
+		}
+		if digest == EmptyString {
+			return 0, 0, EmptyString, errors.New("empty certificate")
+		}
+		return cert.ViewNumber, cert.SequenceNumber, digest, nil
+	}
+	return 0, 0, EmptyString, errors.New("unknown statement type")
+}
+
+func DecodeSignedMessage(message *common.PBFTMessage) (*common.SignedMessage, error) {
+	signedMessage := &common.SignedMessage{}
+	err := signedPayload.Unmarshal(message.Payload, signedMessage)
+	if err != nil {
+		return nil, err
+	}
+	return signedMessage, nil
+}
+
+// ShareStatementOnMismatch sends the statement this replica holds from sender for the view and sequence
+// number of cert to the rest of the cluster if a message in cert carries another digest. a replica that
+// got a conflicting statement from sender, like the other half of an equivocating pre-prepare, then holds
+// the evidence
+
+func ShareStatementOnMismatch(conf *config.Config, sender int32, cert *common.Certificate) {
+	key := config.StatementKey{Sender: sender, ViewNumber: cert.ViewNumber, SequenceNumber: cert.SequenceNumber}
+	recorded, ok := conf.Misbehavior.GetStatement(key)
+	if !ok {
+		return
+	}
+
+	for _, message := range cert.Messages {
+		signedMessage, err := DecodeSignedMessage(message)
+		if err != nil || signedMessage.Digest == recorded.Digest {
+			continue
+		}
+		fmt.Printf("certificate from server %d does not match its pre-prepare for sequence %d, sharing it\n",
+			sender, cert.SequenceNumber)
+		go MulticastToCluster(conf, func(ctx context.Context, server common.Byz2PCClient) error {
+			_, err := server.ShareStatement(ctx, recorded.Statement)
+			return err
+		})
+		return
+	}
+}
+
+func ReceiveStatement(ctx context.Context, conf *config.Config, statement *common.SignedStatement) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+	if !IsServerInCluster(conf, statement.Sender, conf.ClusterNumber) {
+		return errors.New("statement from server outside cluster")
+	}
+
+	err := VerifyStatementSignature(conf, statement)
+	if err != nil {
+		return err
+	}
+	RecordStatement(conf, statement)
+	return nil
+}
+
+func VerifyStatementSignature(conf *config.Config, statement *common.SignedStatement) error {
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[statement.Sender])
+	if err != nil {
+		return err
+	}
+	return VerifySignature(publicKey, statement.Payload, statement.Sign)
+}
+
+// AddMisbehaviorEvidence blacklists the accused replica, gossips new evidence to the cluster and starts a
+// view change if the accused replica is the leader
+
+func AddMisbehaviorEvidence(conf *config.Config, evidence *common.MisbehaviorEvidence) {
+	if !conf.Misbehavior.AddEvidence(evidence) {
+		return
+	}
+	fmt.Printf("server %d signed conflicting digests for view %d sequence %d, blacklisting it\n",
+		evidence.Accused, evidence.ViewNumber, evidence.SequenceNumber)
+
+	go MulticastToCluster(conf, func(ctx context.Context, server common.Byz2PCClient) error {
+		_, err := server.ReportMisbehavior(ctx, evidence)
+		return err
+	})
+
+	if evidence.Accused != conf.ServerNumber && evidence.Accused == GetLeaderNumber(conf, conf.ClusterNumber) {
+		go InitiateViewChange(conf, conf.PBFT.GetViewNumber()+1)
+	}
+}
+
+func ReceiveMisbehaviorReport(ctx context.Context, conf *config.Config, evidence *common.MisbehaviorEvidence) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+	if !IsServerInCluster(conf, evidence.Accused, conf.ClusterNumber) {
+		return errors.New("evidence against server outside cluster")
+	}
+
+	err := VerifyEvidence(conf, evidence)
+	if err != nil {
+		return err
+	}
+	AddMisbehaviorEvidence(conf, evidence)
+	return nil
+}
+
+// VerifyEvidence checks evidence holds two statements signed by the accused replica for its view and
+// sequence number with different digests
+
+func VerifyEvidence(conf *config.Config, evidence *common.MisbehaviorEvidence) error {
+	if evidence.First == nil || evidence.Second == nil {
+		return errors.New("incomplete evidence")
+	}
+
+	var digests []string
+	for _, statement := range []*common.SignedStatement{evidence.First, evidence.Second} {
+		if statement.Sender != evidence.Accused {
+			return errors.New("statement not signed by the accused server")
+		}
+		err := VerifyStatementSignature(conf, statement)
+		if err != nil {
+			return err
+		}
+		view, seqNo, digest, err := GetStatementContent(statement)
+		if err != nil {
+			return err
+		}
+		if view != evidence.ViewNumber || seqNo != evidence.SequenceNumber {
+			return errors.New("statement for another view or sequence number")
+		}
+		digests = append(digests, digest)
+	}
+
+	if digests[0] == digests[1] {
+		return errors.New("statements do not conflict")
+	}
+	return nil
+}
+
+func GetMisbehaviorEvidence(ctx context.Context, conf *config.Config, req *common.EvidenceRequest) *common.EvidenceResponse {
+	return &common.EvidenceResponse{
+		Evidence:    conf.Misbehavior.GetEvidence(),
+		Blacklisted: conf.Misbehavior.GetBlacklist(),
+	}
+}
+
+// MulticastToCluster calls send on every other server of the cluster and waits for all of them
+
+func MulticastToCluster(conf *config.Config, send func(ctx context.Context, server common.Byz2PCClient) error) {
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), GetRPCTimeout(conf))
+			defer cancel()
+
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			err = send(ctx, server)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(config.MapServerNumberToAddress[serverNo])
+	}
+	wg.Wait()
+}
@@ -3,100 +3,128 @@ package logic
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func SendPrePrepare(conf *config.Config, req *common.TxnRequest, outcome string) error {
 	fmt.Printf("Sending pre-prepare with request: %v\n", req)
 
-	signedReq := &common.SignedMessage{
-		ViewNumber:           req.ViewNo,
-		SequenceNumber:       req.SeqNo,
-		Digest:               req.Digest,
-		LastExecutedSequence: conf.PBFT.GetLastExecutedSequenceNumber(),
-	}
-	signedReqBytes, err := json.Marshal(signedReq)
+	prePrepareReq, err := GetPrePrepareRequest(conf, req, outcome)
 	if err != nil {
 		return err
 	}
-	sign, err := SignMessage(conf.PrivateKey, signedReqBytes)
-	if err != nil {
-		return err
+
+	// an equivocating leader proposes a conflicting request to every other follower
+	equivocatedReq := prePrepareReq
+	if HasFault(conf, FaultEquivocate) {
+		equivocatedReq, err = GetPrePrepareRequest(conf, GetEquivocatedTxn(req), outcome)
+		if err != nil {
+			return err
+		}
 	}
 
-	requestBytes, err := json.Marshal(req)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
-	prePrepareReq := &common.PBFTRequestResponse{
-		SignedMessage: signedReqBytes,
-		Sign:          sign,
-		TxnRequest:    requestBytes,
-		ServerNo:      conf.ServerNumber,
-		Outcome:       outcome,
+	if outcome != EmptyString || !IsSequenceExecuted(conf, dbTxn.SeqNo) {
+		GetTxnUpdatedStatusLeader(dbTxn, MessageTypePrePrepare)
+		req.Status = dbTxn.Status
+		err = conf.DataStore.UpdateTransactionStatus(dbTxn)
+		if err != nil {
+			return err
+		}
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
-	if err != nil {
-		return err
+	var followers []int32
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo != conf.ServerNumber {
+			followers = append(followers, serverNo)
+		}
 	}
 
-	GetTxnUpdatedStatusLeader(dbTxn, MessageTypePrePrepare)
-	req.Status = dbTxn.Status
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+	MulticastToQuorum(conf, followers, func(ctx context.Context, serverNo int32, server common.Byz2PCClient) error {
+		prePrepare := prePrepareReq
+		if serverNo%2 == 0 {
+			prePrepare = equivocatedReq
+		}
+		resp, err := server.PrePrepare(ctx, prePrepare)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return errors.New("empty pre-prepare response")
+		}
+		if resp.Outcome == EmptyString {
+			HandlePBFTResponse(conf, resp, MessageTypePrepare)
+		} else {
+			HandlePBFTResponse(conf, resp, MessageTypeTwoPCPrepare)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+func GetPrePrepareRequest(conf *config.Config, req *common.TxnRequest, outcome string) (*common.PBFTRequestResponse, error) {
+	signedReq := &common.SignedMessage{
+		ViewNumber:           req.ViewNo,
+		SequenceNumber:       req.SeqNo,
+		Digest:               GetSignedDigest(conf, req.Digest),
+		LastExecutedSequence: conf.PBFT.GetLastExecutedSequenceNumber(),
+	}
+	signedReqBytes, err := signedPayload.Marshal(signedReq)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	sign, authenticator, err := AuthenticateMessage(conf, signedReqBytes)
+	if err != nil {
+		return nil, err
 	}
 
-	//todo: send context with a timeout? Handle timeouts in some way (if majority not reached)
-
-	var wg sync.WaitGroup
-	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
-		if serverNo == conf.ServerNumber {
-			continue
-		}
-		wg.Add(1)
-		go func(serverAddress string) {
-			defer wg.Done()
-			server, err := conf.Pool.GetServer(serverAddress)
-			if err != nil {
-				return
-			}
-			resp, err := server.PrePrepare(context.Background(), prePrepareReq)
-			if err != nil || resp == nil {
-				return
-			}
-			if resp.Outcome == EmptyString {
-				HandlePBFTResponse(conf, resp, MessageTypePrepare)
-			} else {
-				HandlePBFTResponse(conf, resp, MessageTypeTwoPCPrepare)
-			}
-		}(config.MapServerNumberToAddress[serverNo])
+	requestBytes, err := signedPayload.Marshal(req)
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
 
-	return nil
+	return &common.PBFTRequestResponse{
+		SignedMessage: signedReqBytes,
+		Sign:          sign,
+		Authenticator: authenticator,
+		TxnRequest:    requestBytes,
+		ServerNo:      conf.ServerNumber,
+		Outcome:       outcome,
+	}, nil
 }
 
 func ReceivePrePrepare(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
 	if !conf.IsAlive {
 		return nil, errors.New("server dead")
 	}
-	if conf.IsByzantine {
+	if IsCrashByzantine(conf) {
 		return nil, errors.New("server byzantine")
 	}
+	if conf.PBFT.IsViewChangeInProgress() {
+		return nil, errors.New("view change in progress")
+	}
 
 	txnReq := &common.TxnRequest{}
-	err := json.Unmarshal(req.TxnRequest, txnReq)
+	err := signedPayload.Unmarshal(req.TxnRequest, txnReq)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Received PrePrepare for request: %v\n", txnReq)
+
+	// a pre-prepare that does not come from the leader of the current view is dropped before it writes or
+	// locks anything, and without marking the txn it names failed
+	err = VerifyPBFTMessage(ctx, conf, req, txnReq, MessageTypePrePrepare)
 	if err != nil {
 		return nil, err
 	}
@@ -107,45 +135,59 @@ func ReceivePrePrepare(ctx context.Context, conf *config.Config, req *common.PBF
 		}
 	}()
 
-	fmt.Printf("Received PrePrepare for request: %v\n", txnReq)
-
-	/*
-		err = SyncIfServerSlow(ctx, conf, req)
-		if err != nil {
-			return nil, err
-		}
-	*/
+	err = SyncIfServerSlow(ctx, conf, req)
+	if err != nil {
+		return nil, err
+	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txnReq.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnReq.TxnID)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
 
 	if dbTxn == nil {
+		if txnReq.Type == TypeBatch {
+			err = VerifyBatch(conf, txnReq)
+			if err != nil {
+				return nil, err
+			}
+		} else if txnReq.Type == TypeTwoPCAbortVote {
+			err = VerifyTwoPCAbortVote(conf, txnReq)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			err = VerifyTxnLegs(txnReq)
+			if err != nil {
+				return nil, err
+			}
+		}
 		txnReq.Status = StatusPrepared
-		err = datastore.InsertTransaction(conf.DataStore, txnReq)
+		err = InsertTxnWithBatch(conf, txnReq)
+		if err != nil {
+			return nil, err
+		}
+		err = AcquireLock(conf, txnReq)
 		if err != nil {
 			return nil, err
 		}
-		AcquireLock(conf, txnReq)
 
 		err = ValidateBalance(conf, txnReq)
 		if err != nil {
 			ReleaseLock(conf, txnReq)
 			return nil, err
 		}
-	} else {
+	} else if req.Outcome != EmptyString || !IsSequenceExecuted(conf, txnReq.SeqNo) {
 		GetTxnUpdatedStatusFollower(dbTxn, MessageTypePrePrepare)
 		txnReq.Status = dbTxn.Status
-		err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+		err = conf.DataStore.UpdateTransactionStatus(dbTxn)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	err = VerifyPBFTMessage(ctx, conf, req, txnReq, MessageTypePrePrepare)
-	if err != nil {
-		return nil, err
+	if req.Outcome == EmptyString && !IsSequenceExecuted(conf, txnReq.SeqNo) {
+		StartRequestTimer(conf, txnReq)
 	}
 
 	fmt.Printf("Sending pre-prepare response for txn:%s\n", txnReq.TxnID)
@@ -2,36 +2,47 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func VerifyPBFTMessage(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse, txnReq *common.TxnRequest, messageType string) error {
-	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
-	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
-	if err != nil {
-		return err
-	}
-	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	err := VerifyMessage(conf, req.ServerNo, req.SignedMessage, req.Sign, req.Authenticator)
 	if err != nil {
 		return err
 	}
 
 	signedMessage := &common.SignedMessage{}
-	err = json.Unmarshal(req.SignedMessage, signedMessage)
+	err = signedPayload.Unmarshal(req.SignedMessage, signedMessage)
 	if err != nil {
 		return err
 	}
+
+	RecordStatement(conf, &common.SignedStatement{
+		Sender:        req.ServerNo,
+		StatementType: StatementSignedMessage,
+		Payload:       req.SignedMessage,
+		Sign:          req.Sign,
+	})
+	if IsBlacklisted(conf, req.ServerNo) {
+		return errors.New("sender is blacklisted")
+	}
+
 	if signedMessage.ViewNumber != conf.PBFT.GetViewNumber() {
 		return errors.New("invalid view number")
 	}
+	if messageType == MessageTypePrePrepare &&
+		req.ServerNo != GetLeaderNumberForView(conf, conf.ClusterNumber, signedMessage.ViewNumber) {
+		return errors.New("pre-prepare not sent by the leader of the view")
+	}
 
-	//if signedMessage.SequenceNumber <= conf.LowWatermark || signedMessage.SequenceNumber > conf.HighWatermark {
-	//	return errors.New("invalid sequence")
-	//}
+	if messageType == MessageTypePrePrepare && req.Outcome == EmptyString &&
+		!conf.PBFT.IsWithinWatermarks(signedMessage.SequenceNumber) {
+		return errors.New("invalid sequence")
+	}
 
 	digest := GetTxnDigest(txnReq)
 	if digest != signedMessage.Digest {
@@ -50,13 +61,31 @@ func VerifyPBFTMessage(ctx context.Context, conf *config.Config, req *common.PBF
 }
 
 func SendPrePrepareResponse(conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
-	sign, err := SignMessage(conf.PrivateKey, req.SignedMessage)
+	if DelayOrDropMessage(conf) {
+		return nil, errors.New("message dropped")
+	}
+
+	signedMsgBytes := req.SignedMessage
+	if HasFault(conf, FaultWrongDigest) {
+		signedMessage := &common.SignedMessage{}
+		err := signedPayload.Unmarshal(req.SignedMessage, signedMessage)
+		if err != nil {
+			return nil, err
+		}
+		signedMessage.Digest = GetSignedDigest(conf, signedMessage.Digest)
+		signedMsgBytes, err = signedPayload.Marshal(signedMessage)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sign, err := SignVote(conf, signedMsgBytes)
 	if err != nil {
 		return nil, err
 	}
 
 	prepareReq := &common.PBFTRequestResponse{
-		SignedMessage: req.SignedMessage,
+		SignedMessage: signedMsgBytes,
 		Sign:          sign,
 		ServerNo:      conf.ServerNumber,
 		TxnRequest:    req.TxnRequest,
@@ -2,14 +2,12 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func SendPrepare(conf *config.Config, req *common.TxnRequest, outcome string) error {
@@ -21,45 +19,54 @@ func SendPrepare(conf *config.Config, req *common.TxnRequest, outcome string) er
 	} else {
 		messageType = MessageTypeTwoPCPrepare
 	}
-	prepareMessages, err := datastore.GetPBFTMessages(conf.DataStore, req.TxnID, messageType)
+	prepareMessages, err := conf.DataStore.GetPBFTMessages(req.TxnID, messageType)
 	if err != nil {
 		return err
 	}
+	if HasFault(conf, FaultForgeCertificate) {
+		prepareMessages = ForgeCertificateMessages(conf, req, messageType, prepareMessages)
+	}
 	if len(prepareMessages) < int(conf.Majority)-1 {
 		return errors.New("not enough prepare messages")
-	} else if conf.IsByzantine {
+	} else if IsCrashByzantine(conf) {
 		return errors.New("server byzantine")
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
 		return err
 	}
-
-	GetTxnUpdatedStatusLeader(dbTxn, MessageTypePrepare)
-	req.Status = dbTxn.Status
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+	err = LoadBatchMembers(conf, dbTxn)
 	if err != nil {
 		return err
 	}
 
+	if outcome != EmptyString || !IsSequenceExecuted(conf, dbTxn.SeqNo) {
+		GetTxnUpdatedStatusLeader(dbTxn, MessageTypePrepare)
+		req.Status = dbTxn.Status
+		err = conf.DataStore.UpdateTransactionStatus(dbTxn)
+		if err != nil {
+			return err
+		}
+	}
+
 	cert := &common.Certificate{
 		ViewNumber:     dbTxn.ViewNo,
 		SequenceNumber: dbTxn.SeqNo,
 		Messages:       prepareMessages,
 	}
 
-	certBytes, err := json.Marshal(cert)
+	certBytes, err := signedPayload.Marshal(cert)
 	if err != nil {
 		return err
 	}
 
-	sign, err := SignMessage(conf.PrivateKey, certBytes)
+	sign, authenticator, err := AuthenticateMessage(conf, certBytes)
 	if err != nil {
 		return err
 	}
 
-	txnBytes, err := json.Marshal(dbTxn)
+	txnBytes, err := signedPayload.Marshal(dbTxn)
 	if err != nil {
 		return err
 	}
@@ -67,41 +74,39 @@ func SendPrepare(conf *config.Config, req *common.TxnRequest, outcome string) er
 	prepareReq := &common.PBFTRequestResponse{
 		SignedMessage: certBytes,
 		Sign:          sign,
+		Authenticator: authenticator,
 		TxnRequest:    txnBytes,
 		ServerNo:      conf.ServerNumber,
 		Outcome:       outcome,
 	}
 
-	var wg sync.WaitGroup
+	var followers []int32
 	for _, prepareMessage := range prepareMessages {
-		serverNo := prepareMessage.Sender
-		wg.Add(1)
-		go func(serverAddress string) {
-			defer wg.Done()
-			server, err := conf.Pool.GetServer(serverAddress)
-			if err != nil {
-				return
-			}
-			resp, err := server.Prepare(context.Background(), prepareReq)
-			if err != nil || resp == nil {
-				fmt.Printf("Prepare failed for request %v: %v\n", prepareReq, err)
-				return
-			}
-			if resp.Outcome == EmptyString {
-				HandlePBFTResponse(conf, resp, MessageTypeCommit)
-			} else {
-				HandlePBFTResponse(conf, resp, MessageTypeTwoPCCommit)
-			}
-		}(config.MapServerNumberToAddress[serverNo])
-	}
-	wg.Wait()
+		followers = append(followers, prepareMessage.Sender)
+	}
+
+	MulticastToQuorum(conf, followers, func(ctx context.Context, _ int32, server common.Byz2PCClient) error {
+		resp, err := server.Prepare(ctx, prepareReq)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return errors.New("empty prepare response")
+		}
+		if resp.Outcome == EmptyString {
+			HandlePBFTResponse(conf, resp, MessageTypeCommit)
+		} else {
+			HandlePBFTResponse(conf, resp, MessageTypeTwoPCCommit)
+		}
+		return nil
+	})
 
 	return nil
 }
 
 func ReceivePrepare(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
 	txnReq := &common.TxnRequest{}
-	err := json.Unmarshal(req.TxnRequest, txnReq)
+	err := signedPayload.Unmarshal(req.TxnRequest, txnReq)
 	if err != nil {
 		return nil, err
 	}
@@ -123,15 +128,17 @@ func ReceivePrepare(ctx context.Context, conf *config.Config, req *common.PBFTRe
 		return nil, err
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txnReq.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnReq.TxnID)
 	if err != nil {
 		return nil, err
 	}
-	GetTxnUpdatedStatusFollower(dbTxn, MessageTypePrepare)
-	txnReq.Status = dbTxn.Status
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
-	if err != nil {
-		return nil, err
+	if req.Outcome != EmptyString || !IsSequenceExecuted(conf, dbTxn.SeqNo) {
+		GetTxnUpdatedStatusFollower(dbTxn, MessageTypePrepare)
+		txnReq.Status = dbTxn.Status
+		err = conf.DataStore.UpdateTransactionStatus(dbTxn)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return SendPrepareResponse(conf, req, txnReq)
 }
@@ -2,30 +2,31 @@ package logic
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func SendPrepareResponse(conf *config.Config, req *common.PBFTRequestResponse, txnRequest *common.TxnRequest) (*common.PBFTRequestResponse, error) {
 	fmt.Printf("Sending prepare response for txn: %v\n", txnRequest)
+	if DelayOrDropMessage(conf) {
+		return nil, errors.New("message dropped")
+	}
 
 	signedMessage := &common.SignedMessage{
 		ViewNumber:     txnRequest.ViewNo,
 		SequenceNumber: txnRequest.SeqNo,
-		Digest:         txnRequest.Digest,
+		Digest:         GetSignedDigest(conf, txnRequest.Digest),
 	}
-	signedMsgBytes, err := json.Marshal(signedMessage)
+	signedMsgBytes, err := signedPayload.Marshal(signedMessage)
 	if err != nil {
 		return nil, err
 	}
 
-	sign, err := SignMessage(conf.PrivateKey, signedMsgBytes)
+	sign, err := SignVote(conf, signedMsgBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -37,36 +38,44 @@ func SendPrepareResponse(conf *config.Config, req *common.PBFTRequestResponse, t
 		TxnRequest:    req.TxnRequest,
 		Outcome:       req.Outcome,
 	}
+	if req.Outcome == EmptyString && IsVoteTransferable(txnRequest) {
+		commitReq.ThresholdShare = GetThresholdShare(conf, txnRequest)
+	}
 
 	return commitReq, nil
 }
 
 func VerifyPrepare(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse, txnReq *common.TxnRequest) error {
-	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
-	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
-	if err != nil {
-		return err
-	}
-
-	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	err := VerifyMessage(conf, req.ServerNo, req.SignedMessage, req.Sign, req.Authenticator)
 	if err != nil {
 		return err
 	}
 
 	cert := &common.Certificate{}
-	err = json.Unmarshal(req.SignedMessage, cert)
+	err = signedPayload.Unmarshal(req.SignedMessage, cert)
 	if err != nil {
 		return err
 	}
 
-	validPrePrepareCount := int32(0)
-	for _, prePrepareMessage := range cert.Messages {
-		payload, _ := base64.StdEncoding.DecodeString(prePrepareMessage.Payload)
-		sign, _ := base64.StdEncoding.DecodeString(prePrepareMessage.Sign)
+	RecordStatement(conf, &common.SignedStatement{
+		Sender:        req.ServerNo,
+		StatementType: StatementCertificate,
+		Payload:       req.SignedMessage,
+		Sign:          req.Sign,
+	})
+	ShareStatementOnMismatch(conf, req.ServerNo, cert)
 
+	// every replica counts once toward the quorum, however often a faulty leader repeats its message
+	senders := make(map[int32]bool)
+	for _, prePrepareMessage := range cert.Messages {
+		if senders[prePrepareMessage.Sender] || !IsServerInCluster(conf, prePrepareMessage.Sender, conf.ClusterNumber) ||
+			len(prePrepareMessage.Sign) == 0 {
+			continue
+		}
 		verifyReq := &common.PBFTRequestResponse{
-			SignedMessage: payload,
-			Sign:          sign,
+			SignedMessage: prePrepareMessage.Payload,
+			Sign:          prePrepareMessage.Sign,
+			Authenticator: prePrepareMessage.Authenticator,
 			ServerNo:      prePrepareMessage.Sender,
 		}
 
@@ -75,10 +84,10 @@ func VerifyPrepare(ctx context.Context, conf *config.Config, req *common.PBFTReq
 			fmt.Println(err)
 			continue
 		}
-		validPrePrepareCount++
+		senders[prePrepareMessage.Sender] = true
 	}
 
-	if validPrePrepareCount < conf.Majority-1 {
+	if len(senders) < int(conf.Majority)-1 {
 		return errors.New("not enough valid pre-prepares")
 	}
 
@@ -87,13 +96,13 @@ func VerifyPrepare(ctx context.Context, conf *config.Config, req *common.PBFTReq
 
 func AddPrepareMessages(conf *config.Config, req *common.PBFTRequestResponse) error {
 	cert := &common.Certificate{}
-	err := json.Unmarshal(req.SignedMessage, cert)
+	err := signedPayload.Unmarshal(req.SignedMessage, cert)
 	if err != nil {
 		return err
 	}
 
 	for _, prepareRequest := range cert.Messages {
-		err = datastore.InsertPBFTMessage(conf.DataStore, prepareRequest)
+		err = conf.DataStore.InsertPBFTMessage(prepareRequest)
 		if err != nil {
 			return err
 		}
@@ -3,24 +3,64 @@ package logic
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func ProcessTxn(ctx context.Context, conf *config.Config, req *common.TxnRequest, isRetry bool) error {
 	fmt.Printf("Received ProcessTxn request: %v\n", req)
 
-	req.Type = GetTxnType(conf, req)
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+	if !isRetry {
+		isAnswered, err := ResendCachedReply(conf, req)
+		if err != nil {
+			return err
+		}
+		if isAnswered {
+			return nil
+		}
+	}
+	if conf.PBFT.IsViewChangeInProgress() || GetLeaderNumber(conf, conf.ClusterNumber) != conf.ServerNumber {
+		return ForwardTxnToLeader(conf, req)
+	}
+
+	if !IsInternalTxn(req) {
+		err := VerifyTxnLegs(req)
+		if err != nil {
+			return err
+		}
+		req.Type = GetTxnType(conf, req)
+	}
 
 	if !isRetry {
-		AcquireLock(conf, req)
+		isDuplicate, err := IsDuplicateRequest(conf, req)
+		if err != nil {
+			return err
+		}
+		if isDuplicate {
+			return nil
+		}
+
+		// the timestamp orders txns for wait-die in every cluster, it is kept at the precision the
+		// transaction table stores so it reads back the same
+		if req.CreatedAt == nil {
+			req.CreatedAt = timestamppb.New(time.Now().Truncate(time.Microsecond))
+		}
+		err = AcquireLockOrDie(conf, req)
+		if err != nil {
+			InsertFailedTxn(conf, req, err)
+			return err
+		}
 	}
 
 	err := ValidateBalance(conf, req)
@@ -30,25 +70,47 @@ func ProcessTxn(ctx context.Context, conf *config.Config, req *common.TxnRequest
 		return err
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
 
 	if dbTxn == nil {
+		if req.Type == TypeIntraShard && conf.BatchSize > 1 {
+			AddToBatch(conf, req)
+			return nil
+		}
+
+		AcquireInstanceSlot(conf)
+		defer ReleaseInstanceSlot(conf)
+
+		seqNo, ok := conf.PBFT.AssignSequenceNumber()
+		if !ok {
+			ReleaseLock(conf, req)
+			return errors.New("sequence number above high watermark")
+		}
 		req.Digest = GetTxnDigest(req)
-		req.SeqNo = conf.PBFT.IncrementSequenceNumber()
+		req.SeqNo = seqNo
 		req.ViewNo = conf.PBFT.GetViewNumber()
 
 		req.Status = StatusInit
-		err = datastore.InsertTransaction(conf.DataStore, req)
+		err = conf.DataStore.InsertTransaction(req)
 		if err != nil {
 			return err
 		}
 	} else {
 		req = dbTxn
+		err = LoadBatchMembers(conf, req)
+		if err != nil {
+			return err
+		}
 		req.Status = StatusInit
-		err = datastore.UpdateTransactionStatus(conf.DataStore, req)
+		err = conf.DataStore.UpdateTransactionStatus(req)
+		if err != nil {
+			return err
+		}
+		req.ViewNo = conf.PBFT.GetViewNumber()
+		err = conf.DataStore.UpdateTransactionView(req)
 		if err != nil {
 			return err
 		}
@@ -83,23 +145,19 @@ func StartConsensus(conf *config.Config, req *common.TxnRequest, outcome string)
 }
 
 func StartTwoPC(conf *config.Config, req *common.TxnRequest) error {
-	fmt.Printf("sending request to participant cluster with request: %v\n", req)
+	fmt.Printf("sending request to participant clusters %v with request: %v\n", GetParticipantClusters(conf, req), req)
 
-	reqBytes, err := json.Marshal(req)
+	reqBytes, err := signedPayload.Marshal(req)
 	if err != nil {
 		return err
 	}
 
-	commitMessages, err := datastore.GetPBFTMessages(conf.DataStore, req.TxnID, MessageTypeCommit)
+	cert, err := GetThresholdCertificate(conf, req, req.TxnID, EmptyString)
 	if err != nil {
 		return err
 	}
 
-	cert := &common.Certificate{
-		Messages: commitMessages,
-	}
-
-	certBytes, err := json.Marshal(cert)
+	certBytes, err := signedPayload.Marshal(cert)
 	if err != nil {
 		return err
 	}
@@ -116,19 +174,18 @@ func StartTwoPC(conf *config.Config, req *common.TxnRequest) error {
 		ServerNo:      conf.ServerNumber,
 	}
 
-	conf.TwoPCLock.Lock()
-	conf.TwoPCTimer[req.TxnID] = time.NewTimer(5 * time.Second)
-	conf.TwoPCChan[req.TxnID] = make(chan *common.PBFTRequestResponse)
-	conf.TwoPCLock.Unlock()
-	go WaitForParticipantResponse(conf, req)
-
-	receiverCluster := math.Ceil(float64(req.Receiver) / float64(conf.DataItemsPerShard))
+	participants := GetParticipantClusters(conf, req)
+	timeouts := StartTwoPCTimers(conf, req.TxnID, participants)
+	go WaitForParticipantResponse(conf, req, timeouts)
 
 	var wg sync.WaitGroup
-	for _, serverNo := range conf.MapClusterToServers[int32(receiverCluster)] {
+	for _, serverNo := range GetClusterServers(conf, participants) {
 		wg.Add(1)
 		go func(serverAddress string) {
 			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
 			server, err := conf.Pool.GetServer(serverAddress)
 			if err != nil {
 				fmt.Println(err)
@@ -144,7 +201,22 @@ func StartTwoPC(conf *config.Config, req *common.TxnRequest) error {
 	return nil
 }
 
+// AcquireInstanceSlot blocks until fewer than PipelineWindow consensus instances started by this leader
+// are still running, so sequence numbers are only handed out inside the window
+
+func AcquireInstanceSlot(conf *config.Config) {
+	conf.InstanceSlots <- struct{}{}
+}
+
+func ReleaseInstanceSlot(conf *config.Config) {
+	<-conf.InstanceSlots
+}
+
 func SendExecuteSignal(conf *config.Config, txnReq *common.TxnRequest) {
+	if IsSequenceExecuted(conf, txnReq.SeqNo) {
+		return
+	}
+
 	conf.PendingTransactionsMutex.Lock()
 	conf.PendingTransactions[txnReq.SeqNo] = txnReq
 	conf.PendingTransactionsMutex.Unlock()
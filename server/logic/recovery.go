@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+)
+
+// RecoverConsensusState rebuilds the pbft state of a restarted replica from its store before it serves any
+// request: the watermarks from the latest stable checkpoint, the view and sequence numbers from the logged
+// txns, committed txns that were not executed yet are queued for the worker again and every txn still in
+// flight takes back its locks. 2PC txns are driven on from there by the 2PC recovery cron
+
+func RecoverConsensusState(conf *config.Config) error {
+	stableCheckpoint, err := GetLatestStableCheckpoint(conf)
+	if err != nil {
+		return err
+	}
+	if stableCheckpoint > 0 {
+		conf.PBFT.AdvanceWatermarks(stableCheckpoint, conf.WatermarkWindow)
+		conf.PBFT.SetExecutedSequenceNumber(stableCheckpoint)
+	}
+
+	dbTxns, err := conf.DataStore.GetTransactionsAfterSequence(0)
+	if err != nil {
+		return err
+	}
+
+	var txns []*common.TxnRequest
+	txnsBySeqNo := make(map[int32]*common.TxnRequest)
+	var maxSeqNo, maxView int32
+	for _, txn := range dbTxns {
+		// batch members are recovered along with their batch
+		if txn.BatchID != EmptyString {
+			continue
+		}
+		txns = append(txns, txn)
+		maxSeqNo = max(maxSeqNo, txn.SeqNo)
+		maxView = max(maxView, txn.ViewNo)
+		if existing, ok := txnsBySeqNo[txn.SeqNo]; !ok || !IsTxnExecutedLocally(existing) {
+			txnsBySeqNo[txn.SeqNo] = txn
+		}
+	}
+
+	if maxView > conf.PBFT.GetViewNumber() {
+		conf.PBFT.InstallView(maxView)
+	}
+
+	executedSeq := conf.PBFT.GetNextSequenceNumber() - 1
+	for {
+		txn, ok := txnsBySeqNo[executedSeq+1]
+		if !ok || !IsTxnExecutedLocally(txn) {
+			break
+		}
+		executedSeq++
+	}
+	conf.PBFT.SetExecutedSequenceNumber(executedSeq)
+	conf.PBFT.SetSequenceNumber(max(maxSeqNo, executedSeq))
+
+	sort.Slice(txns, func(i, j int) bool { return txns[i].SeqNo < txns[j].SeqNo })
+	var requeued, locked int
+	for _, txn := range txns {
+		if IsTwoPCFinished(txn) {
+			continue
+		}
+		err = LoadBatchMembers(conf, txn)
+		if err != nil {
+			return err
+		}
+
+		err = AcquireLockWithAbort(conf, txn)
+		if err != nil {
+			fmt.Printf("could not take back locks of txn %s: %v\n", txn.TxnID, err)
+		} else {
+			locked++
+		}
+
+		if txn.Status == StatusCommitted && !IsSequenceExecuted(conf, txn.SeqNo) {
+			SendExecuteSignal(conf, txn)
+			requeued++
+		}
+	}
+
+	fmt.Printf("recovered view %d, executed sequence %d, last sequence %d, stable checkpoint %d, "+
+		"%d committed txns queued, %d txns relocked\n", conf.PBFT.GetViewNumber(), executedSeq,
+		conf.PBFT.GetSequenceNumber(), stableCheckpoint, requeued, locked)
+	return nil
+}
+
+// GetLatestStableCheckpoint returns the highest checkpoint this replica took that 2f+1 replicas agree on, or
+// 0 if there is none
+
+func GetLatestStableCheckpoint(conf *config.Config) (int32, error) {
+	checkpointIDs, err := conf.DataStore.GetPBFTMessageIDs(MessageTypeCheckpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	var stableCheckpoint int32
+	for _, checkpointID := range checkpointIDs {
+		var seqNo int32
+		if _, err = fmt.Sscanf(checkpointID, "checkpoint-%d", &seqNo); err != nil || seqNo <= stableCheckpoint {
+			continue
+		}
+		stableDigest, _, err := GetCheckpointProof(conf, seqNo)
+		if err != nil || stableDigest == EmptyString {
+			continue
+		}
+		checkpoint, err := conf.DataStore.GetCheckpoint(seqNo)
+		if err != nil || checkpoint.Digest != stableDigest {
+			continue
+		}
+		stableCheckpoint = seqNo
+	}
+	return stableCheckpoint, nil
+}
+
+// IsTxnExecutedLocally reports whether the worker is done with txn, a cross-shard txn counts once its balance
+// changes are applied here even if its 2PC round is still going on
+
+func IsTxnExecutedLocally(txn *common.TxnRequest) bool {
+	switch txn.Status {
+	case StatusExecuted, StatusAborted, Status2PCPending, Status2PCPrePrepared, Status2PCPrepared, Status2PCCommitted:
+		return true
+	}
+	return false
+}
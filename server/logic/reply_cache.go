@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// a client stamps every request with its id and a timestamp that grows with each request it sends, the pair
+// names the request across retries. every replica caches the reply it sent for a request, so a retried
+// request is answered from the cache and never ordered or executed again. the reply is stored as its
+// protobuf payload, base64 encoded like the other binary columns
+
+func CacheReply(conf *config.Config, response *common.ProcessTxnResponse) error {
+	if response.Txn == nil || response.Txn.ClientID == EmptyString {
+		return nil
+	}
+
+	replyBytes, err := signedPayload.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return conf.DataStore.UpsertClientReply(datastore.ClientReply{
+		ClientID:        response.Txn.ClientID,
+		ClientTimestamp: response.Txn.ClientTimestamp,
+		TxnID:           response.Txn.TxnID,
+		Reply:           base64.StdEncoding.EncodeToString(replyBytes),
+	})
+}
+
+func GetCachedReply(conf *config.Config, req *common.TxnRequest) (*common.ProcessTxnResponse, error) {
+	reply, err := conf.DataStore.GetClientReply(req.ClientID, req.ClientTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// replies cached before the move to protobuf are plain json
+	replyBytes := []byte(reply.Reply)
+	if !strings.HasPrefix(reply.Reply, "{") {
+		replyBytes, err = base64.StdEncoding.DecodeString(reply.Reply)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := &common.ProcessTxnResponse{}
+	err = signedPayload.Unmarshal(replyBytes, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ResendCachedReply answers req with the reply cached for it, it reports false if req has not been answered yet.
+// abort votes carry the client request of the txn they vote on but are never answered themselves
+
+func ResendCachedReply(conf *config.Config, req *common.TxnRequest) (bool, error) {
+	if req.ClientID == EmptyString || IsInternalTxn(req) {
+		return false, nil
+	}
+
+	response, err := GetCachedReply(conf, req)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("request %d of client %s was already answered, resending the reply for txn %s\n",
+		req.ClientTimestamp, req.ClientID, response.Txn.TxnID)
+	go SendResponseToClient(conf, response)
+	return true, nil
+}
+
+// IsDuplicateRequest reports whether the leader already admitted req. the duplicate is dropped while the
+// original is in flight, a txn that finished without a reply (one that failed before it was ordered) is
+// answered now
+
+func IsDuplicateRequest(conf *config.Config, req *common.TxnRequest) (bool, error) {
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("dropping duplicate request for txn %s with status %s\n", dbTxn.TxnID, dbTxn.Status)
+	// an executed read is answered along with the balance it read, which only its cached reply has
+	if IsTwoPCFinished(dbTxn) && dbTxn.Type != TypeReadOnly {
+		go SendReplyToClient(conf, dbTxn)
+	}
+	return true, nil
+}
@@ -2,33 +2,55 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
-	"encoding/json"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
+// a replica that falls behind fetches the latest stable checkpoint and the log after it from every other
+// replica in its cluster, and only installs what is backed by a certificate or by f+1 matching replies
+
 func SyncIfServerSlow(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
 	signedMessage := &common.SignedMessage{}
-	err := json.Unmarshal(req.SignedMessage, signedMessage)
+	err := signedPayload.Unmarshal(req.SignedMessage, signedMessage)
 	if err != nil {
 		return err
 	}
 
 	lastExecutedSeq := conf.PBFT.GetLastExecutedSequenceNumber()
-	if signedMessage.LastExecutedSequence <= lastExecutedSeq {
+	if signedMessage.LastExecutedSequence-lastExecutedSeq < conf.CheckpointInterval &&
+		signedMessage.SequenceNumber <= conf.PBFT.GetHighWatermark() {
 		return nil
 	}
 
 	fmt.Printf("server is slow, asking for new txns...\n")
 
+	go func() {
+		err := StateTransfer(conf)
+		if err != nil {
+			fmt.Printf("StateTransfer error: %v\n", err)
+		}
+	}()
+	return nil
+}
+
+func StateTransfer(conf *config.Config) error {
+	if !conf.StateTransferLock.TryLock() {
+		return nil
+	}
+	defer conf.StateTransferLock.Unlock()
+
+	executedSeq := conf.PBFT.GetNextSequenceNumber() - 1
 	signedReq := &common.SignedMessage{
-		LastExecutedSequence: conf.PBFT.GetLastExecutedSequenceNumber(),
+		LastExecutedSequence: executedSeq,
 	}
 
-	signedReqBytes, err := json.Marshal(signedReq)
+	signedReqBytes, err := signedPayload.Marshal(signedReq)
 	if err != nil {
 		return err
 	}
@@ -43,57 +65,75 @@ func SyncIfServerSlow(ctx context.Context, conf *config.Config, req *common.PBFT
 		ServerNo:      conf.ServerNumber,
 	}
 
-	server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[req.ServerNo])
-	if err != nil {
-		return err
-	}
-	resp, err := server.Sync(ctx, syncReq)
-	if err != nil {
-		return err
-	}
-
-	err = AddNewTxns(ctx, conf, resp)
-	if err != nil {
-		return err
-	}
+	fmt.Printf("starting state transfer after sequence %d\n", executedSeq)
 
-	return nil
-}
+	var lock sync.Mutex
+	responses := make(map[int32]*common.StateTransferMessage)
 
-func AddNewTxns(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
-	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
-	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
-	if err != nil {
-		return err
-	}
-	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
-	if err != nil {
-		return err
-	}
-
-	var newTxns []*common.TxnRequest
-	err = json.Unmarshal(req.SignedMessage, &newTxns)
-	if err != nil {
-		return err
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverNo int32) {
+			defer wg.Done()
+			server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[serverNo])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			resp, err := server.Sync(context.Background(), syncReq)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			stMessage, err := VerifyStateTransferResponse(conf, serverNo, resp)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			lock.Lock()
+			responses[serverNo] = stMessage
+			lock.Unlock()
+		}(serverNo)
+	}
+	wg.Wait()
+
+	checkpoint, proof := GetCertifiedCheckpoint(conf, responses, executedSeq)
+	if checkpoint != nil {
+		err = InstallCheckpoint(conf, checkpoint, proof)
+		if err != nil {
+			return err
+		}
+	} else if executedSeq == 0 && IsGenesisCertified(conf, responses) {
+		err = InstallGenesisState(conf)
+		if err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("i was slow, adding these new txns: %v\n", newTxns)
-
-	for _, txn := range newTxns {
-		AcquireLock(conf, txn)
-		err = ExecuteTxn(conf, txn, true)
+	certifiedTxns := GetCertifiedTxns(conf, responses, conf.PBFT.GetNextSequenceNumber()-1)
+	for _, cert := range certifiedTxns {
+		err = ApplySyncedTxn(conf, cert)
 		if err != nil {
-			ReleaseLock(conf, txn)
 			return err
 		}
-		conf.PBFT.IncrementLastExecutedSequenceNumber()
-		ReleaseLock(conf, txn)
 	}
 
+	fmt.Printf("state transfer done, executed up to sequence %d\n", conf.PBFT.GetNextSequenceNumber()-1)
+	conf.ExecuteSignal <- struct{}{}
 	return nil
 }
 
 func ReceiveSyncRequest(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
+	if !conf.IsAlive {
+		return nil, errors.New("server dead")
+	}
+	if !IsServerInCluster(conf, req.ServerNo, conf.ClusterNumber) {
+		return nil, errors.New("sync request from server outside cluster")
+	}
+
 	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
 	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
 	if err != nil {
@@ -105,17 +145,20 @@ func ReceiveSyncRequest(ctx context.Context, conf *config.Config, req *common.PB
 	}
 
 	signedMessage := &common.SignedMessage{}
-	err = json.Unmarshal(req.SignedMessage, signedMessage)
+	err = signedPayload.Unmarshal(req.SignedMessage, signedMessage)
 	if err != nil {
 		return nil, err
 	}
 
-	txns, err := datastore.GetExecutedTransactionsAfterSequence(conf.DataStore, signedMessage.LastExecutedSequence)
+	stMessage, err := GetStateTransferMessage(conf, signedMessage.LastExecutedSequence)
 	if err != nil {
 		return nil, err
 	}
+	if HasFault(conf, FaultLieSync) {
+		LieInStateTransfer(stMessage)
+	}
 
-	signedMsgBytes, err := json.Marshal(txns)
+	signedMsgBytes, err := signedPayload.Marshal(stMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -132,3 +175,29 @@ func ReceiveSyncRequest(ctx context.Context, conf *config.Config, req *common.PB
 
 	return syncResp, nil
 }
+
+// SyncOnStartup rebuilds a replica that comes up with an empty database, retrying until enough of
+// the cluster is reachable to certify the state
+
+func SyncOnStartup(conf *config.Config) {
+	users, err := conf.DataStore.GetBalances()
+	if err != nil || len(users) > 0 {
+		return
+	}
+
+	fmt.Printf("database is empty, rebuilding it from the other replicas\n")
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		err = StateTransfer(conf)
+		if err != nil {
+			fmt.Printf("StateTransfer error: %v\n", err)
+		}
+		users, err = conf.DataStore.GetBalances()
+		if err == nil && len(users) > 0 {
+			return
+		}
+		<-ticker.C
+	}
+}
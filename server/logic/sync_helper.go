@@ -0,0 +1,411 @@
+package logic
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// GetStateTransferMessage returns the stable checkpoint if the requester is behind it, along with every
+// finished txn after it and the commit certificate this replica holds for each
+
+func GetStateTransferMessage(conf *config.Config, executedSeq int32) (*common.StateTransferMessage, error) {
+	stMessage := &common.StateTransferMessage{}
+
+	fromSeq := executedSeq
+	stableCheckpoint := conf.PBFT.GetLowWatermark()
+	if stableCheckpoint > executedSeq {
+		checkpoint, err := conf.DataStore.GetCheckpoint(stableCheckpoint)
+		if err != nil {
+			return nil, err
+		}
+		_, proof, err := GetCheckpointProof(conf, stableCheckpoint)
+		if err != nil {
+			return nil, err
+		}
+		stMessage.StableCheckpoint = stableCheckpoint
+		stMessage.State = checkpoint.State
+		stMessage.CheckpointMessages = proof
+		fromSeq = stableCheckpoint
+	}
+
+	txns, err := conf.DataStore.GetFinishedTransactionsAfterSequence(fromSeq)
+	if err != nil {
+		return nil, err
+	}
+	for _, txn := range txns {
+		if txn.BatchID != EmptyString {
+			continue
+		}
+		err = LoadBatchMembers(conf, txn)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := GetQuorumCertificate(conf, txn, MessageTypeCommit)
+		if err != nil {
+			return nil, err
+		}
+		stMessage.CommittedTxns = append(stMessage.CommittedTxns, &common.CommitCertificate{
+			Txn:         txn,
+			Certificate: cert,
+		})
+	}
+	return stMessage, nil
+}
+
+func VerifyStateTransferResponse(conf *config.Config, serverNo int32, resp *common.PBFTRequestResponse) (*common.StateTransferMessage, error) {
+	if resp == nil || resp.ServerNo != serverNo {
+		return nil, errors.New("invalid sync response")
+	}
+
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[serverNo])
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, resp.SignedMessage, resp.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	stMessage := &common.StateTransferMessage{}
+	err = signedPayload.Unmarshal(resp.SignedMessage, stMessage)
+	if err != nil {
+		return nil, err
+	}
+	return stMessage, nil
+}
+
+// GetCertifiedCheckpoint picks the highest checkpoint after executedSeq whose state either matches a
+// valid 2f+1 checkpoint proof or was sent with the same digest by f+1 replicas
+
+func GetCertifiedCheckpoint(conf *config.Config, responses map[int32]*common.StateTransferMessage, executedSeq int32) (*datastore.Checkpoint, []*common.PBFTMessage) {
+	var certified *datastore.Checkpoint
+	var certifiedProof []*common.PBFTMessage
+
+	votes := make(map[string]int32)
+	for _, stMessage := range responses {
+		if stMessage.StableCheckpoint <= executedSeq || stMessage.State == EmptyString {
+			continue
+		}
+
+		stateDigest, err := GetCheckpointDigest(stMessage.State)
+		if err != nil {
+			continue
+		}
+		checkpoint := &datastore.Checkpoint{
+			SeqNo:  stMessage.StableCheckpoint,
+			Digest: stateDigest,
+			State:  stMessage.State,
+		}
+
+		var proof []*common.PBFTMessage
+		digest, err := VerifyCheckpointProof(conf, checkpoint.SeqNo, stMessage.CheckpointMessages)
+		if err == nil && digest == checkpoint.Digest {
+			proof = stMessage.CheckpointMessages
+		} else {
+			key := fmt.Sprintf("%d-%s", checkpoint.SeqNo, checkpoint.Digest)
+			votes[key]++
+			if votes[key] < GetFaultTolerance(conf)+1 {
+				continue
+			}
+		}
+
+		if certified == nil || checkpoint.SeqNo > certified.SeqNo ||
+			(checkpoint.SeqNo == certified.SeqNo && certifiedProof == nil) {
+			certified = checkpoint
+			certifiedProof = proof
+		}
+	}
+	return certified, certifiedProof
+}
+
+// GetCertifiedTxns returns the contiguous run of txns after executedSeq that can be trusted: intra-shard
+// txns and no-ops with a valid commit certificate, or any txn that f+1 replicas agree on, which is what
+// decides the 2PC outcome of cross-shard txns
+
+func GetCertifiedTxns(conf *config.Config, responses map[int32]*common.StateTransferMessage, executedSeq int32) []*common.CommitCertificate {
+	candidates := make(map[int32]map[int32]*common.CommitCertificate)
+	for serverNo, stMessage := range responses {
+		for _, cert := range stMessage.CommittedTxns {
+			if cert.Txn == nil || cert.Txn.SeqNo <= executedSeq {
+				continue
+			}
+			if candidates[cert.Txn.SeqNo] == nil {
+				candidates[cert.Txn.SeqNo] = make(map[int32]*common.CommitCertificate)
+			}
+			candidates[cert.Txn.SeqNo][serverNo] = cert
+		}
+	}
+
+	var certified []*common.CommitCertificate
+	for seqNo := executedSeq + 1; ; seqNo++ {
+		cert := GetCertifiedTxn(conf, candidates[seqNo])
+		if cert == nil {
+			break
+		}
+		certified = append(certified, cert)
+	}
+	return certified
+}
+
+func GetCertifiedTxn(conf *config.Config, candidates map[int32]*common.CommitCertificate) *common.CommitCertificate {
+	votes := make(map[string]int32)
+	for _, cert := range candidates {
+		txn := cert.Txn
+		if txn.Status != StatusExecuted && txn.Status != StatusAborted {
+			continue
+		}
+
+		key := fmt.Sprintf("%s-%s-%s", txn.TxnID, GetTxnDigest(txn), txn.Status)
+		votes[key]++
+		if votes[key] >= GetFaultTolerance(conf)+1 {
+			return cert
+		}
+
+		if txn.Status == StatusExecuted && cert.Certificate != nil && IsCommitCertificateFinal(conf, txn) &&
+			VerifyQuorumCertificate(conf, txn, cert.Certificate) == nil {
+			return cert
+		}
+	}
+	return nil
+}
+
+// IsCommitCertificateFinal reports whether committing txn is enough to know its outcome, which is not the
+// case for cross-shard txns that still depend on 2PC
+
+func IsCommitCertificateFinal(conf *config.Config, txn *common.TxnRequest) bool {
+	switch txn.Type {
+	case TypeNoOp, TypeTwoPCAbortVote, TypeReadOnly:
+		return true
+	case TypeBatch:
+		return VerifyBatch(conf, txn) == nil
+	}
+	return GetTxnType(conf, txn) == TypeIntraShard
+}
+
+func IsGenesisCertified(conf *config.Config, responses map[int32]*common.StateTransferMessage) bool {
+	count := int32(0)
+	for _, stMessage := range responses {
+		if stMessage.StableCheckpoint == 0 {
+			count++
+		}
+	}
+	return count >= GetFaultTolerance(conf)+1
+}
+
+// InstallCheckpoint replaces the local state with a certified checkpoint, drops the txns it covers that
+// are still in flight here and moves execution and the watermarks past it
+
+func InstallCheckpoint(conf *config.Config, checkpoint *datastore.Checkpoint, proof []*common.PBFTMessage) error {
+	var users []datastore.User
+	err := json.Unmarshal([]byte(checkpoint.State), &users)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("installing checkpoint %d with digest %s\n", checkpoint.SeqNo, checkpoint.Digest)
+
+	err = conf.DataStore.ReplaceBalances(users)
+	if err != nil {
+		return err
+	}
+	err = conf.DataStore.InsertCheckpoint(*checkpoint)
+	if err != nil {
+		return err
+	}
+
+	err = AddCheckpointProof(conf, checkpoint.SeqNo, proof)
+	if err != nil {
+		return err
+	}
+
+	txns, err := conf.DataStore.GetTransactionsAfterSequence(0)
+	if err != nil {
+		return err
+	}
+	for _, txn := range txns {
+		if txn.SeqNo > checkpoint.SeqNo {
+			break
+		}
+		if txn.Status == StatusExecuted || txn.Status == StatusAborted || txn.Status == StatusFailed {
+			continue
+		}
+		err = DropSyncedTxn(conf, txn)
+		if err != nil {
+			return err
+		}
+	}
+
+	conf.PendingTransactionsMutex.Lock()
+	for seqNo := range conf.PendingTransactions {
+		if seqNo <= checkpoint.SeqNo {
+			delete(conf.PendingTransactions, seqNo)
+		}
+	}
+	conf.PendingTransactionsMutex.Unlock()
+
+	conf.PBFT.SetExecutedSequenceNumber(checkpoint.SeqNo)
+	conf.PBFT.RemovePendingCheckpoints(checkpoint.SeqNo)
+
+	if len(proof) > 0 && conf.PBFT.AdvanceWatermarks(checkpoint.SeqNo, conf.WatermarkWindow) {
+		err = GarbageCollect(conf, checkpoint.SeqNo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func AddCheckpointProof(conf *config.Config, seqNo int32, proof []*common.PBFTMessage) error {
+	checkpointMessages, err := conf.DataStore.GetPBFTMessages(GetCheckpointID(seqNo), MessageTypeCheckpoint)
+	if err != nil {
+		return err
+	}
+	senders := make(map[int32]bool)
+	for _, checkpointMessage := range checkpointMessages {
+		senders[checkpointMessage.Sender] = true
+	}
+
+	for _, checkpointMessage := range proof {
+		if senders[checkpointMessage.Sender] {
+			continue
+		}
+		senders[checkpointMessage.Sender] = true
+		err = conf.DataStore.InsertPBFTMessage(checkpointMessage)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InstallGenesisState fills an empty database with the initial balances of the shard
+
+func InstallGenesisState(conf *config.Config) error {
+	users, err := conf.DataStore.GetBalances()
+	if err != nil || len(users) > 0 {
+		return err
+	}
+
+	fmt.Printf("installing the initial state of cluster %d\n", conf.ClusterNumber)
+
+	firstUser := (conf.ClusterNumber-1)*conf.DataItemsPerShard + 1
+	for user := firstUser; user < firstUser+conf.DataItemsPerShard; user++ {
+		users = append(users, datastore.User{User: user, Balance: InitialBalance})
+	}
+	return conf.DataStore.ReplaceBalances(users)
+}
+
+// ApplySyncedTxn executes a certified txn in place of the normal protocol, replacing whatever local
+// record of it this replica had
+
+func ApplySyncedTxn(conf *config.Config, cert *common.CommitCertificate) error {
+	txn := cert.Txn
+	if !IsInternalTxn(txn) {
+		txn.Type = GetTxnType(conf, txn)
+	}
+
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txn.TxnID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if dbTxn == nil || (dbTxn.Status != StatusExecuted && dbTxn.Status != StatusAborted) {
+		if dbTxn != nil {
+			err = DropSyncedTxn(conf, dbTxn)
+			if err != nil {
+				return err
+			}
+		}
+		for _, member := range txn.Batch {
+			dbMember, err := conf.DataStore.GetTransactionByTxnID(member.TxnID)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if dbMember != nil {
+				err = DropSyncedTxn(conf, dbMember)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		fmt.Printf("applying synced txn %s with sequence %d\n", txn.TxnID, txn.SeqNo)
+
+		outcome := txn.Status
+		txn.Status = StatusInit
+		txn.Digest = GetTxnDigest(txn)
+		txn.Error = EmptyString
+		err = InsertTxnWithBatch(conf, txn)
+		if err != nil {
+			return err
+		}
+
+		if outcome == StatusExecuted {
+			err = ExecuteTxn(conf, txn, true)
+			if err == nil && txn.Type == TypeTwoPCAbortVote {
+				_, err = AbortVotedTxn(conf, txn)
+			}
+		} else {
+			txn.Status = StatusAborted
+			err = conf.DataStore.UpdateTransactionStatus(txn)
+		}
+		if err != nil {
+			return err
+		}
+
+		if cert.Certificate != nil {
+			for _, commitMessage := range cert.Certificate.Messages {
+				err = conf.DataStore.InsertPBFTMessage(commitMessage)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	StopRequestTimer(conf, txn.TxnID)
+
+	conf.PendingTransactionsMutex.Lock()
+	delete(conf.PendingTransactions, txn.SeqNo)
+	conf.PendingTransactionsMutex.Unlock()
+
+	conf.PBFT.SetExecutedSequenceNumber(txn.SeqNo)
+
+	if txn.SeqNo%conf.CheckpointInterval == 0 {
+		err = TakeCheckpoint(conf, txn.SeqNo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropSyncedTxn removes a local txn superseded by state transfer, releasing its locks if it still holds them
+
+func DropSyncedTxn(conf *config.Config, txn *common.TxnRequest) error {
+	if IsTxnHoldingLocks(txn) {
+		ReleaseLock(conf, txn)
+	}
+	StopRequestTimer(conf, txn.TxnID)
+
+	_, err := conf.DataStore.DeletePBFTMessagesByByTxnID(txn.TxnID)
+	if err != nil {
+		return err
+	}
+	return conf.DataStore.DeleteTransaction(txn.TxnID)
+}
+
+func IsTxnHoldingLocks(txn *common.TxnRequest) bool {
+	switch txn.Status {
+	case Status2PCPending, Status2PCPrePrepared, Status2PCPrepared, Status2PCCommitted:
+		return true
+	}
+	return IsTxnInFlight(txn)
+}
@@ -0,0 +1,124 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	KeyPool "GolandProjects/2pcbyz-gautamsardana/key_pool"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// the vote of a cluster on a cross-shard txn travels as a threshold certificate: every replica adds a
+// signature share over the vote to its commit message, or sends it on its own once it executed an abort
+// vote, and the leader combines 2f+1 of the shares, its own included, into a single signature of the
+// cluster key. the other cluster checks that one signature against
+// the public key of the cluster, so the certificate has the same size whatever the number of replicas.
+// the signed vote names the txn and the client request it came from, so a certificate only ever counts for
+// the txn it was made for
+
+func GetThresholdMessage(cluster int32, txn *common.TxnRequest, digest string) []byte {
+	message, _ := signedPayload.Marshal(&common.ThresholdVote{
+		Cluster:         cluster,
+		TxnID:           GetVotedTxnID(txn.TxnID),
+		ClientID:        txn.ClientID,
+		ClientTimestamp: txn.ClientTimestamp,
+		Digest:          digest,
+	})
+	return message
+}
+
+// GetVoteDigest returns the digest of the vote the commit messages for txn stand for
+
+func GetVoteDigest(txn *common.TxnRequest) string {
+	if txn.Type == TypeTwoPCAbortVote {
+		return GetTwoPCVoteDigest(txn, OutcomeAbort)
+	}
+	return GetTwoPCVoteDigest(txn, OutcomeCommit)
+}
+
+func GetThresholdShare(conf *config.Config, txn *common.TxnRequest) []byte {
+	digest := GetSignedDigest(conf, GetVoteDigest(txn))
+	return conf.ThresholdKeys.Signer.SignShare(GetThresholdMessage(conf.ClusterNumber, txn, digest))
+}
+
+// GetThresholdCertificate combines the shares in the commit messages of certTxnID, or in the abort vote shares
+// when certTxnID is an abort vote, into the certificate of the outcome vote of this cluster on txn, leaving
+// out the shares that do not verify. a leader that combines badly takes whatever shares it has without
+// checking them, and one that forges certificates makes up the missing ones with its own share
+
+func GetThresholdCertificate(conf *config.Config, txn *common.TxnRequest, certTxnID, outcome string) (*common.Certificate, error) {
+	messageType := MessageTypeCommit
+	if strings.HasPrefix(certTxnID, AbortVotePrefix) {
+		messageType = MessageTypeAbortVoteShare
+	}
+	shareMessages, err := conf.DataStore.GetPBFTMessages(certTxnID, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := GetTwoPCVoteDigest(txn, outcome)
+	message := GetThresholdMessage(conf.ClusterNumber, txn, digest)
+	signer := conf.ThresholdKeys.Signer
+	shares := map[int32][]byte{
+		signer.Index: signer.SignShare(GetThresholdMessage(conf.ClusterNumber, txn, GetSignedDigest(conf, digest))),
+	}
+	isBadCombine := HasFault(conf, FaultBadCombine)
+	for _, shareMessage := range shareMessages {
+		if len(shares) == int(conf.Majority) {
+			break
+		}
+		if len(shareMessage.ThresholdShare) == 0 || !IsServerInCluster(conf, shareMessage.Sender, conf.ClusterNumber) {
+			continue
+		}
+		publicKey, err := conf.ThresholdKeys.GetPublicKey(config.MapServerNumberToAddress[shareMessage.Sender])
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if !isBadCombine {
+			err = publicKey.Verify(message, shareMessage.ThresholdShare)
+			if err != nil {
+				fmt.Printf("invalid threshold share from server %d: %v\n", shareMessage.Sender, err)
+				continue
+			}
+		}
+		shares[publicKey.Index] = shareMessage.ThresholdShare
+	}
+
+	if HasFault(conf, FaultForgeCertificate) {
+		for _, publicKey := range conf.ThresholdKeys.PublicKeys {
+			if len(shares) == int(conf.Majority) {
+				break
+			}
+			if _, ok := shares[publicKey.Index]; !ok {
+				shares[publicKey.Index] = shares[signer.Index]
+			}
+		}
+		fmt.Printf("fault injection: forged threshold certificate for txn %s\n", txn.TxnID)
+	}
+
+	if len(shares) < int(conf.Majority) {
+		return nil, errors.New("not enough threshold shares")
+	}
+	signature, err := KeyPool.CombineShares(shares)
+	if err != nil {
+		return nil, err
+	}
+	return &common.Certificate{ThresholdSignature: signature}, nil
+}
+
+// VerifyThresholdCertificate checks cert carries the signature of the key of cluster over its vote on txn
+
+func VerifyThresholdCertificate(conf *config.Config, cluster int32, txn *common.TxnRequest, digest string, cert *common.Certificate) error {
+	if len(cert.ThresholdSignature) == 0 {
+		return errors.New("certificate without threshold signature")
+	}
+	clusterKey, err := conf.ThresholdKeys.GetClusterKey(cluster)
+	if err != nil {
+		return err
+	}
+	return clusterKey.Verify(GetThresholdMessage(cluster, txn, digest), cert.ThresholdSignature)
+}
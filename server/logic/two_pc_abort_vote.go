@@ -0,0 +1,291 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// a participant cluster that cannot prepare a cross-shard txn orders an explicit abort vote for it like any
+// other request. a replica only signs the abort once it executed the vote and actually aborted the txn, one
+// that prepared the txn before the vote was ordered stands by its commit vote. the replicas hand their
+// threshold share for the abort to the rest of the cluster and the leader sends the certificate to the
+// coordinator cluster once it holds 2f+1 of them
+
+const AbortVotePrefix = "abort-vote-"
+
+func StartTwoPCAbortVote(conf *config.Config, req *common.TxnRequest) error {
+	voteTxn := &common.TxnRequest{
+		TxnID:    GetAbortVoteID(req.TxnID),
+		Sender:   req.Sender,
+		Receiver: req.Receiver,
+		Amount:   req.Amount,
+		Legs:     req.Legs,
+		Type:     TypeTwoPCAbortVote,
+
+		ClientID:        req.ClientID,
+		ClientTimestamp: req.ClientTimestamp,
+	}
+
+	fmt.Printf("voting abort for txn %s\n", req.TxnID)
+	return ProcessTxn(context.Background(), conf, voteTxn, false)
+}
+
+// AbortVotedTxn aborts the txn an executed abort vote refers to, it returns false when the txn got
+// prepared here before the vote was ordered, in which case a commit vote has already gone out for it
+
+func AbortVotedTxn(conf *config.Config, voteTxn *common.TxnRequest) (bool, error) {
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(GetVotedTxnID(voteTxn.TxnID))
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if dbTxn.Type != TypeCrossShardReceiver {
+		return false, nil
+	}
+
+	switch dbTxn.Status {
+	case Status2PCPending, Status2PCPrePrepared, Status2PCPrepared, Status2PCCommitted, StatusExecuted:
+		return false, nil
+	case StatusAborted, StatusFailed:
+		return true, nil
+	}
+
+	fmt.Printf("aborting txn %s after abort vote\n", dbTxn.TxnID)
+
+	ReleaseLock(conf, dbTxn)
+	StopRequestTimer(conf, dbTxn.TxnID)
+	dbTxn.Status = StatusAborted
+	err = conf.DataStore.UpdateTransactionStatus(dbTxn)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IsVotedTxnAborted reports whether the txn an executed abort vote refers to ended up aborted here, it
+// reads what AbortVotedTxn left behind without changing anything
+
+func IsVotedTxnAborted(conf *config.Config, voteTxn *common.TxnRequest) (bool, error) {
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(GetVotedTxnID(voteTxn.TxnID))
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if dbTxn.Type != TypeCrossShardReceiver {
+		return false, nil
+	}
+	return dbTxn.Status == StatusAborted || dbTxn.Status == StatusFailed, nil
+}
+
+// SendAbortVoteShare hands the threshold share of this replica for the abort to every other replica of the
+// cluster rather than just the leader, so a leader elected later can still put the certificate together
+
+func SendAbortVoteShare(conf *config.Config, voteTxn *common.TxnRequest) error {
+	txnBytes, err := signedPayload.Marshal(voteTxn)
+	if err != nil {
+		return err
+	}
+
+	shareReq := &common.PBFTRequestResponse{
+		TxnRequest:     txnBytes,
+		ServerNo:       conf.ServerNumber,
+		Outcome:        OutcomeAbort,
+		ThresholdShare: GetThresholdShare(conf, voteTxn),
+	}
+
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.AbortVoteShare(context.Background(), shareReq)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(config.MapServerNumberToAddress[serverNo])
+	}
+	wg.Wait()
+	return nil
+}
+
+func ReceiveAbortVoteShare(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+	if !IsServerInCluster(conf, req.ServerNo, conf.ClusterNumber) {
+		return errors.New("abort vote share from server outside cluster")
+	}
+
+	voteTxn := &common.TxnRequest{}
+	err := signedPayload.Unmarshal(req.TxnRequest, voteTxn)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(voteTxn.TxnID, AbortVotePrefix) {
+		return errors.New("invalid abort vote")
+	}
+
+	publicKey, err := conf.ThresholdKeys.GetPublicKey(config.MapServerNumberToAddress[req.ServerNo])
+	if err != nil {
+		return err
+	}
+	digest := GetTwoPCVoteDigest(voteTxn, OutcomeAbort)
+	err = publicKey.Verify(GetThresholdMessage(conf.ClusterNumber, voteTxn, digest), req.ThresholdShare)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("received abort vote share for txn %s from server %d\n", voteTxn.TxnID, req.ServerNo)
+
+	err = AddAbortVoteShare(conf, req, voteTxn.TxnID)
+	if err != nil {
+		return err
+	}
+	return SendTwoPCAbortVoteOnQuorum(conf, voteTxn.TxnID)
+}
+
+func AddAbortVoteShare(conf *config.Config, req *common.PBFTRequestResponse, voteTxnID string) error {
+	shareMessages, err := conf.DataStore.GetPBFTMessages(voteTxnID, MessageTypeAbortVoteShare)
+	if err != nil {
+		return err
+	}
+	for _, shareMessage := range shareMessages {
+		if shareMessage.Sender == req.ServerNo {
+			return nil
+		}
+	}
+
+	pbftMessage := &common.PBFTMessage{
+		TxnID:          voteTxnID,
+		MessageType:    MessageTypeAbortVoteShare,
+		Sender:         req.ServerNo,
+		Payload:        req.TxnRequest,
+		ThresholdShare: req.ThresholdShare,
+		CreatedAt:      timestamppb.New(time.Now()),
+	}
+	return conf.DataStore.InsertPBFTMessage(pbftMessage)
+}
+
+// SendTwoPCAbortVoteOnQuorum sends the abort vote once this replica leads the cluster, has aborted the txn
+// itself and holds the shares of 2f other replicas. it runs when the leader executes the vote and whenever a
+// share comes in, whichever completes the quorum sends the vote
+
+func SendTwoPCAbortVoteOnQuorum(conf *config.Config, voteTxnID string) error {
+	if GetLeaderNumber(conf, conf.ClusterNumber) != conf.ServerNumber {
+		return nil
+	}
+
+	voteTxn, err := conf.DataStore.GetTransactionByTxnID(voteTxnID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if voteTxn.Status != StatusExecuted {
+		return nil
+	}
+	aborted, err := IsVotedTxnAborted(conf, voteTxn)
+	if err != nil || !aborted {
+		return err
+	}
+
+	shareMessages, err := conf.DataStore.GetPBFTMessages(voteTxnID, MessageTypeAbortVoteShare)
+	if err != nil {
+		return err
+	}
+	senders := make(map[int32]bool)
+	for _, shareMessage := range shareMessages {
+		if shareMessage.Sender != conf.ServerNumber && IsServerInCluster(conf, shareMessage.Sender, conf.ClusterNumber) {
+			senders[shareMessage.Sender] = true
+		}
+	}
+	if len(senders) < int(conf.Majority)-1 {
+		return nil
+	}
+	return SendTwoPCAbortVote(conf, voteTxn)
+}
+
+func SendTwoPCAbortVote(conf *config.Config, voteTxn *common.TxnRequest) error {
+	txn := &common.TxnRequest{
+		TxnID:    GetVotedTxnID(voteTxn.TxnID),
+		Sender:   voteTxn.Sender,
+		Receiver: voteTxn.Receiver,
+		Amount:   voteTxn.Amount,
+		Legs:     voteTxn.Legs,
+
+		ClientID:        voteTxn.ClientID,
+		ClientTimestamp: voteTxn.ClientTimestamp,
+	}
+	err := SendTwoPCVote(conf, txn, voteTxn.TxnID, OutcomeAbort)
+	if err != nil {
+		return err
+	}
+	if HasFault(conf, FaultDoubleVote) {
+		return SendTwoPCVote(conf, txn, txn.TxnID, OutcomeCommit)
+	}
+	return nil
+}
+
+// VerifyTwoPCAbortVote checks a proposed abort vote refers to a cross-shard txn this cluster receives, which
+// is the only kind of txn it gets a say on
+
+func VerifyTwoPCAbortVote(conf *config.Config, voteTxn *common.TxnRequest) error {
+	if !strings.HasPrefix(voteTxn.TxnID, AbortVotePrefix) || GetTxnType(conf, voteTxn) != TypeCrossShardReceiver {
+		return errors.New("invalid abort vote")
+	}
+	return nil
+}
+
+// GetTwoPCVoteDigest is the digest participant replicas sign for a vote, a commit vote is the commit
+// certificate of the txn itself while an abort vote binds the outcome into the digest
+
+func GetTwoPCVoteDigest(req *common.TxnRequest, outcome string) string {
+	digest := GetTxnDigest(&common.TxnRequest{
+		Sender:   req.Sender,
+		Receiver: req.Receiver,
+		Amount:   req.Amount,
+		Legs:     req.Legs,
+	})
+	if outcome != OutcomeAbort {
+		return digest
+	}
+
+	voteDigest := sha256.Sum256([]byte(digest + ":" + OutcomeAbort))
+	return fmt.Sprintf("%x", voteDigest[:])
+}
+
+func GetAbortVoteID(txnID string) string {
+	return AbortVotePrefix + txnID
+}
+
+func GetVotedTxnID(voteTxnID string) string {
+	return strings.TrimPrefix(voteTxnID, AbortVotePrefix)
+}
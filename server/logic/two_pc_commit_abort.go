@@ -12,76 +12,103 @@ import (
 func TwoPCCommit(ctx context.Context, conf *config.Config, req *common.TxnRequest) error {
 	fmt.Printf("received final commit from leader for txn: %v\n", req)
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
 		return err
 	}
+	if IsTwoPCFinished(dbTxn) {
+		return nil
+	}
 
 	dbTxn.Status = StatusExecuted
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+	err = conf.DataStore.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+		err := uow.UpdateTransactionStatus(dbTxn)
+		if err != nil {
+			return err
+		}
+		return uow.UpsertTwoPCState(NewTwoPCState(dbTxn, OutcomeCommit))
+	})
 	if err != nil {
-		fmt.Printf("failed to update transaction status: %v\n", err)
+		return err
 	}
 	conf.PBFT.IncrementLastExecutedSequenceNumber()
 	ReleaseLock(conf, req)
 
+	TakeDecidedCheckpoints(conf)
 	return nil
 }
 
 func TwoPCAbort(ctx context.Context, conf *config.Config, req *common.TxnRequest) error {
 	fmt.Printf("received final abort from leader for txn: %v\n", req)
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
 		return err
 	}
+	if IsTwoPCFinished(dbTxn) {
+		return nil
+	}
 
-	if dbTxn.Status == StatusCommitted {
+	// a txn that only got committed was never executed, so there is nothing to roll back
+	isExecuted := dbTxn.Status != StatusCommitted
+	err = conf.DataStore.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+		if isExecuted {
+			return ApplyRollback(conf, uow, dbTxn)
+		}
 		dbTxn.Status = StatusAborted
-		err := datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
+		err := uow.UpdateTransactionStatus(dbTxn)
 		if err != nil {
-			fmt.Printf("failed to update transaction status: %v\n", err)
+			return err
 		}
-		ReleaseLock(conf, dbTxn)
-		return nil
-	}
-
-	err = RollbackTxn(conf, dbTxn)
+		return uow.UpsertTwoPCState(NewTwoPCState(dbTxn, OutcomeAbort))
+	})
 	if err != nil {
 		return err
 	}
 
 	ReleaseLock(conf, dbTxn)
 
+	TakeDecidedCheckpoints(conf)
 	return nil
 }
 
+// TakeDecidedCheckpoints takes the checkpoints that were waiting for the outcome just applied
+
+func TakeDecidedCheckpoints(conf *config.Config) {
+	err := TakePendingCheckpoints(conf)
+	if err != nil {
+		fmt.Printf("TakePendingCheckpoints error: %v\n", err)
+	}
+}
+
+// RollbackTxn undoes the balance changes of an executed cross-shard txn and marks it aborted in one unit of work
+
 func RollbackTxn(conf *config.Config, req *common.TxnRequest) error {
+	return conf.DataStore.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+		return ApplyRollback(conf, uow, req)
+	})
+}
+
+func ApplyRollback(conf *config.Config, uow datastore.UnitOfWork, req *common.TxnRequest) error {
 	if req.Type == TypeCrossShardSender {
-		senderBalance, err := datastore.GetBalance(conf.DataStore, req.Sender)
-		if err != nil {
-			return err
-		}
-		err = datastore.UpdateBalance(conf.DataStore, datastore.User{User: req.Sender, Balance: senderBalance + req.Amount})
-		if err != nil {
-			return err
-		}
-	} else if req.Type == TypeCrossShardReceiver {
-		receiverBalance, err := datastore.GetBalance(conf.DataStore, req.Receiver)
+		err := uow.AddToBalance(req.Sender, req.Amount)
 		if err != nil {
 			return err
 		}
-		err = datastore.UpdateBalance(conf.DataStore, datastore.User{User: req.Receiver, Balance: receiverBalance - req.Amount})
-		if err != nil {
-			return err
+	}
+	if req.Type == TypeCrossShardSender || req.Type == TypeCrossShardReceiver {
+		for _, leg := range GetLocalLegs(conf, req) {
+			err := uow.AddToBalance(leg.Receiver, -leg.Amount)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	req.Status = StatusAborted
-	err := datastore.UpdateTransactionStatus(conf.DataStore, req)
+	err := uow.UpdateTransactionStatus(req)
 	if err != nil {
-		fmt.Printf("failed to update transaction status: %v\n", err)
+		return err
 	}
-
-	return nil
+	return uow.UpsertTwoPCState(NewTwoPCState(req, OutcomeAbort))
 }
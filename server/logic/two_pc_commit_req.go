@@ -2,12 +2,11 @@ package logic
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 )
 
 func ReceiveTwoPCCommit(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
@@ -23,19 +22,19 @@ func ReceiveTwoPCCommit(ctx context.Context, conf *config.Config, req *common.PB
 	}
 
 	txnReq := &common.TxnRequest{}
-	err = json.Unmarshal(req.SignedMessage, txnReq)
+	err = signedPayload.Unmarshal(req.SignedMessage, txnReq)
 	if err != nil {
 		return nil, err
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txnReq.TxnID)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnReq.TxnID)
 	if err != nil {
 		return nil, err
 	}
 
 	fmt.Printf("received TwoPCCommit from coordinator cluster with request: %v\n", dbTxn)
 
-	txnBytes, err := json.Marshal(dbTxn)
+	txnBytes, err := signedPayload.Marshal(dbTxn)
 	if err != nil {
 		return nil, err
 	}
@@ -51,20 +50,25 @@ func ReceiveTwoPCCommit(ctx context.Context, conf *config.Config, req *common.PB
 	}
 
 	if GetLeaderNumber(conf, conf.ClusterNumber) == conf.ServerNumber {
-		conf.TwoPCChan[txnReq.TxnID] <- req
+		NotifyTwoPCChan(conf, txnReq.TxnID, req)
 	}
 
 	return resp, nil
 }
 
-func WaitForCoordinatorResponse(conf *config.Config, txnReq *common.TxnRequest) {
+func WaitForCoordinatorResponse(conf *config.Config, txnReq *common.TxnRequest, timeouts <-chan int32) {
+	conf.TwoPCLock.Lock()
+	decisions := conf.TwoPCChan[txnReq.TxnID]
+	conf.TwoPCLock.Unlock()
+	defer ClearTwoPCState(conf, txnReq.TxnID)
+
 	select {
-	case <-conf.TwoPCTimer[txnReq.TxnID].C:
+	case <-timeouts:
 		fmt.Printf("no response from coordinator cluster, outcome = abort\n")
 		ProcessTwoPCCommit(context.Background(), conf, txnReq, OutcomeAbort)
 		return
-	case resp := <-conf.TwoPCChan[txnReq.TxnID]:
-		conf.TwoPCTimer[txnReq.TxnID].Stop()
+	case resp := <-decisions:
+		StopTwoPCTimer(conf, txnReq.TxnID, GetServerClusterNumber(conf, resp.ServerNo))
 		if resp.Outcome == OutcomeCommit {
 			fmt.Printf("got response from participant cluster, outcome = commit\n")
 			ProcessTwoPCCommit(context.Background(), conf, txnReq, OutcomeCommit)
@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+)
+
+const TwoPCTimeout = 5 * time.Second
+
+// StartTwoPCTimers arms one timer per cluster txnID waits on, a timer that runs out sends its cluster on the
+// returned channel, and opens the channel 2pc messages for txnID are handed over on
+
+func StartTwoPCTimers(conf *config.Config, txnID string, clusters []int32) <-chan int32 {
+	timeouts := make(chan int32, len(clusters))
+
+	conf.TwoPCLock.Lock()
+	defer conf.TwoPCLock.Unlock()
+
+	conf.TwoPCTimer[txnID] = make(map[int32]*time.Timer)
+	for _, cluster := range clusters {
+		cluster := cluster
+		conf.TwoPCTimer[txnID][cluster] = time.AfterFunc(TwoPCTimeout, func() {
+			timeouts <- cluster
+		})
+	}
+	conf.TwoPCChan[txnID] = make(chan *common.PBFTRequestResponse, len(clusters))
+	return timeouts
+}
+
+func StopTwoPCTimer(conf *config.Config, txnID string, cluster int32) {
+	conf.TwoPCLock.Lock()
+	defer conf.TwoPCLock.Unlock()
+
+	if timer, exists := conf.TwoPCTimer[txnID][cluster]; exists {
+		timer.Stop()
+	}
+}
+
+// ClearTwoPCState stops the remaining timers of txnID once its wait is over, later messages for it are dropped
+
+func ClearTwoPCState(conf *config.Config, txnID string) {
+	conf.TwoPCLock.Lock()
+	defer conf.TwoPCLock.Unlock()
+
+	for _, timer := range conf.TwoPCTimer[txnID] {
+		timer.Stop()
+	}
+	delete(conf.TwoPCTimer, txnID)
+	delete(conf.TwoPCChan, txnID)
+}
+
+// NotifyTwoPCChan hands a 2pc message to the goroutine waiting on txnID, dropping it when nobody waits,
+// e.g. when this cluster voted abort or the wait already timed out
+
+func NotifyTwoPCChan(conf *config.Config, txnID string, req *common.PBFTRequestResponse) {
+	conf.TwoPCLock.Lock()
+	ch, exists := conf.TwoPCChan[txnID]
+	conf.TwoPCLock.Unlock()
+	if !exists {
+		fmt.Printf("no one waiting on 2pc message for txn %s, dropping it\n", txnID)
+		return
+	}
+
+	select {
+	case ch <- req:
+	default:
+	}
+}
+
+func GetClusterServers(conf *config.Config, clusters []int32) []int32 {
+	var servers []int32
+	for _, cluster := range clusters {
+		servers = append(servers, conf.MapClusterToServers[cluster]...)
+	}
+	return servers
+}
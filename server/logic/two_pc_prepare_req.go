@@ -3,13 +3,12 @@ package logic
 import (
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
-	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"database/sql"
 	"errors"
 	"fmt"
-	"math"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"sync"
 	"time"
 )
@@ -29,7 +28,7 @@ func ReceiveTwoPCPrepareRequest(ctx context.Context, conf *config.Config, req *c
 	}
 
 	txnReq := &common.TxnRequest{}
-	err = json.Unmarshal(req.TxnRequest, txnReq)
+	err = signedPayload.Unmarshal(req.TxnRequest, txnReq)
 	if err != nil {
 		return err
 	}
@@ -44,8 +43,28 @@ func ReceiveTwoPCPrepareRequest(ctx context.Context, conf *config.Config, req *c
 		return nil
 	}
 
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnReq.TxnID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if dbTxn != nil {
+		return ResendTwoPCVote(conf, dbTxn)
+	}
+
+	// the txn is admitted here with wait-die like in the coordinator cluster, and both have to order it by
+	// the same timestamp, stamping a fresh one would let waits between the clusters form a cycle
+	if txnReq.CreatedAt == nil {
+		return errors.New("2pc prepare without the timestamp of the coordinator")
+	}
 	err = ProcessTxn(ctx, conf, txnReq, false)
 	if err != nil {
+		fmt.Printf("could not prepare txn %s: %v\n", txnReq.TxnID, err)
+		go func() {
+			err := StartTwoPCAbortVote(conf, txnReq)
+			if err != nil {
+				fmt.Printf("StartTwoPCAbortVote error: %v\n", err)
+			}
+		}()
 		return err
 	}
 	return nil
@@ -54,63 +73,73 @@ func ReceiveTwoPCPrepareRequest(ctx context.Context, conf *config.Config, req *c
 // participant leader sends 2pc prepare response to coordinator nodes
 
 func SendTwoPCPrepareResponse(conf *config.Config, req *common.TxnRequest) error {
-	commitMessages, err := datastore.GetPBFTMessages(conf.DataStore, req.TxnID, MessageTypeCommit)
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
 	if err != nil {
 		return err
 	}
 
-	cert := &common.Certificate{
-		Messages: commitMessages,
+	timeouts := StartTwoPCTimers(conf, req.TxnID, []int32{GetClusterNumber(conf, dbTxn.Sender)})
+	go WaitForCoordinatorResponse(conf, req, timeouts)
+
+	err = SendTwoPCVote(conf, dbTxn, req.TxnID, OutcomeCommit)
+	if err != nil {
+		return err
+	}
+	// a double voting leader tells the coordinator the cluster aborts the txn as well
+	if HasFault(conf, FaultDoubleVote) {
+		return SendTwoPCVote(conf, dbTxn, req.TxnID, OutcomeAbort)
 	}
+	return nil
+}
 
-	certBytes, err := json.Marshal(cert)
+// SendTwoPCVote sends the commit certificate of certTxnID to the coordinator cluster as the vote of this
+// cluster on txn
+
+func SendTwoPCVote(conf *config.Config, txn *common.TxnRequest, certTxnID, outcome string) error {
+	cert, err := GetThresholdCertificate(conf, txn, certTxnID, outcome)
 	if err != nil {
 		return err
 	}
 
-	sign, err := SignMessage(conf.PrivateKey, certBytes)
+	certBytes, err := signedPayload.Marshal(cert)
 	if err != nil {
 		return err
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, req.TxnID)
+	sign, err := SignMessage(conf.PrivateKey, certBytes)
 	if err != nil {
 		return err
 	}
 
-	txnBytes, err := json.Marshal(dbTxn)
+	txnBytes, err := signedPayload.Marshal(txn)
 	if err != nil {
 		return err
 	}
 
-	commitReq := &common.PBFTRequestResponse{
+	voteReq := &common.PBFTRequestResponse{
 		SignedMessage: certBytes,
 		Sign:          sign,
 		TxnRequest:    txnBytes,
 		ServerNo:      conf.ServerNumber,
-		Outcome:       OutcomeCommit,
+		Outcome:       outcome,
 	}
 
-	fmt.Printf("sending response to coordinator cluster for txn: %s\n", dbTxn.TxnID)
-
-	conf.TwoPCLock.Lock()
-	conf.TwoPCTimer[req.TxnID] = time.NewTimer(5 * time.Second)
-	conf.TwoPCChan[req.TxnID] = make(chan *common.PBFTRequestResponse)
-	conf.TwoPCLock.Unlock()
-	go WaitForCoordinatorResponse(conf, req)
-
-	senderCluster := math.Ceil(float64(req.Sender) / float64(conf.DataItemsPerShard))
+	fmt.Printf("sending %s vote to coordinator cluster for txn: %s\n", outcome, txn.TxnID)
 
 	var wg sync.WaitGroup
-	for _, serverNo := range conf.MapClusterToServers[int32(senderCluster)] {
+	for _, serverNo := range conf.MapClusterToServers[GetClusterNumber(conf, txn.Sender)] {
 		wg.Add(1)
 		go func(serverAddress string) {
 			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
 			server, err := conf.Pool.GetServer(serverAddress)
 			if err != nil {
 				fmt.Println(err)
+				return
 			}
-			_, err = server.TwoPCPrepareResponse(context.Background(), commitReq)
+			_, err = server.TwoPCPrepareResponse(context.Background(), voteReq)
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -122,37 +151,41 @@ func SendTwoPCPrepareResponse(conf *config.Config, req *common.TxnRequest) error
 	return nil
 }
 
+// VerifyTwoPCMessages checks that a certificate from the other cluster carries the threshold signature of
+// that cluster over the digest implied by req.Outcome, so a leader cannot turn an abort vote into a commit or
+// the other way round
+
 func VerifyTwoPCMessages(conf *config.Config, req *common.PBFTRequestResponse, messageType string) error {
 	cert := &common.Certificate{}
-	err := json.Unmarshal(req.SignedMessage, cert)
+	err := signedPayload.Unmarshal(req.SignedMessage, cert)
 	if err != nil {
 		return err
 	}
 
-	if len(cert.Messages) < int(conf.Majority-1) {
-		return errors.New("not enough messages")
+	txnReq := &common.TxnRequest{}
+	err = signedPayload.Unmarshal(req.TxnRequest, txnReq)
+	if err != nil {
+		return err
 	}
 
-	for _, commitMessage := range cert.Messages {
-		serverAddr := config.MapServerNumberToAddress[commitMessage.Sender]
-		publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
-		if err != nil {
-			return err
-		}
-
-		payload, _ := base64.StdEncoding.DecodeString(commitMessage.Payload)
-		sign, _ := base64.StdEncoding.DecodeString(commitMessage.Sign)
-
-		err = VerifySignature(publicKey, payload, sign)
-		if err != nil {
-			return err
-		}
-
-		commitMessage.MessageType = messageType
-		err = datastore.InsertPBFTMessage(conf.DataStore, commitMessage)
-		if err != nil {
-			return err
+	cluster := GetClusterNumber(conf, txnReq.Sender)
+	if messageType == MessageTypeTwoPCPrepareFromParticipant || messageType == MessageTypeTwoPCAbortFromParticipant {
+		cluster = GetServerClusterNumber(conf, req.ServerNo)
+		if !IsParticipantCluster(conf, txnReq, cluster) {
+			return errors.New("vote from a cluster outside the txn")
 		}
 	}
-	return nil
+	err = VerifyThresholdCertificate(conf, cluster, txnReq, GetTwoPCVoteDigest(txnReq, req.Outcome), cert)
+	if err != nil {
+		return err
+	}
+
+	return conf.DataStore.InsertPBFTMessage(&common.PBFTMessage{
+		TxnID:       txnReq.TxnID,
+		MessageType: messageType,
+		Sender:      req.ServerNo,
+		Sign:        req.Sign,
+		Payload:     req.SignedMessage,
+		CreatedAt:   timestamppb.New(time.Now()),
+	})
 }
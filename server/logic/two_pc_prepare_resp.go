@@ -3,23 +3,26 @@ package logic
 import (
 	common "GolandProjects/2pcbyz-gautamsardana/api_common"
 	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
 	"context"
-	"encoding/json"
 	"fmt"
-	"math"
 	"sync"
 )
 
 func ReceiveTwoPCPrepareResponse(ctx context.Context, conf *config.Config, resp *common.PBFTRequestResponse) error {
 	txnReq := &common.TxnRequest{}
-	err := json.Unmarshal(resp.TxnRequest, txnReq)
+	err := signedPayload.Unmarshal(resp.TxnRequest, txnReq)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("received response from participant cluster for txn: %s\n", txnReq.TxnID)
 
-	err = VerifyTwoPCMessages(conf, resp, MessageTypeTwoPCPrepareFromParticipant)
+	messageType := MessageTypeTwoPCPrepareFromParticipant
+	if resp.Outcome == OutcomeAbort {
+		messageType = MessageTypeTwoPCAbortFromParticipant
+	}
+	err = VerifyTwoPCMessages(conf, resp, messageType)
 	if err != nil {
 		return err
 	}
@@ -28,29 +31,50 @@ func ReceiveTwoPCPrepareResponse(ctx context.Context, conf *config.Config, resp
 		return nil
 	}
 
-	conf.TwoPCChan[txnReq.TxnID] <- resp
+	NotifyTwoPCChan(conf, txnReq.TxnID, resp)
 
 	return nil
 }
 
-func WaitForParticipantResponse(conf *config.Config, req *common.TxnRequest) {
-	select {
-	case <-conf.TwoPCTimer[req.TxnID].C:
-		fmt.Printf("no response from participant cluster, outcome = abort\n")
-		ProcessTwoPCPrepareResponse(context.Background(), conf, req, OutcomeAbort)
-		return
-	case resp := <-conf.TwoPCChan[req.TxnID]:
-		conf.TwoPCTimer[req.TxnID].Stop()
-
-		if resp.Outcome == OutcomeCommit {
-			fmt.Printf("got response from participant cluster, outcome = commit\n")
-			ProcessTwoPCPrepareResponse(context.Background(), conf, req, OutcomeCommit)
-		} else if resp.Outcome == OutcomeAbort {
-			fmt.Printf("got response from participant cluster,  outcome = abort\n")
-			ProcessTwoPCPrepareResponse(context.Background(), conf, req, OutcomeAbort)
+// WaitForParticipantResponse commits once every participant cluster voted commit and aborts as soon as one
+// of them votes abort or its timer runs out
+
+func WaitForParticipantResponse(conf *config.Config, req *common.TxnRequest, timeouts <-chan int32) {
+	conf.TwoPCLock.Lock()
+	votes := conf.TwoPCChan[req.TxnID]
+	conf.TwoPCLock.Unlock()
+
+	participants := GetParticipantClusters(conf, req)
+	committed := make(map[int32]bool)
+	outcome := OutcomeCommit
+	for len(committed) < len(participants) && outcome == OutcomeCommit {
+		select {
+		case cluster := <-timeouts:
+			if committed[cluster] {
+				continue
+			}
+			fmt.Printf("no response from participant cluster %d, outcome = abort\n", cluster)
+			outcome = OutcomeAbort
+		case resp := <-votes:
+			cluster := GetServerClusterNumber(conf, resp.ServerNo)
+			StopTwoPCTimer(conf, req.TxnID, cluster)
+
+			if resp.Outcome == OutcomeAbort {
+				fmt.Printf("got response from participant cluster %d, outcome = abort\n", cluster)
+				outcome = OutcomeAbort
+			} else {
+				fmt.Printf("got response from participant cluster %d, outcome = commit\n", cluster)
+				committed[cluster] = true
+			}
 		}
-		return
 	}
+	ClearTwoPCState(conf, req.TxnID)
+
+	err := SaveTwoPCState(conf, req, outcome)
+	if err != nil {
+		fmt.Printf("SaveTwoPCState error: %v\n", err)
+	}
+	ProcessTwoPCPrepareResponse(context.Background(), conf, req, outcome)
 }
 
 func ProcessTwoPCPrepareResponse(ctx context.Context, conf *config.Config, txnReq *common.TxnRequest, outcome string) {
@@ -74,7 +98,7 @@ func ProcessTwoPCPrepareResponse(ctx context.Context, conf *config.Config, txnRe
 		SendReplyToClient(conf, txnReq)
 	}()
 
-	reqBytes, err := json.Marshal(txnReq)
+	reqBytes, err := signedPayload.Marshal(txnReq)
 	if err != nil {
 		fmt.Printf("Failed to marshal req: %v\n", err)
 	}
@@ -91,15 +115,17 @@ func ProcessTwoPCPrepareResponse(ctx context.Context, conf *config.Config, txnRe
 		ServerNo:      conf.ServerNumber,
 	}
 
-	receiverCluster := math.Ceil(float64(txnReq.Receiver) / float64(conf.DataItemsPerShard))
 	var wg sync.WaitGroup
-	for _, serverNo := range conf.MapClusterToServers[int32(receiverCluster)] {
+	for _, serverNo := range GetClusterServers(conf, GetParticipantClusters(conf, txnReq)) {
 		if serverNo == conf.ServerNumber {
 			continue
 		}
 		wg.Add(1)
 		go func(serverAddress string) {
 			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
 			server, err := conf.Pool.GetServer(serverAddress)
 			if err != nil {
 				fmt.Println(err)
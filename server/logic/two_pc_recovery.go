@@ -0,0 +1,265 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// every replica records the cross-shard txns it executed and their outcome once decided, so a 2PC
+// interrupted by a crash or a leader change can be picked up again. the coordinator leader resends its
+// prepare request or finishes a decision it already took, participants ask the coordinator cluster for
+// the decision and apply it once f+1 of its replicas agree on it
+
+func SaveTwoPCState(conf *config.Config, txn *common.TxnRequest, outcome string) error {
+	return conf.DataStore.UpsertTwoPCState(NewTwoPCState(txn, outcome))
+}
+
+func NewTwoPCState(txn *common.TxnRequest, outcome string) datastore.TwoPCState {
+	role := RoleParticipant
+	if txn.Type == TypeCrossShardSender {
+		role = RoleCoordinator
+	}
+	return datastore.TwoPCState{
+		TxnID:   txn.TxnID,
+		Role:    role,
+		Outcome: outcome,
+	}
+}
+
+func TwoPCRecoveryCron(conf *config.Config) {
+	RecoverTwoPC(conf, time.Now())
+
+	ticker := time.NewTicker(2 * TwoPCTimeout)
+	for range ticker.C {
+		RecoverTwoPC(conf, time.Now().Add(-2*TwoPCTimeout))
+	}
+}
+
+// RecoverTwoPC resumes every unfinished 2PC last touched before the given time that nobody waits on
+
+func RecoverTwoPC(conf *config.Config, before time.Time) {
+	if !conf.IsAlive {
+		return
+	}
+
+	states, err := conf.DataStore.GetUnfinishedTwoPCStates(before)
+	if err != nil {
+		fmt.Printf("RecoverTwoPC error: %v\n", err)
+		return
+	}
+
+	for _, state := range states {
+		if IsTwoPCWaiting(conf, state.TxnID) {
+			continue
+		}
+		txn, err := conf.DataStore.GetTransactionByTxnID(state.TxnID)
+		if err != nil {
+			fmt.Printf("RecoverTwoPC error: %v\n", err)
+			continue
+		}
+
+		if state.Role == RoleParticipant {
+			go func() {
+				err := RequestTwoPCDecision(conf, txn)
+				if err != nil {
+					fmt.Printf("RequestTwoPCDecision error for txn %s: %v\n", txn.TxnID, err)
+				}
+			}()
+			continue
+		}
+
+		if GetLeaderNumber(conf, conf.ClusterNumber) != conf.ServerNumber {
+			continue
+		}
+		fmt.Printf("resuming 2pc for txn %s as coordinator, outcome so far: %q\n", txn.TxnID, state.Outcome)
+		if state.Outcome != EmptyString {
+			go ProcessTwoPCPrepareResponse(context.Background(), conf, txn, state.Outcome)
+			continue
+		}
+		go func() {
+			err := StartTwoPC(conf, txn)
+			if err != nil {
+				fmt.Printf("StartTwoPC error: %v\n", err)
+			}
+		}()
+	}
+}
+
+// ResendTwoPCVote answers a repeated prepare request for a txn this cluster already handled with the vote
+// it sent the first time, or with nothing while the txn is still being prepared
+
+func ResendTwoPCVote(conf *config.Config, txn *common.TxnRequest) error {
+	switch txn.Status {
+	case Status2PCPending, Status2PCPrePrepared, Status2PCPrepared, Status2PCCommitted, StatusExecuted:
+		return SendTwoPCVote(conf, txn, txn.TxnID, OutcomeCommit)
+	case StatusAborted, StatusFailed:
+		voteTxn, err := conf.DataStore.GetTransactionByTxnID(GetAbortVoteID(txn.TxnID))
+		if err == sql.ErrNoRows {
+			return StartTwoPCAbortVote(conf, txn)
+		}
+		if err != nil {
+			return err
+		}
+		if voteTxn.Status == StatusExecuted {
+			return SendTwoPCAbortVoteOnQuorum(conf, voteTxn.TxnID)
+		}
+	}
+	return nil
+}
+
+// RequestTwoPCDecision is the termination protocol of a participant replica, it asks every replica of the
+// coordinator cluster for the outcome of txn and applies it once f+1 of them report the same one
+
+func RequestTwoPCDecision(conf *config.Config, txn *common.TxnRequest) error {
+	decisionBytes, err := signedPayload.Marshal(&common.TwoPCDecisionMessage{TxnID: txn.TxnID})
+	if err != nil {
+		return err
+	}
+	sign, err := SignMessage(conf.PrivateKey, decisionBytes)
+	if err != nil {
+		return err
+	}
+
+	decisionReq := &common.PBFTRequestResponse{
+		SignedMessage: decisionBytes,
+		Sign:          sign,
+		ServerNo:      conf.ServerNumber,
+	}
+
+	fmt.Printf("asking coordinator cluster for the outcome of txn %s\n", txn.TxnID)
+
+	coordinatorCluster := GetClusterNumber(conf, txn.Sender)
+	var lock sync.Mutex
+	votes := make(map[string]int32)
+
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[coordinatorCluster] {
+		wg.Add(1)
+		go func(serverNo int32) {
+			defer wg.Done()
+			server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[serverNo])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			resp, err := server.TwoPCDecision(context.Background(), decisionReq)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			decision, err := VerifyTwoPCDecision(conf, serverNo, resp)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			if decision.TxnID != txn.TxnID || decision.Outcome == EmptyString {
+				return
+			}
+			lock.Lock()
+			votes[decision.Outcome]++
+			lock.Unlock()
+		}(serverNo)
+	}
+	wg.Wait()
+
+	for outcome, count := range votes {
+		if count < GetFaultTolerance(conf)+1 {
+			continue
+		}
+		fmt.Printf("coordinator cluster decided %s for txn %s\n", outcome, txn.TxnID)
+		if outcome == OutcomeCommit {
+			return TwoPCCommit(context.Background(), conf, txn)
+		}
+		return TwoPCAbort(context.Background(), conf, txn)
+	}
+	return errors.New("coordinator cluster has not decided yet")
+}
+
+func ReceiveTwoPCDecisionRequest(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) (*common.PBFTRequestResponse, error) {
+	if !conf.IsAlive {
+		return nil, errors.New("server dead")
+	}
+
+	serverAddr := config.MapServerNumberToAddress[req.ServerNo]
+	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &common.TwoPCDecisionMessage{}
+	err = signedPayload.Unmarshal(req.SignedMessage, decision)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := conf.DataStore.GetTwoPCState(decision.TxnID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if state != nil && state.Role == RoleCoordinator {
+		decision.Outcome = state.Outcome
+	}
+
+	decisionBytes, err := signedPayload.Marshal(decision)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := SignMessage(conf.PrivateKey, decisionBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.PBFTRequestResponse{
+		SignedMessage: decisionBytes,
+		Sign:          sign,
+		ServerNo:      conf.ServerNumber,
+	}, nil
+}
+
+func VerifyTwoPCDecision(conf *config.Config, serverNo int32, resp *common.PBFTRequestResponse) (*common.TwoPCDecisionMessage, error) {
+	if resp.ServerNo != serverNo {
+		return nil, errors.New("decision signed by another server")
+	}
+
+	serverAddr := config.MapServerNumberToAddress[serverNo]
+	publicKey, err := conf.PublicKeys.GetPublicKey(serverAddr)
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, resp.SignedMessage, resp.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &common.TwoPCDecisionMessage{}
+	err = signedPayload.Unmarshal(resp.SignedMessage, decision)
+	if err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
+
+func IsTwoPCWaiting(conf *config.Config, txnID string) bool {
+	conf.TwoPCLock.Lock()
+	defer conf.TwoPCLock.Unlock()
+
+	_, exists := conf.TwoPCChan[txnID]
+	return exists
+}
+
+func IsTwoPCFinished(txn *common.TxnRequest) bool {
+	return txn.Status == StatusExecuted || txn.Status == StatusAborted || txn.Status == StatusFailed
+}
@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+)
+
+// a multi-shard txn moves Amount out of the sender into several legs, each crediting one receiver, and its
+// receivers can live in any number of clusters. the sender cluster coordinates 2PC with every other cluster
+// holding a receiver. a plain txn is the same thing with a single leg made of Receiver and Amount
+
+func GetTxnLegs(req *common.TxnRequest) []*common.TxnLeg {
+	if len(req.Legs) > 0 {
+		return req.Legs
+	}
+	return []*common.TxnLeg{{Receiver: req.Receiver, Amount: req.Amount}}
+}
+
+// GetLocalLegs returns the legs crediting users of this cluster
+
+func GetLocalLegs(conf *config.Config, req *common.TxnRequest) []*common.TxnLeg {
+	var legs []*common.TxnLeg
+	for _, leg := range GetTxnLegs(req) {
+		if GetClusterNumber(conf, leg.Receiver) == conf.ClusterNumber {
+			legs = append(legs, leg)
+		}
+	}
+	return legs
+}
+
+// GetParticipantClusters returns the clusters other than the sender's that hold a receiver of req, in order
+
+func GetParticipantClusters(conf *config.Config, req *common.TxnRequest) []int32 {
+	senderCluster := GetClusterNumber(conf, req.Sender)
+	seen := make(map[int32]bool)
+	var clusters []int32
+	for _, leg := range GetTxnLegs(req) {
+		cluster := GetClusterNumber(conf, leg.Receiver)
+		if cluster == senderCluster || seen[cluster] {
+			continue
+		}
+		seen[cluster] = true
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i] < clusters[j] })
+	return clusters
+}
+
+func IsParticipantCluster(conf *config.Config, req *common.TxnRequest, cluster int32) bool {
+	for _, participant := range GetParticipantClusters(conf, req) {
+		if participant == cluster {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyTxnLegs checks the legs of a multi-shard txn credit distinct users other than the sender and add
+// up to the amount taken from the sender, replicas lock every leg receiver so overlapping legs would deadlock
+
+func VerifyTxnLegs(req *common.TxnRequest) error {
+	if len(req.Legs) == 0 {
+		return nil
+	}
+
+	receivers := make(map[int32]bool)
+	var total float32
+	for _, leg := range req.Legs {
+		if leg.Amount <= 0 {
+			return errors.New("invalid leg amount")
+		}
+		if leg.Receiver == req.Sender || receivers[leg.Receiver] {
+			return errors.New("txn legs overlap")
+		}
+		receivers[leg.Receiver] = true
+		total += leg.Amount
+	}
+
+	if math.Abs(float64(total-req.Amount)) > 0.001 {
+		return errors.New("txn legs do not add up to the amount")
+	}
+	return nil
+}
+
+func IsClientTxnType(txnType string) bool {
+	return txnType == TypeIntraShard || txnType == TypeCrossShardSender || txnType == TypeCrossShardReceiver
+}
@@ -0,0 +1,409 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+// replicas start a timer for every request they forward to the leader or get a pre-prepare for,
+// and ask for a view change if it is not executed before the timer expires
+
+func StartRequestTimer(conf *config.Config, txnReq *common.TxnRequest) {
+	conf.ViewChangeLock.Lock()
+	defer conf.ViewChangeLock.Unlock()
+
+	if _, exists := conf.RequestTimers[txnReq.TxnID]; exists {
+		return
+	}
+
+	txnID := txnReq.TxnID
+	conf.RequestTimers[txnID] = time.AfterFunc(GetViewChangeTimeout(conf), func() {
+		conf.ViewChangeLock.Lock()
+		delete(conf.RequestTimers, txnID)
+		conf.ViewChangeLock.Unlock()
+
+		if conf.PBFT.IsViewChangeInProgress() {
+			return
+		}
+		fmt.Printf("request timer expired for txn: %s\n", txnID)
+		InitiateViewChange(conf, conf.PBFT.GetViewNumber()+1)
+	})
+}
+
+func StopRequestTimer(conf *config.Config, txnID string) {
+	conf.ViewChangeLock.Lock()
+	defer conf.ViewChangeLock.Unlock()
+
+	if timer, exists := conf.RequestTimers[txnID]; exists {
+		timer.Stop()
+		delete(conf.RequestTimers, txnID)
+	}
+	delete(conf.ForwardedRequests, txnID)
+}
+
+func ForwardTxnToLeader(conf *config.Config, req *common.TxnRequest) error {
+	// a request already in the log of this replica is being ordered and its request timer is running
+	dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if dbTxn != nil {
+		fmt.Printf("txn %s is already known with status %s, not forwarding it\n", req.TxnID, dbTxn.Status)
+		return nil
+	}
+
+	conf.ViewChangeLock.Lock()
+	conf.ForwardedRequests[req.TxnID] = req
+	conf.ViewChangeLock.Unlock()
+
+	if conf.PBFT.IsViewChangeInProgress() {
+		fmt.Printf("view change in progress, holding txn %s until new view\n", req.TxnID)
+		return nil
+	}
+
+	leader := GetLeaderNumber(conf, conf.ClusterNumber)
+	fmt.Printf("forwarding txn %s to leader %d\n", req.TxnID, leader)
+	StartRequestTimer(conf, req)
+
+	server, err := conf.Pool.GetServer(config.MapServerNumberToAddress[leader])
+	if err != nil {
+		return err
+	}
+	_, err = server.ProcessTxn(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func InitiateViewChange(conf *config.Config, newView int32) {
+	conf.ViewChangeLock.Lock()
+	defer conf.ViewChangeLock.Unlock()
+
+	if !conf.PBFT.MoveToView(newView) {
+		return
+	}
+	fmt.Printf("starting view change to view %d\n", newView)
+
+	for txnID, timer := range conf.RequestTimers {
+		timer.Stop()
+		delete(conf.RequestTimers, txnID)
+	}
+	DropPendingBatch(conf)
+
+	if conf.ViewChangeTimer != nil {
+		conf.ViewChangeTimer.Stop()
+	}
+	conf.ViewChangeTimer = time.AfterFunc(GetViewChangeTimeout(conf), func() {
+		if conf.PBFT.IsViewChangeInProgress() && conf.PBFT.GetViewNumber() == newView {
+			fmt.Printf("view change to view %d timed out\n", newView)
+			InitiateViewChange(conf, newView+1)
+		}
+	})
+
+	go func() {
+		err := SendViewChange(conf, newView)
+		if err != nil {
+			fmt.Printf("SendViewChange error: %v\n", err)
+		}
+	}()
+}
+
+func SendViewChange(conf *config.Config, newView int32) error {
+	vcMessage, err := GetViewChangeMessage(conf, newView)
+	if err != nil {
+		return err
+	}
+
+	vcBytes, err := signedPayload.Marshal(vcMessage)
+	if err != nil {
+		return err
+	}
+	sign, err := SignMessage(conf.PrivateKey, vcBytes)
+	if err != nil {
+		return err
+	}
+
+	vcReq := &common.PBFTRequestResponse{
+		SignedMessage: vcBytes,
+		Sign:          sign,
+		ServerNo:      conf.ServerNumber,
+	}
+
+	err = AddViewChangeMessage(conf, vcReq, vcMessage)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("sending view change for view %d with %d prepared certificates\n", newView, len(vcMessage.PreparedCertificates))
+
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.ViewChange(context.Background(), vcReq)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(config.MapServerNumberToAddress[serverNo])
+	}
+	wg.Wait()
+
+	CheckViewChangeQuorum(conf, newView)
+	return nil
+}
+
+func ReceiveViewChange(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+
+	vcMessage, err := VerifyViewChange(conf, req)
+	if err != nil {
+		return err
+	}
+
+	currentView := conf.PBFT.GetViewNumber()
+	if vcMessage.ViewNumber < currentView ||
+		(vcMessage.ViewNumber == currentView && !conf.PBFT.IsViewChangeInProgress()) {
+		return errors.New("stale view change")
+	}
+
+	fmt.Printf("received view change for view %d from server %d\n", vcMessage.ViewNumber, req.ServerNo)
+
+	err = AddViewChangeMessage(conf, req, vcMessage)
+	if err != nil {
+		return err
+	}
+
+	CheckViewChangeQuorum(conf, vcMessage.ViewNumber)
+	return nil
+}
+
+// CheckViewChangeQuorum joins a view change once f+1 replicas ask for it, and lets the primary of the
+// new view send NEW-VIEW once it holds 2f+1 view change messages
+
+func CheckViewChangeQuorum(conf *config.Config, view int32) {
+	vcMessages, err := conf.DataStore.GetPBFTMessages(GetViewChangeID(view), MessageTypeViewChange)
+	if err != nil {
+		fmt.Printf("CheckViewChangeQuorum error: %v\n", err)
+		return
+	}
+
+	if int32(len(vcMessages)) >= GetFaultTolerance(conf)+1 && view > conf.PBFT.GetViewNumber() {
+		InitiateViewChange(conf, view)
+	}
+
+	if int32(len(vcMessages)) < conf.Majority ||
+		GetLeaderNumberForView(conf, conf.ClusterNumber, view) != conf.ServerNumber ||
+		conf.PBFT.GetViewNumber() != view {
+		return
+	}
+
+	err = SendNewView(conf, view, vcMessages)
+	if err != nil {
+		fmt.Printf("SendNewView error: %v\n", err)
+	}
+}
+
+func SendNewView(conf *config.Config, view int32, vcMessages []*common.PBFTMessage) error {
+	viewChanges, err := DecodeViewChangeMessages(vcMessages)
+	if err != nil {
+		return err
+	}
+
+	if !conf.PBFT.InstallView(view) {
+		return nil
+	}
+	StopViewChangeTimer(conf)
+
+	stableCheckpoint := GetNewViewStableCheckpoint(viewChanges)
+	nvMessage := &common.NewViewMessage{
+		ViewNumber:       view,
+		ViewChanges:      vcMessages,
+		PrePrepares:      ComputeNewViewPrePrepares(view, stableCheckpoint, viewChanges),
+		StableCheckpoint: stableCheckpoint,
+	}
+
+	nvBytes, err := signedPayload.Marshal(nvMessage)
+	if err != nil {
+		return err
+	}
+	sign, err := SignMessage(conf.PrivateKey, nvBytes)
+	if err != nil {
+		return err
+	}
+
+	nvReq := &common.PBFTRequestResponse{
+		SignedMessage: nvBytes,
+		Sign:          sign,
+		ServerNo:      conf.ServerNumber,
+	}
+
+	fmt.Printf("i am the new leader, sending new view %d with %d pre-prepares\n", view, len(nvMessage.PrePrepares))
+
+	err = ApplyNewView(conf, nvMessage)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, serverNo := range conf.MapClusterToServers[conf.ClusterNumber] {
+		if serverNo == conf.ServerNumber {
+			continue
+		}
+		wg.Add(1)
+		go func(serverAddress string) {
+			defer wg.Done()
+			if DelayOrDropMessage(conf) {
+				return
+			}
+			server, err := conf.Pool.GetServer(serverAddress)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			_, err = server.NewView(context.Background(), nvReq)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}(config.MapServerNumberToAddress[serverNo])
+	}
+	wg.Wait()
+
+	go func() {
+		ReproposeTxns(conf, nvMessage.PrePrepares)
+		ResumeForwardedRequests(conf)
+	}()
+
+	return nil
+}
+
+func ReceiveNewView(ctx context.Context, conf *config.Config, req *common.PBFTRequestResponse) error {
+	if !conf.IsAlive {
+		return errors.New("server dead")
+	}
+
+	nvMessage, err := VerifyNewView(conf, req)
+	if err != nil {
+		return err
+	}
+
+	if !conf.PBFT.InstallView(nvMessage.ViewNumber) {
+		return errors.New("stale new view")
+	}
+	StopViewChangeTimer(conf)
+
+	fmt.Printf("received new view %d from server %d\n", nvMessage.ViewNumber, req.ServerNo)
+
+	err = ApplyNewView(conf, nvMessage)
+	if err != nil {
+		return err
+	}
+
+	go ResumeForwardedRequests(conf)
+	return nil
+}
+
+// ReproposeTxns runs consensus in the new view for every sequence number carried over by NEW-VIEW
+
+func ReproposeTxns(conf *config.Config, prePrepares []*common.TxnRequest) {
+	for _, txn := range prePrepares {
+		dbTxn, err := conf.DataStore.GetTransactionByTxnID(txn.TxnID)
+		if err != nil && err != sql.ErrNoRows {
+			fmt.Printf("ReproposeTxns error: %v\n", err)
+			continue
+		}
+
+		if dbTxn == nil {
+			if !IsInternalTxn(txn) {
+				txn.Type = GetTxnType(conf, txn)
+			}
+			// the new view has to fill this sequence number either way, so a lock timeout does not stop it
+			err = AcquireLock(conf, txn)
+			if err != nil {
+				fmt.Printf("ReproposeTxns error: %v\n", err)
+			}
+			txn.Status = StatusInit
+			err = InsertTxnWithBatch(conf, txn)
+			if err != nil {
+				fmt.Printf("ReproposeTxns error: %v\n", err)
+				continue
+			}
+		} else {
+			txn = dbTxn
+			err = LoadBatchMembers(conf, txn)
+			if err != nil {
+				fmt.Printf("ReproposeTxns error: %v\n", err)
+				continue
+			}
+		}
+
+		fmt.Printf("re-proposing txn %s with sequence %d in view %d\n", txn.TxnID, txn.SeqNo, txn.ViewNo)
+
+		err = StartConsensus(conf, txn, EmptyString)
+		if err != nil {
+			fmt.Printf("ReproposeTxns error: %v\n", err)
+			continue
+		}
+		SendExecuteSignal(conf, txn)
+	}
+}
+
+// ResumeForwardedRequests hands requests held during the view change to the new leader
+
+func ResumeForwardedRequests(conf *config.Config) {
+	conf.ViewChangeLock.Lock()
+	var forwardedRequests []*common.TxnRequest
+	for _, req := range conf.ForwardedRequests {
+		forwardedRequests = append(forwardedRequests, req)
+	}
+	conf.ViewChangeLock.Unlock()
+
+	for _, req := range forwardedRequests {
+		dbTxn, err := conf.DataStore.GetTransactionByTxnID(req.TxnID)
+		if err != nil && err != sql.ErrNoRows {
+			fmt.Printf("ResumeForwardedRequests error: %v\n", err)
+			continue
+		}
+		if dbTxn != nil {
+			continue
+		}
+
+		if GetLeaderNumber(conf, conf.ClusterNumber) == conf.ServerNumber {
+			StopRequestTimer(conf, req.TxnID)
+			go func(req *common.TxnRequest) {
+				err := ProcessTxn(context.Background(), conf, req, false)
+				if err != nil {
+					fmt.Printf("ProcessTxnError: %v\n", err)
+				}
+			}(req)
+			continue
+		}
+
+		err = ForwardTxnToLeader(conf, req)
+		if err != nil {
+			fmt.Printf("ResumeForwardedRequests error: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sort"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/config"
+	signedPayload "GolandProjects/2pcbyz-gautamsardana/signed_payload"
+)
+
+func GetViewChangeTimeout(conf *config.Config) time.Duration {
+	return time.Duration(conf.ViewChangeTimeout) * time.Millisecond
+}
+
+func StopViewChangeTimer(conf *config.Config) {
+	conf.ViewChangeLock.Lock()
+	defer conf.ViewChangeLock.Unlock()
+
+	if conf.ViewChangeTimer != nil {
+		conf.ViewChangeTimer.Stop()
+		conf.ViewChangeTimer = nil
+	}
+}
+
+// view change messages are stored in pbft_messages under a pseudo txn id per view
+
+func GetViewChangeID(view int32) string {
+	return fmt.Sprintf("view-change-%d", view)
+}
+
+func GetNoOpTxnID(view, seqNo int32) string {
+	return fmt.Sprintf("no-op-%d-%d", view, seqNo)
+}
+
+func GetViewChangeMessage(conf *config.Config, newView int32) (*common.ViewChangeMessage, error) {
+	stableCheckpoint := conf.PBFT.GetLowWatermark()
+	txns, err := conf.DataStore.GetTransactionsAfterSequence(stableCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	vcMessage := &common.ViewChangeMessage{
+		ViewNumber:           newView,
+		LastExecutedSequence: conf.PBFT.GetLastExecutedSequenceNumber(),
+		StableCheckpoint:     stableCheckpoint,
+	}
+	if stableCheckpoint > 0 {
+		_, vcMessage.CheckpointMessages, err = GetCheckpointProof(conf, stableCheckpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, txn := range txns {
+		if txn.BatchID != EmptyString {
+			continue
+		}
+		err = LoadBatchMembers(conf, txn)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := GetPreparedCertificate(conf, txn)
+		if err != nil {
+			return nil, err
+		}
+		if cert != nil {
+			vcMessage.PreparedCertificates = append(vcMessage.PreparedCertificates, cert)
+		}
+	}
+	return vcMessage, nil
+}
+
+// GetPreparedCertificate returns the prepare messages of the highest view in which txn got 2f matching
+// prepares, or nil if it never prepared locally
+
+func GetPreparedCertificate(conf *config.Config, txn *common.TxnRequest) (*common.PreparedCertificate, error) {
+	cert, err := GetQuorumCertificate(conf, txn, MessageTypePrepare)
+	if err != nil || cert == nil {
+		return nil, err
+	}
+	return &common.PreparedCertificate{Txn: txn, Certificate: cert}, nil
+}
+
+// GetQuorumCertificate collects the signed messageType messages from the highest view in which 2f distinct
+// replicas signed txn's sequence number and digest
+
+func GetQuorumCertificate(conf *config.Config, txn *common.TxnRequest, messageType string) (*common.Certificate, error) {
+	messages, err := conf.DataStore.GetPBFTMessages(txn.TxnID, messageType)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := GetTxnDigest(txn)
+	messagesByView := make(map[int32][]*common.PBFTMessage)
+	senders := make(map[int32]map[int32]bool)
+	for _, message := range messages {
+		if len(message.Sign) == 0 {
+			continue
+		}
+		signedMessage := &common.SignedMessage{}
+		if err = signedPayload.Unmarshal(message.Payload, signedMessage); err != nil {
+			continue
+		}
+		if signedMessage.SequenceNumber != txn.SeqNo || signedMessage.Digest != digest {
+			continue
+		}
+		view := signedMessage.ViewNumber
+		if senders[view] == nil {
+			senders[view] = make(map[int32]bool)
+		}
+		if senders[view][message.Sender] {
+			continue
+		}
+		senders[view][message.Sender] = true
+		messagesByView[view] = append(messagesByView[view], message)
+	}
+
+	var cert *common.Certificate
+	for view, viewMessages := range messagesByView {
+		if len(viewMessages) < int(conf.Majority)-1 {
+			continue
+		}
+		if cert == nil || view > cert.ViewNumber {
+			cert = &common.Certificate{
+				ViewNumber:     view,
+				SequenceNumber: txn.SeqNo,
+				Messages:       viewMessages,
+			}
+		}
+	}
+	return cert, nil
+}
+
+func VerifyPreparedCertificate(conf *config.Config, cert *common.PreparedCertificate) error {
+	if cert.Txn == nil || cert.Certificate == nil {
+		return errors.New("incomplete prepared certificate")
+	}
+	return VerifyQuorumCertificate(conf, cert.Txn, cert.Certificate)
+}
+
+// VerifyQuorumCertificate checks that cert holds 2f valid signatures from distinct replicas of the cluster over
+// the same view, sequence number and txn digest. an authenticator only proves something to the replica it
+// was made for, so messages that are not signed do not count
+
+func VerifyQuorumCertificate(conf *config.Config, txn *common.TxnRequest, cert *common.Certificate) error {
+	digest := GetTxnDigest(txn)
+	senders := make(map[int32]bool)
+	for _, message := range cert.Messages {
+		if senders[message.Sender] || !IsServerInCluster(conf, message.Sender, conf.ClusterNumber) ||
+			len(message.Sign) == 0 {
+			continue
+		}
+
+		err := VerifyMessage(conf, message.Sender, message.Payload, message.Sign, message.Authenticator)
+		if err != nil {
+			continue
+		}
+
+		signedMessage := &common.SignedMessage{}
+		if err = signedPayload.Unmarshal(message.Payload, signedMessage); err != nil {
+			continue
+		}
+		if signedMessage.ViewNumber != cert.ViewNumber ||
+			signedMessage.SequenceNumber != cert.SequenceNumber ||
+			signedMessage.Digest != digest {
+			continue
+		}
+		senders[message.Sender] = true
+	}
+
+	if len(senders) < int(conf.Majority)-1 {
+		return fmt.Errorf("not enough valid signatures in certificate for sequence %d", cert.SequenceNumber)
+	}
+	return nil
+}
+
+func VerifyViewChange(conf *config.Config, req *common.PBFTRequestResponse) (*common.ViewChangeMessage, error) {
+	if !IsServerInCluster(conf, req.ServerNo, conf.ClusterNumber) {
+		return nil, errors.New("view change from server outside cluster")
+	}
+	if IsBlacklisted(conf, req.ServerNo) {
+		return nil, errors.New("view change from blacklisted server")
+	}
+
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[req.ServerNo])
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	vcMessage := &common.ViewChangeMessage{}
+	err = signedPayload.Unmarshal(req.SignedMessage, vcMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if vcMessage.StableCheckpoint > 0 {
+		_, err = VerifyCheckpointProof(conf, vcMessage.StableCheckpoint, vcMessage.CheckpointMessages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cert := range vcMessage.PreparedCertificates {
+		err = VerifyPreparedCertificate(conf, cert)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vcMessage, nil
+}
+
+func AddViewChangeMessage(conf *config.Config, req *common.PBFTRequestResponse, vcMessage *common.ViewChangeMessage) error {
+	viewChangeID := GetViewChangeID(vcMessage.ViewNumber)
+	vcMessages, err := conf.DataStore.GetPBFTMessages(viewChangeID, MessageTypeViewChange)
+	if err != nil {
+		return err
+	}
+	for _, vc := range vcMessages {
+		if vc.Sender == req.ServerNo {
+			return nil
+		}
+	}
+
+	pbftMessage := &common.PBFTMessage{
+		TxnID:       viewChangeID,
+		MessageType: MessageTypeViewChange,
+		Sender:      req.ServerNo,
+		Sign:        req.Sign,
+		Payload:     req.SignedMessage,
+		CreatedAt:   timestamppb.New(time.Now()),
+	}
+	return conf.DataStore.InsertPBFTMessage(pbftMessage)
+}
+
+func DecodeViewChangeMessages(vcMessages []*common.PBFTMessage) ([]*common.ViewChangeMessage, error) {
+	sorted := make([]*common.PBFTMessage, len(vcMessages))
+	copy(sorted, vcMessages)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Sender < sorted[j].Sender
+	})
+
+	var viewChanges []*common.ViewChangeMessage
+	for _, vc := range sorted {
+		vcMessage := &common.ViewChangeMessage{}
+		err := signedPayload.Unmarshal(vc.Payload, vcMessage)
+		if err != nil {
+			return nil, err
+		}
+		viewChanges = append(viewChanges, vcMessage)
+	}
+	return viewChanges, nil
+}
+
+func GetNewViewStableCheckpoint(viewChanges []*common.ViewChangeMessage) int32 {
+	stableCheckpoint := int32(0)
+	for _, vcMessage := range viewChanges {
+		if vcMessage.StableCheckpoint > stableCheckpoint {
+			stableCheckpoint = vcMessage.StableCheckpoint
+		}
+	}
+	return stableCheckpoint
+}
+
+// ComputeNewViewPrePrepares picks, for every sequence number between the latest stable checkpoint and the
+// highest prepared one, the certificate from the highest view, and fills the gaps with no-op requests
+
+func ComputeNewViewPrePrepares(view, stableCheckpoint int32, viewChanges []*common.ViewChangeMessage) []*common.TxnRequest {
+	certs := make(map[int32]*common.PreparedCertificate)
+	maxSeq := stableCheckpoint
+	for _, vcMessage := range viewChanges {
+		for _, cert := range vcMessage.PreparedCertificates {
+			seqNo := cert.Certificate.SequenceNumber
+			if seqNo <= stableCheckpoint {
+				continue
+			}
+			existing, exists := certs[seqNo]
+			if !exists || cert.Certificate.ViewNumber > existing.Certificate.ViewNumber {
+				certs[seqNo] = cert
+			}
+			if seqNo > maxSeq {
+				maxSeq = seqNo
+			}
+		}
+	}
+
+	var prePrepares []*common.TxnRequest
+	for seqNo := stableCheckpoint + 1; seqNo <= maxSeq; seqNo++ {
+		txn := &common.TxnRequest{
+			TxnID: GetNoOpTxnID(view, seqNo),
+			Type:  TypeNoOp,
+		}
+		if cert, exists := certs[seqNo]; exists {
+			txn = &common.TxnRequest{
+				TxnID:    cert.Txn.TxnID,
+				Sender:   cert.Txn.Sender,
+				Receiver: cert.Txn.Receiver,
+				Amount:   cert.Txn.Amount,
+				Type:     cert.Txn.Type,
+				Batch:    cert.Txn.Batch,
+				Legs:     cert.Txn.Legs,
+
+				ClientID:        cert.Txn.ClientID,
+				ClientTimestamp: cert.Txn.ClientTimestamp,
+			}
+		}
+		txn.SeqNo = seqNo
+		txn.ViewNo = view
+		txn.Digest = GetTxnDigest(txn)
+		prePrepares = append(prePrepares, txn)
+	}
+	return prePrepares
+}
+
+func VerifyNewView(conf *config.Config, req *common.PBFTRequestResponse) (*common.NewViewMessage, error) {
+	nvMessage := &common.NewViewMessage{}
+	err := signedPayload.Unmarshal(req.SignedMessage, nvMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ServerNo != GetLeaderNumberForView(conf, conf.ClusterNumber, nvMessage.ViewNumber) {
+		return nil, errors.New("new view not sent by the leader of the view")
+	}
+	if IsBlacklisted(conf, req.ServerNo) {
+		return nil, errors.New("new view from blacklisted server")
+	}
+	publicKey, err := conf.PublicKeys.GetPublicKey(config.MapServerNumberToAddress[req.ServerNo])
+	if err != nil {
+		return nil, err
+	}
+	err = VerifySignature(publicKey, req.SignedMessage, req.Sign)
+	if err != nil {
+		return nil, err
+	}
+
+	var validViewChanges []*common.PBFTMessage
+	senders := make(map[int32]bool)
+	for _, vc := range nvMessage.ViewChanges {
+		if senders[vc.Sender] {
+			continue
+		}
+		vcMessage, err := VerifyViewChange(conf, &common.PBFTRequestResponse{
+			SignedMessage: vc.Payload,
+			Sign:          vc.Sign,
+			ServerNo:      vc.Sender,
+		})
+		if err != nil || vcMessage.ViewNumber != nvMessage.ViewNumber {
+			continue
+		}
+		senders[vc.Sender] = true
+		validViewChanges = append(validViewChanges, vc)
+	}
+	if int32(len(validViewChanges)) < conf.Majority {
+		return nil, errors.New("not enough valid view changes")
+	}
+
+	viewChanges, err := DecodeViewChangeMessages(validViewChanges)
+	if err != nil {
+		return nil, err
+	}
+	stableCheckpoint := GetNewViewStableCheckpoint(viewChanges)
+	if stableCheckpoint != nvMessage.StableCheckpoint {
+		return nil, errors.New("invalid stable checkpoint in new view")
+	}
+	expected := ComputeNewViewPrePrepares(nvMessage.ViewNumber, stableCheckpoint, viewChanges)
+	if len(expected) != len(nvMessage.PrePrepares) {
+		return nil, errors.New("invalid pre-prepares in new view")
+	}
+	for i, txn := range nvMessage.PrePrepares {
+		if txn.TxnID != expected[i].TxnID || txn.SeqNo != expected[i].SeqNo || txn.Digest != expected[i].Digest {
+			return nil, errors.New("invalid pre-prepares in new view")
+		}
+	}
+	return nvMessage, nil
+}
+
+// ApplyNewView moves the txns carried over by NEW-VIEW into the new view and drops the in-flight
+// txns that did not make it, releasing their locks
+
+func ApplyNewView(conf *config.Config, nvMessage *common.NewViewMessage) error {
+	prePrepares := make(map[string]*common.TxnRequest)
+	maxSeq := nvMessage.StableCheckpoint
+	for _, txn := range nvMessage.PrePrepares {
+		prePrepares[txn.TxnID] = txn
+		for _, member := range txn.Batch {
+			prePrepares[member.TxnID] = txn
+		}
+		if txn.SeqNo > maxSeq {
+			maxSeq = txn.SeqNo
+		}
+	}
+
+	txns, err := conf.DataStore.GetTransactionsAfterSequence(nvMessage.StableCheckpoint)
+	if err != nil {
+		return err
+	}
+	for _, txn := range txns {
+		prePrepare, exists := prePrepares[txn.TxnID]
+		if exists && txn.Status != StatusFailed {
+			txn.SeqNo = prePrepare.SeqNo
+			txn.ViewNo = prePrepare.ViewNo
+			err = conf.DataStore.UpdateTransactionView(txn)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// a txn that failed locally but is carried over gets inserted again by its pre-prepare
+		if !exists && !IsTxnInFlight(txn) {
+			continue
+		}
+		if IsTxnInFlight(txn) {
+			fmt.Printf("dropping txn %s not carried into view %d\n", txn.TxnID, nvMessage.ViewNumber)
+			ReleaseLock(conf, txn)
+		}
+		_, err = conf.DataStore.DeletePBFTMessagesByByTxnID(txn.TxnID)
+		if err != nil {
+			return err
+		}
+		err = conf.DataStore.DeleteTransaction(txn.TxnID)
+		if err != nil {
+			return err
+		}
+	}
+
+	conf.PBFT.SetSequenceNumber(maxSeq)
+
+	if nvMessage.StableCheckpoint >= conf.PBFT.GetNextSequenceNumber() {
+		go func() {
+			err := StateTransfer(conf)
+			if err != nil {
+				fmt.Printf("StateTransfer error: %v\n", err)
+			}
+		}()
+	}
+	return nil
+}
+
+func IsTxnInFlight(txn *common.TxnRequest) bool {
+	switch txn.Status {
+	case StatusInit, StatusPrePrepared, StatusPrepared, StatusCommitted:
+		return true
+	}
+	return false
+}
@@ -28,9 +28,28 @@ func ProcessReadyTransactions(conf *config.Config) {
 			break
 		}
 
-		err := ExecuteTxn(conf, txnRequest, false)
+		// the same txn can end up ordered twice, e.g. when a view change re-proposes it under a new
+		// sequence number, only the first one is executed and answered
+		isReordered := false
+		dbTxn, err := conf.DataStore.GetTransactionByTxnID(txnRequest.TxnID)
+		if err == nil && IsTxnExecutedLocally(dbTxn) {
+			isReordered = true
+		}
+
+		// a unit of work that failed left nothing behind, moving past it would skip the txn on this replica
+		// only, so execution stops at it and tries again on the next signal
+		err = ExecuteTxn(conf, txnRequest, false)
 		if err != nil {
-			fmt.Println(err)
+			fmt.Printf("could not execute txn %s at sequence %d: %v\n", txnRequest.TxnID, currentSeqNum, err)
+			return
+		}
+		StopRequestTimer(conf, txnRequest.TxnID)
+
+		if txnRequest.Type == TypeCrossShardSender || txnRequest.Type == TypeCrossShardReceiver {
+			err = SaveTwoPCState(conf, txnRequest, EmptyString)
+			if err != nil {
+				fmt.Println(err)
+			}
 		}
 
 		conf.PBFT.IncrementNextSequenceNumber()
@@ -39,10 +58,47 @@ func ProcessReadyTransactions(conf *config.Config) {
 		delete(conf.PendingTransactions, currentSeqNum)
 		conf.PendingTransactionsMutex.Unlock()
 
-		if txnRequest.Type == TypeIntraShard {
+		if isReordered {
+			conf.PBFT.IncrementLastExecutedSequenceNumber()
+		} else if txnRequest.Type == TypeIntraShard {
 			conf.PBFT.IncrementLastExecutedSequenceNumber()
 			ReleaseLock(conf, txnRequest)
 			go SendReplyToClient(conf, txnRequest)
+		} else if txnRequest.Type == TypeReadOnly {
+			// the balance is read before the next txn runs, so the read sees exactly the txns ordered before it
+			// that are committed
+			balance, err := GetCommittedBalance(conf, txnRequest.Sender)
+			if err != nil {
+				fmt.Println(err)
+			}
+			conf.PBFT.IncrementLastExecutedSequenceNumber()
+			go SendReadReplyToClient(conf, txnRequest, balance)
+		} else if txnRequest.Type == TypeNoOp {
+			conf.PBFT.IncrementLastExecutedSequenceNumber()
+		} else if txnRequest.Type == TypeBatch {
+			conf.PBFT.IncrementLastExecutedSequenceNumber()
+			ReleaseLock(conf, txnRequest)
+			for _, txn := range txnRequest.Batch {
+				go SendReplyToClient(conf, txn)
+			}
+		} else if txnRequest.Type == TypeTwoPCAbortVote {
+			conf.PBFT.IncrementLastExecutedSequenceNumber()
+			aborted, err := AbortVotedTxn(conf, txnRequest)
+			if err != nil {
+				fmt.Println(err)
+			}
+			// the share for the abort is only signed now that the txn is known to be aborted here
+			if aborted {
+				go func(voteTxn *common.TxnRequest) {
+					err := SendAbortVoteShare(conf, voteTxn)
+					if err == nil {
+						err = SendTwoPCAbortVoteOnQuorum(conf, voteTxn.TxnID)
+					}
+					if err != nil {
+						fmt.Println(err)
+					}
+				}(txnRequest)
+			}
 		} else if txnRequest.Type == TypeCrossShardSender &&
 			GetLeaderNumber(conf, conf.ClusterNumber) == conf.ServerNumber {
 			err = StartTwoPC(conf, txnRequest)
@@ -57,43 +113,66 @@ func ProcessReadyTransactions(conf *config.Config) {
 				fmt.Println(err)
 			}
 		}
+
+		if currentSeqNum%conf.CheckpointInterval == 0 {
+			err = TakeCheckpoint(conf, currentSeqNum)
+			if err != nil {
+				fmt.Println(err)
+			}
+		}
 	}
 }
 
 func ExecuteTxn(conf *config.Config, txnReq *common.TxnRequest, isSync bool) error {
 	fmt.Printf("executing txn for request: %v\n", txnReq)
 
-	if txnReq.Type == TypeIntraShard || txnReq.Type == TypeCrossShardSender {
-		senderBalance, err := datastore.GetBalance(conf.DataStore, txnReq.Sender)
-		if err != nil {
-			return err
-		}
-		updatedSenderBalance := senderBalance - txnReq.Amount
-		err = datastore.UpdateBalance(conf.DataStore, datastore.User{User: txnReq.Sender, Balance: updatedSenderBalance})
-		if err != nil {
-			return err
-		}
+	err := LoadBatchMembers(conf, txnReq)
+	if err != nil {
+		return err
 	}
 
-	if txnReq.Type == TypeIntraShard || txnReq.Type == TypeCrossShardReceiver {
-		receiverBalance, err := datastore.GetBalance(conf.DataStore, txnReq.Receiver)
+	return conf.DataStore.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+		return ApplyTxn(conf, uow, txnReq, isSync)
+	})
+}
+
+// ApplyTxn moves the balances of txnReq and its batch members and updates their status as part of uow
+
+func ApplyTxn(conf *config.Config, uow datastore.UnitOfWork, txnReq *common.TxnRequest, isSync bool) error {
+	dbTxn, err := uow.GetTransactionByTxnID(txnReq.TxnID)
+	if err != nil {
+		return err
+	}
+	// a txn that got ordered a second time is not executed again
+	if IsTxnExecutedLocally(dbTxn) {
+		fmt.Printf("txn %s is already executed, skipping it\n", txnReq.TxnID)
+		return nil
+	}
+
+	for _, txn := range txnReq.Batch {
+		err := ApplyTxn(conf, uow, txn, isSync)
 		if err != nil {
 			return err
 		}
-		updatedReceiverBalance := receiverBalance + txnReq.Amount
+	}
 
-		err = datastore.UpdateBalance(conf.DataStore, datastore.User{User: txnReq.Receiver, Balance: updatedReceiverBalance})
+	if txnReq.Type == TypeIntraShard || txnReq.Type == TypeCrossShardSender {
+		err := uow.AddToBalance(txnReq.Sender, -txnReq.Amount)
 		if err != nil {
 			return err
 		}
 	}
 
-	dbTxn, err := datastore.GetTransactionByTxnID(conf.DataStore, txnReq.TxnID)
-	if err != nil {
-		return err
+	if IsClientTxnType(txnReq.Type) {
+		for _, leg := range GetLocalLegs(conf, txnReq) {
+			err := uow.AddToBalance(leg.Receiver, leg.Amount)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	if txnReq.Type == TypeIntraShard {
+	if txnReq.Type == TypeIntraShard || txnReq.Type == TypeReadOnly || IsInternalTxn(txnReq) {
 		dbTxn.Status = StatusExecuted
 	} else {
 		if isSync {
@@ -103,12 +182,7 @@ func ExecuteTxn(conf *config.Config, txnReq *common.TxnRequest, isSync bool) err
 		}
 	}
 
-	err = datastore.UpdateTransactionStatus(conf.DataStore, dbTxn)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return uow.UpdateTransactionStatus(dbTxn)
 }
 
 func RetryCron(conf *config.Config) {
@@ -119,7 +193,7 @@ func RetryCron(conf *config.Config) {
 			select {
 			case <-ticker.C:
 				if GetLeaderNumber(conf, conf.ClusterNumber) != conf.ServerNumber {
-					return
+					continue
 				}
 				RetryPendingTransactions(conf)
 			}
@@ -128,16 +202,16 @@ func RetryCron(conf *config.Config) {
 }
 
 func RetryPendingTransactions(conf *config.Config) {
-	pendingTxns, err := datastore.GetPendingTransactions(conf.DataStore)
+	pendingTxns, err := conf.DataStore.GetPendingTransactions()
 	if err != nil {
 		return
 	}
 
 	for _, txn := range pendingTxns {
-		if txn.Type == TypeCrossShardReceiver {
+		if txn.Type == TypeCrossShardReceiver || txn.BatchID != EmptyString {
 			continue
 		}
-		messagesDeleted, err := datastore.DeletePBFTMessagesByByTxnID(conf.DataStore, txn.TxnID) // not a good way of doing this, ideally have a retry count
+		messagesDeleted, err := conf.DataStore.DeletePBFTMessagesByByTxnID(txn.TxnID) // not a good way of doing this, ideally have a retry count
 		if err != nil {
 			fmt.Println(err)
 		}
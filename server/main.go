@@ -15,11 +15,17 @@ import (
 func main() {
 	conf := config.GetConfig()
 
-	config.SetupDB(conf)
 	config.InitiateConfig(conf)
+	config.SetupStore(conf)
+	if err := logic.RecoverConsensusState(conf); err != nil {
+		log.Fatal(err)
+	}
 
 	go logic.WorkerProcess(conf)
 	go logic.RetryCron(conf)
+	go logic.SyncOnStartup(conf)
+	go logic.TwoPCRecoveryCron(conf)
+	go logic.KeySyncCron(conf)
 
 	ListenAndServe(conf)
 }
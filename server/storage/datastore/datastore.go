@@ -2,6 +2,8 @@ package datastore
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
@@ -11,19 +13,29 @@ import (
 
 var ErrNoRowsUpdated = errors.New("no rows updated for user")
 
-func GetBalance(db *sql.DB, user int32) (float32, error) {
+// MySQLStore keeps the state of a replica in its own lab4_N database
+
+type MySQLStore struct {
+	db *sql.DB
+}
+
+func NewMySQLStore(db *sql.DB) *MySQLStore {
+	return &MySQLStore{db: db}
+}
+
+func (s *MySQLStore) GetBalance(user int32) (float32, error) {
 	var balance float32
 	query := `SELECT balance FROM user WHERE user = ?`
-	err := db.QueryRow(query, user).Scan(&balance)
+	err := s.db.QueryRow(query, user).Scan(&balance)
 	if err != nil {
 		return 0, err
 	}
 	return balance, nil
 }
 
-func UpdateBalance(tx *sql.DB, user User) error {
+func (s *MySQLStore) UpdateBalance(user User) error {
 	query := `UPDATE user SET balance = ? WHERE user = ?`
-	res, err := tx.Exec(query, user.Balance, user.User)
+	res, err := s.db.Exec(query, user.Balance, user.User)
 	if err != nil {
 		return err
 	}
@@ -34,45 +46,64 @@ func UpdateBalance(tx *sql.DB, user User) error {
 	return nil
 }
 
-func GetTransactionByTxnID(db *sql.DB, txnID string) (*common.TxnRequest, error) {
+const selectTransactionQuery = `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE txn_id = ?`
+
+func (s *MySQLStore) GetTransactionByTxnID(txnID string) (*common.TxnRequest, error) {
+	return ScanTransaction(s.db.QueryRow(selectTransactionQuery, txnID))
+}
+
+func ScanTransaction(row *sql.Row) (*common.TxnRequest, error) {
 	transaction := &common.TxnRequest{}
+	var legs string
 	var createdAt time.Time
 
-	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, type, status, digest, error, created_at FROM transaction WHERE txn_id = ?`
-	err := db.QueryRow(query, txnID).Scan(
+	err := row.Scan(
 		&transaction.TxnID,
 		&transaction.Sender,
 		&transaction.Receiver,
 		&transaction.Amount,
 		&transaction.SeqNo,
 		&transaction.ViewNo,
+		&transaction.BatchID,
+		&legs,
 		&transaction.Type,
 		&transaction.Status,
 		&transaction.Digest,
 		&transaction.Error,
 		&createdAt,
+		&transaction.ClientID,
+		&transaction.ClientTimestamp,
 	)
 	if err != nil {
 		return nil, err
 	}
+	transaction.Legs, err = DecodeLegs(legs)
+	if err != nil {
+		return nil, err
+	}
 	transaction.CreatedAt = timestamppb.New(createdAt)
 
 	return transaction, nil
 }
 
-func InsertTransaction(db *sql.DB, transaction *common.TxnRequest) error {
-	query := `INSERT INTO transaction (txn_id, sender, receiver, amount, seq_no, view_no, type, status, digest, error, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := db.Exec(query, transaction.TxnID, transaction.Sender, transaction.Receiver, transaction.Amount,
-		transaction.SeqNo, transaction.ViewNo, transaction.Type, transaction.Status, transaction.Digest, transaction.Error, time.Now())
+func (s *MySQLStore) InsertTransaction(transaction *common.TxnRequest) error {
+	createdAt := time.Now()
+	if transaction.CreatedAt != nil {
+		createdAt = transaction.CreatedAt.AsTime()
+	}
+	query := `INSERT INTO transaction (txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := s.db.Exec(query, transaction.TxnID, transaction.Sender, transaction.Receiver, transaction.Amount,
+		transaction.SeqNo, transaction.ViewNo, transaction.BatchID, EncodeLegs(transaction.Legs), transaction.Type, transaction.Status, transaction.Digest, transaction.Error, createdAt,
+		transaction.ClientID, transaction.ClientTimestamp)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func UpdateTransactionStatus(db *sql.DB, transaction *common.TxnRequest) error {
+func (s *MySQLStore) UpdateTransactionStatus(transaction *common.TxnRequest) error {
 	query := `UPDATE transaction SET status = ?, error = ? WHERE txn_id = ?`
-	_, err := db.Exec(query, transaction.Status, transaction.Error, transaction.TxnID)
+	_, err := s.db.Exec(query, transaction.Status, transaction.Error, transaction.TxnID)
 
 	if err != nil {
 		return err
@@ -80,12 +111,12 @@ func UpdateTransactionStatus(db *sql.DB, transaction *common.TxnRequest) error {
 	return nil
 }
 
-func GetExecutedTransactionsAfterSequence(db *sql.DB, sequenceNumber int32) ([]*common.TxnRequest, error) {
+func (s *MySQLStore) GetFinishedTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error) {
 	var transactions []*common.TxnRequest
 	var createdAt time.Time
 
-	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, type, status, digest, error, created_at FROM transaction WHERE seq_no > ? AND status = 'Executed' ORDER BY seq_no`
-	rows, err := db.Query(query, sequenceNumber)
+	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE seq_no > ? AND status IN ('Executed', 'Aborted') ORDER BY seq_no`
+	rows, err := s.db.Query(query, sequenceNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -93,8 +124,12 @@ func GetExecutedTransactionsAfterSequence(db *sql.DB, sequenceNumber int32) ([]*
 
 	for rows.Next() {
 		var txn common.TxnRequest
+		var legs string
 		if err = rows.Scan(&txn.TxnID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.SeqNo, &txn.ViewNo,
-			&txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt); err != nil {
+			&txn.BatchID, &legs, &txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt, &txn.ClientID, &txn.ClientTimestamp); err != nil {
+			return nil, err
+		}
+		if txn.Legs, err = DecodeLegs(legs); err != nil {
 			return nil, err
 		}
 		txn.CreatedAt = timestamppb.New(createdAt)
@@ -106,12 +141,12 @@ func GetExecutedTransactionsAfterSequence(db *sql.DB, sequenceNumber int32) ([]*
 	return transactions, nil
 }
 
-func GetExecutedTxns(db *sql.DB) ([]*common.TxnRequest, error) {
+func (s *MySQLStore) GetExecutedTxns() ([]*common.TxnRequest, error) {
 	var transactions []*common.TxnRequest
 	var createdAt time.Time
 
-	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, type, status, digest, error, created_at FROM transaction WHERE status = 'Executed' ORDER BY seq_no`
-	rows, err := db.Query(query)
+	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE status = 'Executed' AND type != 'Batch' ORDER BY seq_no`
+	rows, err := s.db.Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -119,8 +154,12 @@ func GetExecutedTxns(db *sql.DB) ([]*common.TxnRequest, error) {
 
 	for rows.Next() {
 		var txn common.TxnRequest
+		var legs string
 		if err = rows.Scan(&txn.TxnID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.SeqNo, &txn.ViewNo,
-			&txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt); err != nil {
+			&txn.BatchID, &legs, &txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt, &txn.ClientID, &txn.ClientTimestamp); err != nil {
+			return nil, err
+		}
+		if txn.Legs, err = DecodeLegs(legs); err != nil {
 			return nil, err
 		}
 		txn.CreatedAt = timestamppb.New(createdAt)
@@ -132,19 +171,24 @@ func GetExecutedTxns(db *sql.DB) ([]*common.TxnRequest, error) {
 	return transactions, nil
 }
 
-func InsertPBFTMessage(db *sql.DB, pbftMessage *common.PBFTMessage) error {
-	query := `INSERT INTO PBFT_Messages (txn_id, message_type, sender, sign, payload, created_at) VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := db.Exec(query, pbftMessage.TxnID, pbftMessage.MessageType, pbftMessage.Sender,
-		pbftMessage.Sign, pbftMessage.Payload, pbftMessage.CreatedAt.AsTime())
+// the sign, payload, authenticator and threshold share of a pbft message are binary, they are kept base64 encoded in their
+// text columns
+
+func (s *MySQLStore) InsertPBFTMessage(pbftMessage *common.PBFTMessage) error {
+	query := `INSERT INTO PBFT_Messages (txn_id, message_type, sender, sign, payload, authenticator, threshold_share, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := s.db.Exec(query, pbftMessage.TxnID, pbftMessage.MessageType, pbftMessage.Sender,
+		base64.StdEncoding.EncodeToString(pbftMessage.Sign), base64.StdEncoding.EncodeToString(pbftMessage.Payload),
+		base64.StdEncoding.EncodeToString(pbftMessage.Authenticator), base64.StdEncoding.EncodeToString(pbftMessage.ThresholdShare),
+		pbftMessage.CreatedAt.AsTime())
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetPBFTMessages(db *sql.DB, txnID, messagesType string) ([]*common.PBFTMessage, error) {
-	query := `SELECT txn_id, message_type, sender, sign, payload, created_at from PBFT_Messages where txn_id = ? and message_type = ?`
-	rows, err := db.Query(query, txnID, messagesType)
+func (s *MySQLStore) GetPBFTMessages(txnID, messagesType string) ([]*common.PBFTMessage, error) {
+	query := `SELECT txn_id, message_type, sender, sign, payload, authenticator, threshold_share, created_at from PBFT_Messages where txn_id = ? and message_type = ?`
+	rows, err := s.db.Query(query, txnID, messagesType)
 	if err != nil {
 		return nil, err
 	}
@@ -153,10 +197,23 @@ func GetPBFTMessages(db *sql.DB, txnID, messagesType string) ([]*common.PBFTMess
 
 	var messages []*common.PBFTMessage
 	var createdAt time.Time
+	var sign, payload, authenticator, thresholdShare string
 
 	for rows.Next() {
 		var message common.PBFTMessage
-		if err = rows.Scan(&message.TxnID, &message.MessageType, &message.Sender, &message.Sign, &message.Payload, &createdAt); err != nil {
+		if err = rows.Scan(&message.TxnID, &message.MessageType, &message.Sender, &sign, &payload, &authenticator, &thresholdShare, &createdAt); err != nil {
+			return nil, err
+		}
+		if message.Sign, err = base64.StdEncoding.DecodeString(sign); err != nil {
+			return nil, err
+		}
+		if message.Payload, err = base64.StdEncoding.DecodeString(payload); err != nil {
+			return nil, err
+		}
+		if message.Authenticator, err = base64.StdEncoding.DecodeString(authenticator); err != nil {
+			return nil, err
+		}
+		if message.ThresholdShare, err = base64.StdEncoding.DecodeString(thresholdShare); err != nil {
 			return nil, err
 		}
 		message.CreatedAt = timestamppb.New(createdAt)
@@ -168,12 +225,12 @@ func GetPBFTMessages(db *sql.DB, txnID, messagesType string) ([]*common.PBFTMess
 	return messages, nil
 }
 
-func GetPendingTransactions(db *sql.DB) ([]*common.TxnRequest, error) {
+func (s *MySQLStore) GetPendingTransactions() ([]*common.TxnRequest, error) {
 	var transactions []*common.TxnRequest
 	var createdAt time.Time
 
-	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, type, status, digest, error, created_at FROM transaction WHERE status in ('Init', 'Pre-Prepared','Prepared') ORDER BY seq_no`
-	rows, err := db.Query(query)
+	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE status in ('Init', 'Pre-Prepared','Prepared') ORDER BY seq_no`
+	rows, err := s.db.Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -181,8 +238,12 @@ func GetPendingTransactions(db *sql.DB) ([]*common.TxnRequest, error) {
 
 	for rows.Next() {
 		var txn common.TxnRequest
+		var legs string
 		if err = rows.Scan(&txn.TxnID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.SeqNo, &txn.ViewNo,
-			&txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt); err != nil {
+			&txn.BatchID, &legs, &txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt, &txn.ClientID, &txn.ClientTimestamp); err != nil {
+			return nil, err
+		}
+		if txn.Legs, err = DecodeLegs(legs); err != nil {
 			return nil, err
 		}
 		txn.CreatedAt = timestamppb.New(createdAt)
@@ -194,10 +255,10 @@ func GetPendingTransactions(db *sql.DB) ([]*common.TxnRequest, error) {
 	return transactions, nil
 }
 
-func DeletePBFTMessagesByByTxnID(db *sql.DB, txnID string) (int64, error) {
+func (s *MySQLStore) DeletePBFTMessagesByByTxnID(txnID string) (int64, error) {
 	query := `DELETE FROM PBFT_Messages WHERE txn_id = ?`
 
-	res, err := db.Exec(query, txnID)
+	res, err := s.db.Exec(query, txnID)
 	if err != nil {
 		return 0, err
 	}
@@ -209,3 +270,395 @@ func DeletePBFTMessagesByByTxnID(db *sql.DB, txnID string) (int64, error) {
 
 	return rowsAffected, nil
 }
+
+func (s *MySQLStore) GetTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error) {
+	var transactions []*common.TxnRequest
+	var createdAt time.Time
+
+	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE seq_no > ? ORDER BY seq_no`
+	rows, err := s.db.Query(query, sequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var txn common.TxnRequest
+		var legs string
+		if err = rows.Scan(&txn.TxnID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.SeqNo, &txn.ViewNo,
+			&txn.BatchID, &legs, &txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt, &txn.ClientID, &txn.ClientTimestamp); err != nil {
+			return nil, err
+		}
+		if txn.Legs, err = DecodeLegs(legs); err != nil {
+			return nil, err
+		}
+		txn.CreatedAt = timestamppb.New(createdAt)
+		transactions = append(transactions, &txn)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (s *MySQLStore) GetTransactionsByBatchID(batchID string) ([]*common.TxnRequest, error) {
+	var transactions []*common.TxnRequest
+	var createdAt time.Time
+
+	query := `SELECT txn_id, sender, receiver, amount, seq_no, view_no, batch_id, legs, type, status, digest, error, created_at, client_id, client_timestamp FROM transaction WHERE batch_id = ? ORDER BY id`
+	rows, err := s.db.Query(query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var txn common.TxnRequest
+		var legs string
+		if err = rows.Scan(&txn.TxnID, &txn.Sender, &txn.Receiver, &txn.Amount, &txn.SeqNo, &txn.ViewNo,
+			&txn.BatchID, &legs, &txn.Type, &txn.Status, &txn.Digest, &txn.Error, &createdAt, &txn.ClientID, &txn.ClientTimestamp); err != nil {
+			return nil, err
+		}
+		if txn.Legs, err = DecodeLegs(legs); err != nil {
+			return nil, err
+		}
+		txn.CreatedAt = timestamppb.New(createdAt)
+		transactions = append(transactions, &txn)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (s *MySQLStore) UpdateTransactionView(transaction *common.TxnRequest) error {
+	query := `UPDATE transaction SET seq_no = ?, view_no = ? WHERE txn_id = ?`
+	_, err := s.db.Exec(query, transaction.SeqNo, transaction.ViewNo, transaction.TxnID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MySQLStore) DeleteTransaction(txnID string) error {
+	query := `DELETE FROM transaction WHERE txn_id = ?`
+	_, err := s.db.Exec(query, txnID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MySQLStore) GetBalances() ([]User, error) {
+	var users []User
+
+	query := `SELECT user, balance FROM user ORDER BY user`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user User
+		if err = rows.Scan(&user.User, &user.Balance); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ReplaceBalances overwrites the user table with users, used when installing a checkpoint from other replicas
+
+func (s *MySQLStore) ReplaceBalances(users []User) error {
+	_, err := s.db.Exec(`DELETE FROM user`)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user (user, balance) VALUES (?, ?)`
+	for _, user := range users {
+		_, err = s.db.Exec(query, user.User, user.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MySQLStore) InsertCheckpoint(checkpoint Checkpoint) error {
+	query := `INSERT INTO checkpoint (seq_no, digest, state, created_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE digest = VALUES(digest), state = VALUES(state)`
+	_, err := s.db.Exec(query, checkpoint.SeqNo, checkpoint.Digest, checkpoint.State, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertTwoPCState records the 2PC role of a txn, a decided outcome is never overwritten by an empty one
+
+func (s *MySQLStore) UpsertTwoPCState(state TwoPCState) error {
+	query := `INSERT INTO two_pc_state (txn_id, role, outcome, updated_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE outcome = IF(VALUES(outcome) = '', outcome, VALUES(outcome))`
+	_, err := s.db.Exec(query, state.TxnID, state.Role, state.Outcome, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MySQLStore) GetTwoPCState(txnID string) (*TwoPCState, error) {
+	state := &TwoPCState{}
+	query := `SELECT txn_id, role, outcome FROM two_pc_state WHERE txn_id = ?`
+	err := s.db.QueryRow(query, txnID).Scan(&state.TxnID, &state.Role, &state.Outcome)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// GetUnfinishedTwoPCStates returns the 2PC states last touched before the given time whose txn has not
+// been committed or aborted locally yet
+
+func (s *MySQLStore) GetUnfinishedTwoPCStates(before time.Time) ([]TwoPCState, error) {
+	query := `SELECT s.txn_id, s.role, s.outcome FROM two_pc_state s JOIN transaction t ON t.txn_id = s.txn_id
+		WHERE t.status NOT IN ('Executed', 'Aborted') AND s.updated_at < ?`
+	rows, err := s.db.Query(query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []TwoPCState
+	for rows.Next() {
+		var state TwoPCState
+		if err = rows.Scan(&state.TxnID, &state.Role, &state.Outcome); err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// UpsertClientReply caches the reply to a client request, replicas send it again when the request is retried
+
+func (s *MySQLStore) UpsertClientReply(reply ClientReply) error {
+	query := `INSERT INTO client_reply (client_id, client_timestamp, txn_id, reply, created_at) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE txn_id = VALUES(txn_id), reply = VALUES(reply)`
+	_, err := s.db.Exec(query, reply.ClientID, reply.ClientTimestamp, reply.TxnID, reply.Reply, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MySQLStore) GetClientReply(clientID string, clientTimestamp int64) (*ClientReply, error) {
+	reply := &ClientReply{}
+	query := `SELECT client_id, client_timestamp, txn_id, reply FROM client_reply WHERE client_id = ? AND client_timestamp = ?`
+	err := s.db.QueryRow(query, clientID, clientTimestamp).Scan(&reply.ClientID, &reply.ClientTimestamp, &reply.TxnID, &reply.Reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *MySQLStore) GetCheckpoint(seqNo int32) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{}
+	query := `SELECT seq_no, digest, state FROM checkpoint WHERE seq_no = ?`
+	err := s.db.QueryRow(query, seqNo).Scan(&checkpoint.SeqNo, &checkpoint.Digest, &checkpoint.State)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (s *MySQLStore) DeleteCheckpointsBeforeSequence(seqNo int32) error {
+	query := `DELETE FROM checkpoint WHERE seq_no < ?`
+	_, err := s.db.Exec(query, seqNo)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeletePBFTMessagesBeforeSequence removes the messages of txns that are finished and ordered at or below seqNo
+
+func (s *MySQLStore) DeletePBFTMessagesBeforeSequence(seqNo int32) (int64, error) {
+	query := `DELETE m FROM PBFT_Messages m JOIN transaction t ON m.txn_id = t.txn_id WHERE t.seq_no <= ? AND t.status IN ('Executed', 'Aborted', 'Failed')`
+
+	res, err := s.db.Exec(query, seqNo)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteTransactionsBeforeSequence drops the finished txns up to seqNo, keeping their outcome in txn_outcome
+
+func (s *MySQLStore) DeleteTransactionsBeforeSequence(seqNo int32) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO txn_outcome (txn_id, seq_no, view_no, status, error)
+		SELECT txn_id, seq_no, view_no, status, error FROM transaction WHERE seq_no <= ? AND status IN ('Executed', 'Aborted', 'Failed')
+		ON DUPLICATE KEY UPDATE seq_no = VALUES(seq_no), view_no = VALUES(view_no), status = VALUES(status), error = VALUES(error)`
+	_, err = tx.Exec(query, seqNo)
+	if err != nil {
+		return 0, err
+	}
+
+	query = `DELETE FROM transaction WHERE seq_no <= ? AND status IN ('Executed', 'Aborted', 'Failed')`
+	res, err := tx.Exec(query, seqNo)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
+}
+
+func (s *MySQLStore) GetTxnOutcome(txnID string) (*TxnOutcome, error) {
+	outcome := &TxnOutcome{}
+	query := `SELECT txn_id, seq_no, view_no, status, error FROM txn_outcome WHERE txn_id = ?`
+	err := s.db.QueryRow(query, txnID).Scan(&outcome.TxnID, &outcome.SeqNo, &outcome.ViewNo, &outcome.Status, &outcome.Error)
+	if err != nil {
+		return nil, err
+	}
+	return outcome, nil
+}
+
+func (s *MySQLStore) GetPBFTMessageIDs(messageType string) ([]string, error) {
+	query := `SELECT DISTINCT txn_id FROM PBFT_Messages WHERE message_type = ?`
+	rows, err := s.db.Query(query, messageType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateTables sets up the schema on a fresh database so a wiped replica can be rebuilt through state transfer
+
+func CreateTables(db *sql.DB) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS user (
+			id int unsigned NOT NULL AUTO_INCREMENT,
+			user int DEFAULT NULL,
+			balance double(10,2) DEFAULT '10.00',
+			PRIMARY KEY (id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS transaction (
+			id int NOT NULL AUTO_INCREMENT,
+			txn_id varchar(255) NOT NULL,
+			sender int NOT NULL,
+			receiver int NOT NULL,
+			amount double(10,2) DEFAULT NULL,
+			seq_no int DEFAULT NULL,
+			view_no int DEFAULT NULL,
+			batch_id varchar(255) NOT NULL DEFAULT '',
+			legs varchar(1024) NOT NULL DEFAULT '',
+			type varchar(255) NOT NULL,
+			status varchar(255) DEFAULT NULL,
+			digest varchar(255) DEFAULT NULL,
+			error varchar(255) DEFAULT '',
+			created_at timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+			client_id varchar(255) NOT NULL DEFAULT '',
+			client_timestamp bigint NOT NULL DEFAULT 0,
+			PRIMARY KEY (id),
+			UNIQUE KEY unique_txnid (txn_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS pbft_messages (
+			txn_id varchar(255) NOT NULL,
+			message_type varchar(64) NOT NULL,
+			sender int DEFAULT NULL,
+			sign TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			authenticator TEXT NOT NULL,
+			threshold_share TEXT NOT NULL,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS two_pc_state (
+			txn_id varchar(255) NOT NULL,
+			role varchar(64) NOT NULL,
+			outcome varchar(64) NOT NULL DEFAULT '',
+			updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			PRIMARY KEY (txn_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS client_reply (
+			client_id varchar(255) NOT NULL,
+			client_timestamp bigint NOT NULL,
+			txn_id varchar(255) NOT NULL,
+			reply TEXT NOT NULL,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (client_id, client_timestamp)
+		)`,
+		`CREATE TABLE IF NOT EXISTS txn_outcome (
+			txn_id varchar(255) NOT NULL,
+			seq_no int NOT NULL,
+			view_no int NOT NULL,
+			status varchar(255) NOT NULL,
+			error varchar(255) NOT NULL DEFAULT '',
+			PRIMARY KEY (txn_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS checkpoint (
+			seq_no int NOT NULL,
+			digest varchar(255) NOT NULL,
+			state mediumtext NOT NULL,
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (seq_no)
+		)`,
+	}
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legs of a multi-shard txn are kept as json in a single column, plain txns store an empty string
+
+func EncodeLegs(legs []*common.TxnLeg) string {
+	if len(legs) == 0 {
+		return ""
+	}
+	legsBytes, _ := json.Marshal(legs)
+	return string(legsBytes)
+}
+
+func DecodeLegs(legs string) ([]*common.TxnLeg, error) {
+	if legs == "" {
+		return nil, nil
+	}
+	var txnLegs []*common.TxnLeg
+	err := json.Unmarshal([]byte(legs), &txnLegs)
+	if err != nil {
+		return nil, err
+	}
+	return txnLegs, nil
+}
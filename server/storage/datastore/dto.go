@@ -5,8 +5,31 @@ type User struct {
 	Balance float32
 }
 
-type Txn struct {
-	Sender   int32
-	Receiver int32
-	Amount   float32
+type TwoPCState struct {
+	TxnID   string
+	Role    string
+	Outcome string
+}
+
+// TxnOutcome is what is left of a finished txn once the log below a stable checkpoint is truncated, enough
+// to still answer a status query for it
+type TxnOutcome struct {
+	TxnID  string
+	SeqNo  int32
+	ViewNo int32
+	Status string
+	Error  string
+}
+
+type ClientReply struct {
+	ClientID        string
+	ClientTimestamp int64
+	TxnID           string
+	Reply           string
+}
+
+type Checkpoint struct {
+	SeqNo  int32
+	Digest string
+	State  string
 }
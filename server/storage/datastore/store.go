@@ -0,0 +1,62 @@
+package datastore
+
+import (
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+)
+
+// Store holds everything a replica persists: balances, the transaction log, pbft messages, checkpoints, 2PC
+// state and the replies cached for clients. MySQLStore keeps it in the database of the replica and
+// memstore.MemStore in memory, so a whole cluster can run in one process. lookups of a single row return
+// sql.ErrNoRows when it does not exist
+
+type Store interface {
+	GetBalance(user int32) (float32, error)
+	UpdateBalance(user User) error
+	GetBalances() ([]User, error)
+	ReplaceBalances(users []User) error
+
+	GetTransactionByTxnID(txnID string) (*common.TxnRequest, error)
+	InsertTransaction(transaction *common.TxnRequest) error
+	UpdateTransactionStatus(transaction *common.TxnRequest) error
+	UpdateTransactionView(transaction *common.TxnRequest) error
+	DeleteTransaction(txnID string) error
+	GetFinishedTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error)
+	GetExecutedTxns() ([]*common.TxnRequest, error)
+	GetPendingTransactions() ([]*common.TxnRequest, error)
+	GetTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error)
+	GetTransactionsByBatchID(batchID string) ([]*common.TxnRequest, error)
+	DeleteTransactionsBeforeSequence(seqNo int32) (int64, error)
+	GetTxnOutcome(txnID string) (*TxnOutcome, error)
+
+	InsertPBFTMessage(pbftMessage *common.PBFTMessage) error
+	GetPBFTMessages(txnID, messagesType string) ([]*common.PBFTMessage, error)
+	GetPBFTMessageIDs(messageType string) ([]string, error)
+	DeletePBFTMessagesByByTxnID(txnID string) (int64, error)
+	DeletePBFTMessagesBeforeSequence(seqNo int32) (int64, error)
+
+	InsertCheckpoint(checkpoint Checkpoint) error
+	GetCheckpoint(seqNo int32) (*Checkpoint, error)
+	DeleteCheckpointsBeforeSequence(seqNo int32) error
+
+	UpsertTwoPCState(state TwoPCState) error
+	GetTwoPCState(txnID string) (*TwoPCState, error)
+	GetUnfinishedTwoPCStates(before time.Time) ([]TwoPCState, error)
+
+	UpsertClientReply(reply ClientReply) error
+	GetClientReply(clientID string, clientTimestamp int64) (*ClientReply, error)
+
+	RunInUnitOfWork(fn func(uow UnitOfWork) error) error
+}
+
+// UnitOfWork groups the balance and status changes made for one txn, they are applied all together or not
+// at all so a crash never leaves balances out of step with the transaction log. a txn read through it cannot
+// change before the unit is applied, so what the unit decides on the read still holds when it is written
+
+type UnitOfWork interface {
+	GetTransactionByTxnID(txnID string) (*common.TxnRequest, error)
+	AddToBalance(user int32, amount float32) error
+	UpdateTransactionStatus(transaction *common.TxnRequest) error
+	UpsertTwoPCState(state TwoPCState) error
+}
@@ -0,0 +1,79 @@
+package datastore
+
+import (
+	"database/sql"
+	"time"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+)
+
+// MySQLUnitOfWork runs a unit of work in a single sql transaction
+
+type MySQLUnitOfWork struct {
+	tx *sql.Tx
+}
+
+// RunInUnitOfWork commits the changes made by fn, or rolls all of them back if fn fails
+
+func (s *MySQLStore) RunInUnitOfWork(fn func(uow UnitOfWork) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	uow := &MySQLUnitOfWork{tx: tx}
+
+	err = fn(uow)
+	if err != nil {
+		_ = uow.Rollback()
+		return err
+	}
+	return uow.Commit()
+}
+
+// GetTransactionByTxnID reads the txn locking its row until the unit of work ends
+
+func (uow *MySQLUnitOfWork) GetTransactionByTxnID(txnID string) (*common.TxnRequest, error) {
+	return ScanTransaction(uow.tx.QueryRow(selectTransactionQuery+` FOR UPDATE`, txnID))
+}
+
+// AddToBalance changes the balance of user by amount relative to its stored value
+
+func (uow *MySQLUnitOfWork) AddToBalance(user int32, amount float32) error {
+	query := `UPDATE user SET balance = balance + ? WHERE user = ?`
+	res, err := uow.tx.Exec(query, amount, user)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (uow *MySQLUnitOfWork) UpdateTransactionStatus(transaction *common.TxnRequest) error {
+	query := `UPDATE transaction SET status = ?, error = ? WHERE txn_id = ?`
+	_, err := uow.tx.Exec(query, transaction.Status, transaction.Error, transaction.TxnID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (uow *MySQLUnitOfWork) UpsertTwoPCState(state TwoPCState) error {
+	query := `INSERT INTO two_pc_state (txn_id, role, outcome, updated_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE outcome = IF(VALUES(outcome) = '', outcome, VALUES(outcome))`
+	_, err := uow.tx.Exec(query, state.TxnID, state.Role, state.Outcome, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (uow *MySQLUnitOfWork) Commit() error {
+	return uow.tx.Commit()
+}
+
+func (uow *MySQLUnitOfWork) Rollback() error {
+	return uow.tx.Rollback()
+}
@@ -0,0 +1,214 @@
+package filestore
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/memstore"
+)
+
+// FileStore persists the state of a replica in its own directory without any external database. the state
+// is served from memory, every change is first appended to a write-ahead log and every snapshotInterval
+// records the whole state is written to a snapshot and the log starts over. on startup the snapshot is
+// loaded and the log replayed on top of it
+
+const (
+	walFile      = "wal.log"
+	snapshotFile = "snapshot.json"
+)
+
+type FileStore struct {
+	*memstore.MemStore
+
+	// mu keeps the order changes are logged in the same as the order they are applied in
+	mu sync.Mutex
+	// unitMu runs one unit of work at a time, so nothing a unit read is changed by another before it is logged
+	unitMu sync.Mutex
+
+	dir              string
+	wal              *os.File
+	lsn              int64
+	snapshotLSN      int64
+	snapshotInterval int64
+}
+
+// NewFileStore opens the store kept in dir, creating it if it does not exist, and recovers its state.
+// users are the balances a fresh store starts out with
+
+func NewFileStore(dir string, snapshotInterval int64, users []datastore.User) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{
+		MemStore:         memstore.NewMemStore(),
+		dir:              dir,
+		snapshotInterval: max(snapshotInterval, 1),
+	}
+
+	isNew, err := s.recover()
+	if err != nil {
+		return nil, err
+	}
+
+	s.wal, err = os.OpenFile(filepath.Join(dir, walFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNew {
+		err = s.ReplaceBalances(users)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *FileStore) UpdateBalance(user datastore.User) error {
+	return s.apply(&walRecord{Op: opUpdateBalance, User: &user})
+}
+
+func (s *FileStore) ReplaceBalances(users []datastore.User) error {
+	return s.apply(&walRecord{Op: opReplaceBalances, Users: users})
+}
+
+func (s *FileStore) InsertTransaction(transaction *common.TxnRequest) error {
+	// the creation time is fixed before logging so replaying the insert gives back the same txn
+	if transaction.CreatedAt == nil {
+		transaction = proto.Clone(transaction).(*common.TxnRequest)
+		transaction.CreatedAt = timestamppb.New(time.Now().Truncate(time.Microsecond))
+	}
+	return s.apply(&walRecord{Op: opInsertTransaction, Txn: transaction})
+}
+
+func (s *FileStore) UpdateTransactionStatus(transaction *common.TxnRequest) error {
+	return s.apply(&walRecord{Op: opUpdateTransactionStatus, Txn: statusOnly(transaction)})
+}
+
+func (s *FileStore) UpdateTransactionView(transaction *common.TxnRequest) error {
+	return s.apply(&walRecord{Op: opUpdateTransactionView, Txn: &common.TxnRequest{
+		TxnID:  transaction.TxnID,
+		SeqNo:  transaction.SeqNo,
+		ViewNo: transaction.ViewNo,
+	}})
+}
+
+func (s *FileStore) DeleteTransaction(txnID string) error {
+	return s.apply(&walRecord{Op: opDeleteTransaction, TxnID: txnID})
+}
+
+func (s *FileStore) DeleteTransactionsBeforeSequence(seqNo int32) (int64, error) {
+	var deleted int64
+	err := s.applyWithResult(&walRecord{Op: opDeleteTransactionsBeforeSequence, SeqNo: seqNo}, &deleted)
+	return deleted, err
+}
+
+func (s *FileStore) InsertPBFTMessage(pbftMessage *common.PBFTMessage) error {
+	return s.apply(&walRecord{Op: opInsertPBFTMessage, Message: pbftMessage})
+}
+
+func (s *FileStore) DeletePBFTMessagesByByTxnID(txnID string) (int64, error) {
+	var deleted int64
+	err := s.applyWithResult(&walRecord{Op: opDeletePBFTMessagesByTxnID, TxnID: txnID}, &deleted)
+	return deleted, err
+}
+
+func (s *FileStore) DeletePBFTMessagesBeforeSequence(seqNo int32) (int64, error) {
+	var deleted int64
+	err := s.applyWithResult(&walRecord{Op: opDeletePBFTMessagesBeforeSequence, SeqNo: seqNo}, &deleted)
+	return deleted, err
+}
+
+func (s *FileStore) InsertCheckpoint(checkpoint datastore.Checkpoint) error {
+	return s.apply(&walRecord{Op: opInsertCheckpoint, Checkpoint: &checkpoint})
+}
+
+func (s *FileStore) DeleteCheckpointsBeforeSequence(seqNo int32) error {
+	return s.apply(&walRecord{Op: opDeleteCheckpointsBeforeSequence, SeqNo: seqNo})
+}
+
+func (s *FileStore) UpsertTwoPCState(state datastore.TwoPCState) error {
+	return s.apply(&walRecord{Op: opUpsertTwoPCState, TwoPCState: &state})
+}
+
+func (s *FileStore) UpsertClientReply(reply datastore.ClientReply) error {
+	return s.apply(&walRecord{Op: opUpsertClientReply, Reply: &reply})
+}
+
+// RunInUnitOfWork logs the changes made by fn as a single record, so they are replayed all together or not
+// at all
+
+func (s *FileStore) RunInUnitOfWork(fn func(uow datastore.UnitOfWork) error) error {
+	s.unitMu.Lock()
+	defer s.unitMu.Unlock()
+
+	uow := &UnitOfWork{store: s}
+	err := fn(uow)
+	if err != nil {
+		return err
+	}
+	return s.apply(&walRecord{Op: opUnitOfWork, Ops: uow.ops})
+}
+
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.wal.Close()
+}
+
+// UnitOfWork records the changes of a unit of work until it is logged
+
+type UnitOfWork struct {
+	store *FileStore
+	ops   []*walRecord
+}
+
+func (uow *UnitOfWork) GetTransactionByTxnID(txnID string) (*common.TxnRequest, error) {
+	txn, err := uow.store.GetTransactionByTxnID(txnID)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range uow.ops {
+		if op.Op == opUpdateTransactionStatus && op.Txn.TxnID == txnID {
+			txn.Status = op.Txn.Status
+			txn.Error = op.Txn.Error
+		}
+	}
+	return txn, nil
+}
+
+func (uow *UnitOfWork) AddToBalance(user int32, amount float32) error {
+	if _, err := uow.store.GetBalance(user); err != nil {
+		return datastore.ErrNoRowsUpdated
+	}
+	uow.ops = append(uow.ops, &walRecord{Op: opAddToBalance, User: &datastore.User{User: user, Balance: amount}})
+	return nil
+}
+
+func (uow *UnitOfWork) UpdateTransactionStatus(transaction *common.TxnRequest) error {
+	uow.ops = append(uow.ops, &walRecord{Op: opUpdateTransactionStatus, Txn: statusOnly(transaction)})
+	return nil
+}
+
+func (uow *UnitOfWork) UpsertTwoPCState(state datastore.TwoPCState) error {
+	uow.ops = append(uow.ops, &walRecord{Op: opUpsertTwoPCState, TwoPCState: &state})
+	return nil
+}
+
+func statusOnly(transaction *common.TxnRequest) *common.TxnRequest {
+	return &common.TxnRequest{
+		TxnID:  transaction.TxnID,
+		Status: transaction.Status,
+		Error:  transaction.Error,
+	}
+}
@@ -0,0 +1,151 @@
+package filestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+)
+
+var initialUsers = []datastore.User{{User: 1, Balance: 10}, {User: 2, Balance: 10}}
+
+func openStore(t *testing.T, dir string, snapshotInterval int64) *FileStore {
+	t.Helper()
+	store, err := NewFileStore(dir, snapshotInterval, initialUsers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// transfer logs a txn and moves amount from user 1 to user 2 the way a replica executes it
+func transfer(t *testing.T, store *FileStore, txnID string, seqNo int32, amount float32) {
+	t.Helper()
+	txn := &common.TxnRequest{TxnID: txnID, Sender: 1, Receiver: 2, Amount: amount, SeqNo: seqNo, Status: "Committed"}
+	err := store.InsertTransaction(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+		if err := uow.AddToBalance(1, -amount); err != nil {
+			return err
+		}
+		if err := uow.AddToBalance(2, amount); err != nil {
+			return err
+		}
+		txn.Status = "Executed"
+		return uow.UpdateTransactionStatus(txn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkBalances(t *testing.T, store *FileStore, want map[int32]float32) {
+	t.Helper()
+	for user, balance := range want {
+		got, err := store.GetBalance(user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != balance {
+			t.Fatalf("balance of user %d is %v, want %v", user, got, balance)
+		}
+	}
+}
+
+func TestReplayWAL(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir, 1000)
+	transfer(t, store, "txn-1", 1, 3)
+	transfer(t, store, "txn-2", 2, 2)
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store = openStore(t, dir, 1000)
+	defer store.Close()
+	checkBalances(t, store, map[int32]float32{1: 5, 2: 15})
+
+	txn, err := store.GetTransactionByTxnID("txn-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txn.Status != "Executed" || txn.SeqNo != 2 || txn.Amount != 2 {
+		t.Fatalf("replayed txn is %v", txn)
+	}
+}
+
+func TestReplayWALOnTopOfSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir, 3)
+	for i, txnID := range []string{"txn-1", "txn-2", "txn-3", "txn-4"} {
+		transfer(t, store, txnID, int32(i+1), 1)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile)); err != nil {
+		t.Fatalf("no snapshot was written: %v", err)
+	}
+
+	store = openStore(t, dir, 3)
+	defer store.Close()
+	checkBalances(t, store, map[int32]float32{1: 6, 2: 14})
+
+	txns, err := store.GetTransactionsAfterSequence(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txns) != 4 {
+		t.Fatalf("recovered %d txns, want 4", len(txns))
+	}
+}
+
+func TestReplayDropsTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir, 1000)
+	transfer(t, store, "txn-1", 1, 3)
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a crash in the middle of appending a record leaves half a line at the end of the log
+	wal, err := os.OpenFile(filepath.Join(dir, walFile), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = wal.WriteString(`{"LSN":99,"Op":"UpdateBal`); err != nil {
+		t.Fatal(err)
+	}
+	wal.Close()
+
+	store = openStore(t, dir, 1000)
+	checkBalances(t, store, map[int32]float32{1: 7, 2: 13})
+
+	// the log keeps working after the torn record was cut off
+	transfer(t, store, "txn-2", 2, 1)
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	store = openStore(t, dir, 1000)
+	defer store.Close()
+	checkBalances(t, store, map[int32]float32{1: 6, 2: 14})
+}
+
+func TestRecoveredStoreKeepsItsBalances(t *testing.T) {
+	dir := t.TempDir()
+	store := openStore(t, dir, 1000)
+	if err := store.UpdateBalance(datastore.User{User: 1, Balance: 42}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store = openStore(t, dir, 1000)
+	defer store.Close()
+	checkBalances(t, store, map[int32]float32{1: 42, 2: 10})
+}
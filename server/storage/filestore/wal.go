@@ -0,0 +1,264 @@
+package filestore
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/memstore"
+)
+
+const (
+	opUpdateBalance                    = "UpdateBalance"
+	opReplaceBalances                  = "ReplaceBalances"
+	opAddToBalance                     = "AddToBalance"
+	opInsertTransaction                = "InsertTransaction"
+	opUpdateTransactionStatus          = "UpdateTransactionStatus"
+	opUpdateTransactionView            = "UpdateTransactionView"
+	opDeleteTransaction                = "DeleteTransaction"
+	opDeleteTransactionsBeforeSequence = "DeleteTransactionsBeforeSequence"
+	opInsertPBFTMessage                = "InsertPBFTMessage"
+	opDeletePBFTMessagesByTxnID        = "DeletePBFTMessagesByTxnID"
+	opDeletePBFTMessagesBeforeSequence = "DeletePBFTMessagesBeforeSequence"
+	opInsertCheckpoint                 = "InsertCheckpoint"
+	opDeleteCheckpointsBeforeSequence  = "DeleteCheckpointsBeforeSequence"
+	opUpsertTwoPCState                 = "UpsertTwoPCState"
+	opUpsertClientReply                = "UpsertClientReply"
+	opUnitOfWork                       = "UnitOfWork"
+)
+
+// walRecord is one line of the log, LSN numbers the records so the ones already covered by the snapshot are
+// skipped when the log is replayed
+
+type walRecord struct {
+	LSN        int64 `json:",omitempty"`
+	Op         string
+	TxnID      string                 `json:",omitempty"`
+	SeqNo      int32                  `json:",omitempty"`
+	User       *datastore.User        `json:",omitempty"`
+	Users      []datastore.User       `json:",omitempty"`
+	Txn        *common.TxnRequest     `json:",omitempty"`
+	Message    *common.PBFTMessage    `json:",omitempty"`
+	Checkpoint *datastore.Checkpoint  `json:",omitempty"`
+	TwoPCState *datastore.TwoPCState  `json:",omitempty"`
+	Reply      *datastore.ClientReply `json:",omitempty"`
+	Ops        []*walRecord           `json:",omitempty"`
+}
+
+type snapshot struct {
+	LSN   int64
+	State *memstore.State
+}
+
+func (s *FileStore) apply(record *walRecord) error {
+	return s.applyWithResult(record, nil)
+}
+
+// applyWithResult appends record to the log and then applies it to the state in memory. a change that fails
+// to apply fails the same way when it is replayed, so it is not taken out of the log again
+
+func (s *FileStore) applyWithResult(record *walRecord, result *int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.LSN = s.lsn + 1
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.wal.Write(append(recordBytes, '\n'))
+	if err != nil {
+		return err
+	}
+	err = s.wal.Sync()
+	if err != nil {
+		return err
+	}
+	s.lsn = record.LSN
+
+	affected, err := s.applyRecord(record)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		*result = affected
+	}
+
+	if s.lsn-s.snapshotLSN >= s.snapshotInterval {
+		err = s.writeSnapshot()
+		if err != nil {
+			fmt.Printf("error writing snapshot: %v\n", err)
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) applyRecord(record *walRecord) (int64, error) {
+	switch record.Op {
+	case opUpdateBalance:
+		return 0, s.MemStore.UpdateBalance(*record.User)
+	case opReplaceBalances:
+		return 0, s.MemStore.ReplaceBalances(record.Users)
+	case opInsertTransaction:
+		return 0, s.MemStore.InsertTransaction(record.Txn)
+	case opUpdateTransactionStatus:
+		return 0, s.MemStore.UpdateTransactionStatus(record.Txn)
+	case opUpdateTransactionView:
+		return 0, s.MemStore.UpdateTransactionView(record.Txn)
+	case opDeleteTransaction:
+		return 0, s.MemStore.DeleteTransaction(record.TxnID)
+	case opDeleteTransactionsBeforeSequence:
+		return s.MemStore.DeleteTransactionsBeforeSequence(record.SeqNo)
+	case opInsertPBFTMessage:
+		return 0, s.MemStore.InsertPBFTMessage(record.Message)
+	case opDeletePBFTMessagesByTxnID:
+		return s.MemStore.DeletePBFTMessagesByByTxnID(record.TxnID)
+	case opDeletePBFTMessagesBeforeSequence:
+		return s.MemStore.DeletePBFTMessagesBeforeSequence(record.SeqNo)
+	case opInsertCheckpoint:
+		return 0, s.MemStore.InsertCheckpoint(*record.Checkpoint)
+	case opDeleteCheckpointsBeforeSequence:
+		return 0, s.MemStore.DeleteCheckpointsBeforeSequence(record.SeqNo)
+	case opUpsertTwoPCState:
+		return 0, s.MemStore.UpsertTwoPCState(*record.TwoPCState)
+	case opUpsertClientReply:
+		return 0, s.MemStore.UpsertClientReply(*record.Reply)
+	case opUnitOfWork:
+		return 0, s.MemStore.RunInUnitOfWork(func(uow datastore.UnitOfWork) error {
+			for _, op := range record.Ops {
+				err := applyUnitOfWorkOp(uow, op)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return 0, errors.New("unknown wal op " + record.Op)
+}
+
+func applyUnitOfWorkOp(uow datastore.UnitOfWork, op *walRecord) error {
+	switch op.Op {
+	case opAddToBalance:
+		return uow.AddToBalance(op.User.User, op.User.Balance)
+	case opUpdateTransactionStatus:
+		return uow.UpdateTransactionStatus(op.Txn)
+	case opUpsertTwoPCState:
+		return uow.UpsertTwoPCState(*op.TwoPCState)
+	}
+	return errors.New("unknown unit of work op " + op.Op)
+}
+
+// writeSnapshot replaces the snapshot with the current state and empties the log. the snapshot is renamed
+// into place so a crash leaves either the old or the new one, and records it already covers are skipped
+// on replay if the crash happens before the log is emptied
+
+func (s *FileStore) writeSnapshot() error {
+	snapshotBytes, err := json.Marshal(&snapshot{LSN: s.lsn, State: s.MemStore.Snapshot()})
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, snapshotFile)
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(snapshotBytes)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return err
+	}
+	s.snapshotLSN = s.lsn
+
+	return s.wal.Truncate(0)
+}
+
+// recover loads the snapshot and replays the log on top of it, a record cut short by a crash while it was
+// being appended is dropped. it reports whether the store is new
+
+func (s *FileStore) recover() (bool, error) {
+	snapshotBytes, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	hasSnapshot := err == nil
+	if hasSnapshot {
+		snap := &snapshot{}
+		err = json.Unmarshal(snapshotBytes, snap)
+		if err != nil {
+			return false, err
+		}
+		err = s.MemStore.Restore(snap.State)
+		if err != nil {
+			return false, err
+		}
+		s.lsn = snap.LSN
+		s.snapshotLSN = snap.LSN
+	}
+
+	path := filepath.Join(s.dir, walFile)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return !hasSnapshot, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	var validBytes int64
+	var replayed int
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+
+		record := &walRecord{}
+		if json.Unmarshal(line, record) != nil {
+			break
+		}
+		validBytes += int64(len(line))
+
+		if record.LSN <= s.lsn {
+			continue
+		}
+		_, err = s.applyRecord(record)
+		if err != nil {
+			fmt.Printf("error replaying wal record %d: %v\n", record.LSN, err)
+		}
+		s.lsn = record.LSN
+		replayed++
+	}
+
+	err = os.Truncate(path, validBytes)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("recovered store at %s, replayed %d wal records after lsn %d\n", s.dir, replayed, s.snapshotLSN)
+	return !hasSnapshot && validBytes == 0, nil
+}
@@ -0,0 +1,438 @@
+package memstore
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+)
+
+// MemStore keeps the state of a replica in memory, it behaves like the mysql store but nothing survives a
+// restart. every txn and message is copied on the way in and out so callers never share them with the store
+
+type MemStore struct {
+	mu sync.Mutex
+	// unitMu runs one unit of work at a time, so nothing a unit read is changed by another before it applies
+	unitMu sync.Mutex
+
+	balances     map[int32]float32
+	transactions map[string]*transaction
+	outcomes     map[string]datastore.TxnOutcome
+	nextID       int64
+	messages     []*common.PBFTMessage
+	checkpoints  map[int32]datastore.Checkpoint
+	twoPCStates  map[string]*twoPCState
+	replies      map[clientRequest]datastore.ClientReply
+}
+
+type clientRequest struct {
+	clientID        string
+	clientTimestamp int64
+}
+
+type transaction struct {
+	id  int64
+	txn *common.TxnRequest
+}
+
+type twoPCState struct {
+	state     datastore.TwoPCState
+	updatedAt time.Time
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{
+		balances:     make(map[int32]float32),
+		transactions: make(map[string]*transaction),
+		outcomes:     make(map[string]datastore.TxnOutcome),
+		checkpoints:  make(map[int32]datastore.Checkpoint),
+		twoPCStates:  make(map[string]*twoPCState),
+		replies:      make(map[clientRequest]datastore.ClientReply),
+	}
+}
+
+func (s *MemStore) GetBalance(user int32) (float32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	balance, ok := s.balances[user]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return balance, nil
+}
+
+func (s *MemStore) UpdateBalance(user datastore.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.balances[user.User]; !ok {
+		return datastore.ErrNoRowsUpdated
+	}
+	s.balances[user.User] = user.Balance
+	return nil
+}
+
+func (s *MemStore) GetBalances() ([]datastore.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var users []datastore.User
+	for user, balance := range s.balances {
+		users = append(users, datastore.User{User: user, Balance: balance})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].User < users[j].User })
+	return users, nil
+}
+
+func (s *MemStore) ReplaceBalances(users []datastore.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balances = make(map[int32]float32)
+	for _, user := range users {
+		s.balances[user.User] = user.Balance
+	}
+	return nil
+}
+
+func (s *MemStore) GetTransactionByTxnID(txnID string) (*common.TxnRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.transactions[txnID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return copyTxn(row.txn), nil
+}
+
+func (s *MemStore) InsertTransaction(txn *common.TxnRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transactions[txn.TxnID]; ok {
+		return errors.New("duplicate txn id " + txn.TxnID)
+	}
+
+	stored := copyTxn(txn)
+	stored.Batch = nil
+	if stored.CreatedAt == nil {
+		stored.CreatedAt = timestamppb.New(time.Now().Truncate(time.Microsecond))
+	}
+	s.nextID++
+	s.transactions[txn.TxnID] = &transaction{id: s.nextID, txn: stored}
+	return nil
+}
+
+func (s *MemStore) UpdateTransactionStatus(txn *common.TxnRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.updateTransactionStatus(txn)
+	return nil
+}
+
+func (s *MemStore) updateTransactionStatus(txn *common.TxnRequest) {
+	if row, ok := s.transactions[txn.TxnID]; ok {
+		row.txn.Status = txn.Status
+		row.txn.Error = txn.Error
+	}
+}
+
+func (s *MemStore) UpdateTransactionView(txn *common.TxnRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row, ok := s.transactions[txn.TxnID]; ok {
+		row.txn.SeqNo = txn.SeqNo
+		row.txn.ViewNo = txn.ViewNo
+	}
+	return nil
+}
+
+func (s *MemStore) DeleteTransaction(txnID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.transactions, txnID)
+	return nil
+}
+
+func (s *MemStore) GetFinishedTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error) {
+	return s.findTransactions(func(txn *common.TxnRequest) bool {
+		return txn.SeqNo > sequenceNumber && (txn.Status == "Executed" || txn.Status == "Aborted")
+	}, bySeqNo), nil
+}
+
+func (s *MemStore) GetExecutedTxns() ([]*common.TxnRequest, error) {
+	return s.findTransactions(func(txn *common.TxnRequest) bool {
+		return txn.Status == "Executed" && txn.Type != "Batch"
+	}, bySeqNo), nil
+}
+
+func (s *MemStore) GetPendingTransactions() ([]*common.TxnRequest, error) {
+	return s.findTransactions(func(txn *common.TxnRequest) bool {
+		return txn.Status == "Init" || txn.Status == "Pre-Prepared" || txn.Status == "Prepared"
+	}, bySeqNo), nil
+}
+
+func (s *MemStore) GetTransactionsAfterSequence(sequenceNumber int32) ([]*common.TxnRequest, error) {
+	return s.findTransactions(func(txn *common.TxnRequest) bool {
+		return txn.SeqNo > sequenceNumber
+	}, bySeqNo), nil
+}
+
+func (s *MemStore) GetTransactionsByBatchID(batchID string) ([]*common.TxnRequest, error) {
+	return s.findTransactions(func(txn *common.TxnRequest) bool {
+		return txn.BatchID == batchID
+	}, byID), nil
+}
+
+func (s *MemStore) DeleteTransactionsBeforeSequence(seqNo int32) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for txnID, row := range s.transactions {
+		if row.txn.SeqNo <= seqNo && isFinished(row.txn) {
+			s.outcomes[txnID] = datastore.TxnOutcome{
+				TxnID:  txnID,
+				SeqNo:  row.txn.SeqNo,
+				ViewNo: row.txn.ViewNo,
+				Status: row.txn.Status,
+				Error:  row.txn.Error,
+			}
+			delete(s.transactions, txnID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *MemStore) GetTxnOutcome(txnID string) (*datastore.TxnOutcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outcome, ok := s.outcomes[txnID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &outcome, nil
+}
+
+func (s *MemStore) InsertPBFTMessage(pbftMessage *common.PBFTMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, proto.Clone(pbftMessage).(*common.PBFTMessage))
+	return nil
+}
+
+func (s *MemStore) GetPBFTMessages(txnID, messagesType string) ([]*common.PBFTMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []*common.PBFTMessage
+	for _, message := range s.messages {
+		if message.TxnID == txnID && message.MessageType == messagesType {
+			messages = append(messages, proto.Clone(message).(*common.PBFTMessage))
+		}
+	}
+	return messages, nil
+}
+
+func (s *MemStore) GetPBFTMessageIDs(messageType string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, message := range s.messages {
+		if message.MessageType == messageType && !seen[message.TxnID] {
+			seen[message.TxnID] = true
+			ids = append(ids, message.TxnID)
+		}
+	}
+	return ids, nil
+}
+
+func (s *MemStore) DeletePBFTMessagesByByTxnID(txnID string) (int64, error) {
+	return s.deleteMessages(func(message *common.PBFTMessage) bool {
+		return message.TxnID == txnID
+	}), nil
+}
+
+func (s *MemStore) DeletePBFTMessagesBeforeSequence(seqNo int32) (int64, error) {
+	s.mu.Lock()
+	finished := make(map[string]bool)
+	for txnID, row := range s.transactions {
+		if row.txn.SeqNo <= seqNo && isFinished(row.txn) {
+			finished[txnID] = true
+		}
+	}
+	s.mu.Unlock()
+
+	return s.deleteMessages(func(message *common.PBFTMessage) bool {
+		return finished[message.TxnID]
+	}), nil
+}
+
+func (s *MemStore) InsertCheckpoint(checkpoint datastore.Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[checkpoint.SeqNo] = checkpoint
+	return nil
+}
+
+func (s *MemStore) GetCheckpoint(seqNo int32) (*datastore.Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[seqNo]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &checkpoint, nil
+}
+
+func (s *MemStore) DeleteCheckpointsBeforeSequence(seqNo int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for checkpointSeqNo := range s.checkpoints {
+		if checkpointSeqNo < seqNo {
+			delete(s.checkpoints, checkpointSeqNo)
+		}
+	}
+	return nil
+}
+
+func (s *MemStore) UpsertTwoPCState(state datastore.TwoPCState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.upsertTwoPCState(state)
+	return nil
+}
+
+// upsertTwoPCState never overwrites a decided outcome with an empty one, like the mysql upsert
+
+func (s *MemStore) upsertTwoPCState(state datastore.TwoPCState) {
+	existing, ok := s.twoPCStates[state.TxnID]
+	if !ok {
+		s.twoPCStates[state.TxnID] = &twoPCState{state: state, updatedAt: time.Now()}
+		return
+	}
+	if state.Outcome != "" {
+		existing.state.Outcome = state.Outcome
+	}
+	existing.updatedAt = time.Now()
+}
+
+func (s *MemStore) GetTwoPCState(txnID string) (*datastore.TwoPCState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.twoPCStates[txnID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	state := existing.state
+	return &state, nil
+}
+
+func (s *MemStore) GetUnfinishedTwoPCStates(before time.Time) ([]datastore.TwoPCState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var states []datastore.TwoPCState
+	for txnID, existing := range s.twoPCStates {
+		row, ok := s.transactions[txnID]
+		if !ok || row.txn.Status == "Executed" || row.txn.Status == "Aborted" || !existing.updatedAt.Before(before) {
+			continue
+		}
+		states = append(states, existing.state)
+	}
+	return states, nil
+}
+
+func (s *MemStore) UpsertClientReply(reply datastore.ClientReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replies[clientRequest{clientID: reply.ClientID, clientTimestamp: reply.ClientTimestamp}] = reply
+	return nil
+}
+
+func (s *MemStore) GetClientReply(clientID string, clientTimestamp int64) (*datastore.ClientReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reply, ok := s.replies[clientRequest{clientID: clientID, clientTimestamp: clientTimestamp}]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &reply, nil
+}
+
+func (s *MemStore) findTransactions(match func(txn *common.TxnRequest) bool, less func(a, b *transaction) bool) []*common.TxnRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var rows []*transaction
+	for _, row := range s.transactions {
+		if match(row.txn) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return less(rows[i], rows[j]) })
+
+	var transactions []*common.TxnRequest
+	for _, row := range rows {
+		transactions = append(transactions, copyTxn(row.txn))
+	}
+	return transactions
+}
+
+func (s *MemStore) deleteMessages(match func(message *common.PBFTMessage) bool) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []*common.PBFTMessage
+	for _, message := range s.messages {
+		if !match(message) {
+			kept = append(kept, message)
+		}
+	}
+	deleted := int64(len(s.messages) - len(kept))
+	s.messages = kept
+	return deleted
+}
+
+func bySeqNo(a, b *transaction) bool {
+	if a.txn.SeqNo != b.txn.SeqNo {
+		return a.txn.SeqNo < b.txn.SeqNo
+	}
+	return a.id < b.id
+}
+
+func byID(a, b *transaction) bool {
+	return a.id < b.id
+}
+
+func isFinished(txn *common.TxnRequest) bool {
+	return txn.Status == "Executed" || txn.Status == "Aborted" || txn.Status == "Failed"
+}
+
+func copyTxn(txn *common.TxnRequest) *common.TxnRequest {
+	return proto.Clone(txn).(*common.TxnRequest)
+}
@@ -0,0 +1,106 @@
+package memstore
+
+import (
+	"sort"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+)
+
+// State is a copy of everything a MemStore holds, stores that persist to disk snapshot it and restore it
+// on startup
+
+type State struct {
+	Balances     []datastore.User
+	Transactions []*common.TxnRequest
+	Outcomes     []datastore.TxnOutcome
+	Messages     []*common.PBFTMessage
+	Checkpoints  []datastore.Checkpoint
+	TwoPCStates  []datastore.TwoPCState
+	Replies      []datastore.ClientReply
+}
+
+func (s *MemStore) Snapshot() *State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := &State{}
+	for user, balance := range s.balances {
+		state.Balances = append(state.Balances, datastore.User{User: user, Balance: balance})
+	}
+	sort.Slice(state.Balances, func(i, j int) bool { return state.Balances[i].User < state.Balances[j].User })
+
+	var rows []*transaction
+	for _, row := range s.transactions {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return byID(rows[i], rows[j]) })
+	for _, row := range rows {
+		state.Transactions = append(state.Transactions, copyTxn(row.txn))
+	}
+
+	for _, outcome := range s.outcomes {
+		state.Outcomes = append(state.Outcomes, outcome)
+	}
+	sort.Slice(state.Outcomes, func(i, j int) bool { return state.Outcomes[i].TxnID < state.Outcomes[j].TxnID })
+
+	state.Messages = append(state.Messages, s.messages...)
+	for _, checkpoint := range s.checkpoints {
+		state.Checkpoints = append(state.Checkpoints, checkpoint)
+	}
+	for _, existing := range s.twoPCStates {
+		state.TwoPCStates = append(state.TwoPCStates, existing.state)
+	}
+	for _, reply := range s.replies {
+		state.Replies = append(state.Replies, reply)
+	}
+	return state
+}
+
+// Restore replaces the contents of the store with state, txns keep the order they had when it was taken
+
+func (s *MemStore) Restore(state *State) error {
+	err := s.ReplaceBalances(state.Balances)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.transactions = make(map[string]*transaction)
+	s.outcomes = make(map[string]datastore.TxnOutcome)
+	for _, outcome := range state.Outcomes {
+		s.outcomes[outcome.TxnID] = outcome
+	}
+	s.messages = nil
+	s.checkpoints = make(map[int32]datastore.Checkpoint)
+	s.twoPCStates = make(map[string]*twoPCState)
+	s.replies = make(map[clientRequest]datastore.ClientReply)
+	s.mu.Unlock()
+
+	for _, txn := range state.Transactions {
+		if err = s.InsertTransaction(txn); err != nil {
+			return err
+		}
+	}
+	for _, message := range state.Messages {
+		if err = s.InsertPBFTMessage(message); err != nil {
+			return err
+		}
+	}
+	for _, checkpoint := range state.Checkpoints {
+		if err = s.InsertCheckpoint(checkpoint); err != nil {
+			return err
+		}
+	}
+	for _, twoPCState := range state.TwoPCStates {
+		if err = s.UpsertTwoPCState(twoPCState); err != nil {
+			return err
+		}
+	}
+	for _, reply := range state.Replies {
+		if err = s.UpsertClientReply(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package memstore
+
+import (
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+	"GolandProjects/2pcbyz-gautamsardana/server/storage/datastore"
+)
+
+// UnitOfWork buffers the changes of a unit of work and applies them under the store lock once fn succeeds,
+// reads made while it runs see the store as it was before the unit started, with the statuses the unit
+// itself set on top
+
+type UnitOfWork struct {
+	store       *MemStore
+	deltas      []datastore.User
+	statuses    []*common.TxnRequest
+	twoPCStates []datastore.TwoPCState
+}
+
+// RunInUnitOfWork applies the changes made by fn, or none of them if fn fails
+
+func (s *MemStore) RunInUnitOfWork(fn func(uow datastore.UnitOfWork) error) error {
+	s.unitMu.Lock()
+	defer s.unitMu.Unlock()
+
+	uow := &UnitOfWork{store: s}
+	err := fn(uow)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delta := range uow.deltas {
+		if _, ok := s.balances[delta.User]; !ok {
+			return datastore.ErrNoRowsUpdated
+		}
+	}
+	for _, delta := range uow.deltas {
+		s.balances[delta.User] += delta.Balance
+	}
+	for _, txn := range uow.statuses {
+		s.updateTransactionStatus(txn)
+	}
+	for _, state := range uow.twoPCStates {
+		s.upsertTwoPCState(state)
+	}
+	return nil
+}
+
+func (uow *UnitOfWork) GetTransactionByTxnID(txnID string) (*common.TxnRequest, error) {
+	txn, err := uow.store.GetTransactionByTxnID(txnID)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range uow.statuses {
+		if status.TxnID == txnID {
+			txn.Status = status.Status
+			txn.Error = status.Error
+		}
+	}
+	return txn, nil
+}
+
+func (uow *UnitOfWork) AddToBalance(user int32, amount float32) error {
+	if _, err := uow.store.GetBalance(user); err != nil {
+		return datastore.ErrNoRowsUpdated
+	}
+	uow.deltas = append(uow.deltas, datastore.User{User: user, Balance: amount})
+	return nil
+}
+
+func (uow *UnitOfWork) UpdateTransactionStatus(txn *common.TxnRequest) error {
+	uow.statuses = append(uow.statuses, &common.TxnRequest{TxnID: txn.TxnID, Status: txn.Status, Error: txn.Error})
+	return nil
+}
+
+func (uow *UnitOfWork) UpsertTwoPCState(state datastore.TwoPCState) error {
+	uow.twoPCStates = append(uow.twoPCStates, state)
+	return nil
+}
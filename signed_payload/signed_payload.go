@@ -0,0 +1,40 @@
+package signed_payload
+
+import (
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// signed consensus payloads are the deterministic protobuf encoding of a typed message behind a version
+// byte, so a replica verifies exactly the bytes that were signed and the format can change behind a new
+// version. payloads of replicas still on the json encoding start with '{' and are decoded as before while
+// the cluster migrates
+
+const (
+	PayloadVersionProto byte = 1
+	payloadVersionJSON  byte = '{'
+)
+
+func Marshal(message proto.Message) ([]byte, error) {
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{PayloadVersionProto}, encoded...), nil
+}
+
+func Unmarshal(payload []byte, message proto.Message) error {
+	if len(payload) == 0 {
+		return errors.New("empty payload")
+	}
+
+	switch payload[0] {
+	case PayloadVersionProto:
+		return proto.Unmarshal(payload[1:], message)
+	case payloadVersionJSON:
+		return json.Unmarshal(payload, message)
+	}
+	return errors.New("unsupported payload version")
+}
@@ -0,0 +1,86 @@
+package signed_payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	common "GolandProjects/2pcbyz-gautamsardana/api_common"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	txn := &common.TxnRequest{
+		TxnID:    "txn-1",
+		Sender:   1,
+		Receiver: 2,
+		Amount:   3,
+		Legs:     []*common.TxnLeg{{Receiver: 2, Amount: 1}, {Receiver: 3, Amount: 2}},
+		ClientID: "client-1",
+	}
+
+	payload, err := Marshal(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload[0] != PayloadVersionProto {
+		t.Fatalf("payload starts with version %d, want %d", payload[0], PayloadVersionProto)
+	}
+
+	decoded := &common.TxnRequest{}
+	err = Unmarshal(payload, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(txn, decoded) {
+		t.Fatalf("decoded %v, want %v", decoded, txn)
+	}
+}
+
+func TestMarshalIsDeterministic(t *testing.T) {
+	statement := &common.SignedMessage{ViewNumber: 2, SequenceNumber: 7, Digest: "digest"}
+
+	payload, err := Marshal(statement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := Marshal(proto.Clone(statement))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(payload, again) {
+			t.Fatal("the same message marshaled to different payloads")
+		}
+	}
+}
+
+func TestUnmarshalLegacyJSON(t *testing.T) {
+	signedMessage := &common.SignedMessage{ViewNumber: 2, SequenceNumber: 7, Digest: "digest"}
+
+	// replicas that have not migrated yet sign the json encoding of the message
+	payload, err := json.Marshal(signedMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &common.SignedMessage{}
+	err = Unmarshal(payload, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(signedMessage, decoded) {
+		t.Fatalf("decoded %v, want %v", decoded, signedMessage)
+	}
+}
+
+func TestUnmarshalRejectsUnknownPayloads(t *testing.T) {
+	decoded := &common.SignedMessage{}
+	if err := Unmarshal(nil, decoded); err == nil {
+		t.Fatal("empty payload was accepted")
+	}
+	if err := Unmarshal([]byte{9, 1, 2}, decoded); err == nil {
+		t.Fatal("payload of an unknown version was accepted")
+	}
+}